@@ -61,8 +61,12 @@ func main() {
 		commands.ConvertCommand,
 		commands.ResampleCommand,
 		commands.MigrateCommand,
+		commands.BackupCommand,
+		commands.RestoreCommand,
 		commands.ConfigCommand,
 		commands.PasswdCommand,
+		commands.UsersCommand,
+		commands.TokensCommand,
 		commands.VersionCommand,
 		commands.StatusCommand,
 	}
@@ -211,15 +211,26 @@ export default class Session {
         );
     }
 
-    redeemToken(token) {
-        return Api.post("session", {token}).then(
+    redeemToken(token, password) {
+        return Api.post("session", {token, password}).then(
             (resp) => {
                 this.setConfig(resp.data.config);
                 this.setId(resp.data.id);
                 this.setData(resp.data.data);
                 this.sendClientInfo();
             }
-        );
+        ).catch((err) => {
+            // Ask for the password if the link is protected and try again.
+            if (err.response && err.response.status === 401 && err.response.data.password) {
+                const retry = window.prompt(err.response.data.error);
+
+                if (retry) {
+                    return this.redeemToken(token, retry);
+                }
+            }
+
+            return Promise.reject(err);
+        });
     }
 
     onLogout() {
@@ -77,8 +77,8 @@ export class Account extends RestModel {
         return Api.get(this.getEntityResource() + "/folders").then((response) => Promise.resolve(response.data));
     }
 
-    Share(photos, dest) {
-        const values = {Photos: photos, Destination: dest};
+    Share(photos, dest, albums) {
+        const values = {Photos: photos, Albums: albums ? albums : [], Destination: dest};
 
         return Api.post(this.getEntityResource() + "/share", values).then((response) => Promise.resolve(response.data));
     }
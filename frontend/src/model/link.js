@@ -46,6 +46,7 @@ export default class Link extends Model {
             HasPassword: false,
             CanComment: false,
             CanEdit: false,
+            CanUpload: false,
             CreatedAt: "",
             ModifiedAt: "",
         };
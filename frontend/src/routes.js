@@ -102,7 +102,7 @@ export default [
         path: "/albums",
         component: Albums,
         meta: {title: $gettext("Albums"), auth: true},
-        props: {view: "album", staticFilter: {type: "album"}},
+        props: {view: "album", staticFilter: {type: "album,smart"}},
     },
     {
         name: "album",
@@ -13,7 +13,7 @@ export default [
         path: "/s/:token",
         component: Albums,
         meta: {title: $gettext("Albums"), auth: true},
-        props: {view: "album", staticFilter: {type: "album"}},
+        props: {view: "album", staticFilter: {type: "album,smart"}},
     },
     {
         name: "album",
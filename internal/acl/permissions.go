@@ -5,15 +5,125 @@ var Permissions = ACL{
 		RoleAdmin: Actions{ActionDefault: true},
 	},
 	ResourceConfig: Roles{
+		RoleAdmin:  Actions{ActionDefault: true},
+		RoleFamily: Actions{ActionRead: true},
+		RoleFriend: Actions{ActionRead: true},
+		RoleChild:  Actions{ActionRead: true},
+		RoleGuest:  Actions{ActionRead: true},
+	},
+	ResourceSettings: Roles{
+		RoleAdmin:  Actions{ActionDefault: true},
+		RoleFamily: Actions{ActionRead: true},
+		RoleFriend: Actions{ActionRead: true},
+		RoleChild:  Actions{ActionRead: true},
+	},
+	ResourceLogs: Roles{
+		RoleAdmin: Actions{ActionDefault: true},
+	},
+	ResourceAccounts: Roles{
 		RoleAdmin: Actions{ActionDefault: true},
-		RoleGuest: Actions{ActionRead: true},
 	},
 	ResourceAlbums: Roles{
+		RoleAdmin:  Actions{ActionDefault: true},
+		RoleFamily: Actions{ActionSearch: true, ActionRead: true, ActionCreate: true, ActionUpdate: true, ActionDelete: true, ActionLike: true, ActionComment: true, ActionShare: true, ActionDownload: true, ActionExport: true},
+		RoleFriend: Actions{ActionSearch: true, ActionRead: true, ActionCreate: true, ActionUpdate: true, ActionDelete: true, ActionLike: true, ActionComment: true, ActionDownload: true},
+		RoleChild:  Actions{ActionSearch: true, ActionRead: true, ActionLike: true, ActionComment: true},
+		RoleGuest:  Actions{ActionSearch: true, ActionRead: true, ActionUpload: true, ActionComment: true},
+	},
+	ResourceCameras: Roles{
+		RoleAdmin:  Actions{ActionDefault: true},
+		RoleFamily: Actions{ActionSearch: true, ActionRead: true},
+		RoleFriend: Actions{ActionSearch: true, ActionRead: true},
+		RoleChild:  Actions{ActionSearch: true, ActionRead: true},
+	},
+	ResourceCategories: Roles{
+		RoleAdmin:  Actions{ActionDefault: true},
+		RoleFamily: Actions{ActionSearch: true, ActionRead: true},
+		RoleFriend: Actions{ActionSearch: true, ActionRead: true},
+		RoleChild:  Actions{ActionSearch: true, ActionRead: true},
+	},
+	ResourceComments: Roles{
+		RoleAdmin:  Actions{ActionDefault: true},
+		RoleFamily: Actions{ActionSearch: true, ActionRead: true, ActionDelete: true},
+		RoleFriend: Actions{ActionSearch: true, ActionRead: true, ActionDelete: true},
+		RoleChild:  Actions{ActionSearch: true, ActionRead: true, ActionDelete: true},
+		RoleGuest:  Actions{ActionSearch: true, ActionRead: true},
+	},
+	ResourceCountries: Roles{
+		RoleAdmin:  Actions{ActionDefault: true},
+		RoleFamily: Actions{ActionSearch: true, ActionRead: true},
+		RoleFriend: Actions{ActionSearch: true, ActionRead: true},
+		RoleChild:  Actions{ActionSearch: true, ActionRead: true},
+	},
+	ResourceEvents: Roles{
+		RoleAdmin:  Actions{ActionDefault: true},
+		RoleFamily: Actions{ActionRead: true},
+		RoleFriend: Actions{ActionRead: true},
+		RoleChild:  Actions{ActionRead: true},
+		RoleGuest:  Actions{ActionRead: true},
+	},
+	ResourceFiles: Roles{
+		RoleAdmin:  Actions{ActionDefault: true},
+		RoleFamily: Actions{ActionRead: true, ActionDownload: true},
+		RoleFriend: Actions{ActionRead: true, ActionDownload: true},
+		RoleChild:  Actions{ActionRead: true},
+	},
+	ResourceFaces: Roles{
+		RoleAdmin:  Actions{ActionDefault: true},
+		RoleFamily: Actions{ActionSearch: true, ActionRead: true, ActionUpdate: true},
+		RoleFriend: Actions{ActionSearch: true, ActionRead: true},
+	},
+	ResourceFolders: Roles{
 		RoleAdmin: Actions{ActionDefault: true},
-		RoleGuest: Actions{ActionSearch: true, ActionRead: true},
+	},
+	ResourceLabels: Roles{
+		RoleAdmin:  Actions{ActionDefault: true},
+		RoleFamily: Actions{ActionSearch: true, ActionRead: true, ActionUpdate: true},
+		RoleFriend: Actions{ActionSearch: true, ActionRead: true},
+		RoleChild:  Actions{ActionSearch: true, ActionRead: true},
+	},
+	ResourceLenses: Roles{
+		RoleAdmin:  Actions{ActionDefault: true},
+		RoleFamily: Actions{ActionSearch: true, ActionRead: true},
+		RoleFriend: Actions{ActionSearch: true, ActionRead: true},
+		RoleChild:  Actions{ActionSearch: true, ActionRead: true},
+	},
+	ResourceLinks: Roles{
+		RoleAdmin:  Actions{ActionDefault: true},
+		RoleFamily: Actions{ActionRead: true, ActionCreate: true, ActionUpdate: true, ActionDelete: true},
+	},
+	ResourceLocations: Roles{
+		RoleAdmin:  Actions{ActionDefault: true},
+		RoleFamily: Actions{ActionSearch: true, ActionRead: true},
+		RoleFriend: Actions{ActionSearch: true, ActionRead: true},
+		RoleChild:  Actions{ActionSearch: true, ActionRead: true},
+	},
+	ResourcePasswords: Roles{
+		RoleAdmin:  Actions{ActionDefault: true},
+		RoleFamily: Actions{ActionUpdateSelf: true},
+		RoleFriend: Actions{ActionUpdateSelf: true},
+		RoleChild:  Actions{ActionUpdateSelf: true},
+	},
+	ResourcePeople: Roles{
+		RoleAdmin:  Actions{ActionDefault: true},
+		RoleFamily: Actions{ActionRead: true, ActionUpdateSelf: true},
+		RoleFriend: Actions{ActionRead: true, ActionUpdateSelf: true},
+		RoleChild:  Actions{ActionRead: true, ActionUpdateSelf: true},
 	},
 	ResourcePhotos: Roles{
+		RoleAdmin:  Actions{ActionDefault: true},
+		RoleFamily: Actions{ActionSearch: true, ActionRead: true, ActionUpdate: true, ActionDelete: true, ActionPrivate: true, ActionUpload: true, ActionImport: true, ActionDownload: true, ActionShare: true, ActionLike: true, ActionComment: true, ActionExport: true},
+		RoleFriend: Actions{ActionSearch: true, ActionRead: true, ActionUpdate: true, ActionUpload: true, ActionImport: true, ActionDownload: true, ActionLike: true, ActionComment: true},
+		RoleChild:  Actions{ActionSearch: true, ActionRead: true, ActionLike: true, ActionComment: true},
+		RoleGuest:  Actions{ActionSearch: true, ActionRead: true, ActionComment: true},
+	},
+	ResourcePlaces: Roles{
+		RoleAdmin:  Actions{ActionDefault: true},
+		RoleFamily: Actions{ActionSearch: true, ActionRead: true},
+		RoleFriend: Actions{ActionSearch: true, ActionRead: true},
+		RoleChild:  Actions{ActionSearch: true, ActionRead: true},
+	},
+	ResourceWebhooks: Roles{
 		RoleAdmin: Actions{ActionDefault: true},
-		RoleGuest: Actions{ActionSearch: true, ActionRead: true},
 	},
 }
@@ -50,3 +50,67 @@ func TestACL_Deny(t *testing.T) {
 		assert.True(t, Permissions.Deny(ResourceAlbums, RoleGuest, ActionDefault))
 	})
 }
+
+func TestPermissions(t *testing.T) {
+	restricted := []Role{RoleFamily, RoleFriend, RoleChild, RoleGuest}
+	manage := []Action{ActionSearch, ActionCreate, ActionRead, ActionUpdate, ActionDelete, ActionUpload, ActionDownload}
+
+	t.Run("admin only", func(t *testing.T) {
+		for _, resource := range []Resource{ResourceAccounts, ResourceFolders, ResourceLogs, ResourceWebhooks} {
+			for _, role := range restricted {
+				for _, action := range manage {
+					assert.True(t, Permissions.Deny(resource, role, action), "%s/%s/%s", resource, role, action)
+				}
+			}
+
+			assert.True(t, Permissions.Allow(resource, RoleAdmin, ActionUpdate))
+		}
+	})
+	t.Run("settings and config", func(t *testing.T) {
+		for _, role := range restricted {
+			assert.True(t, Permissions.Deny(ResourceSettings, role, ActionUpdate))
+			assert.True(t, Permissions.Deny(ResourceConfig, role, ActionUpdate))
+		}
+	})
+	t.Run("people and passwords", func(t *testing.T) {
+		for _, role := range restricted {
+			for _, action := range []Action{ActionSearch, ActionCreate, ActionUpdate, ActionDelete} {
+				assert.True(t, Permissions.Deny(ResourcePeople, role, action), "%s/%s", role, action)
+			}
+
+			assert.True(t, Permissions.Deny(ResourcePasswords, role, ActionUpdate))
+		}
+	})
+	t.Run("guest", func(t *testing.T) {
+		for _, resource := range []Resource{ResourcePhotos, ResourceAlbums, ResourceLabels, ResourceLinks, ResourceComments, ResourceFiles} {
+			for _, action := range []Action{ActionCreate, ActionUpdate, ActionDelete, ActionShare, ActionPrivate, ActionImport} {
+				assert.True(t, Permissions.Deny(resource, RoleGuest, action), "%s/%s", resource, action)
+			}
+		}
+
+		assert.True(t, Permissions.Deny(ResourcePhotos, RoleGuest, ActionDownload))
+	})
+	t.Run("friend", func(t *testing.T) {
+		for _, action := range []Action{ActionDelete, ActionPrivate, ActionShare, ActionExport} {
+			assert.True(t, Permissions.Deny(ResourcePhotos, RoleFriend, action), "photos/%s", action)
+		}
+
+		for _, action := range []Action{ActionCreate, ActionUpdate, ActionDelete} {
+			assert.True(t, Permissions.Deny(ResourceLinks, RoleFriend, action), "links/%s", action)
+			assert.True(t, Permissions.Deny(ResourceLabels, RoleFriend, action), "labels/%s", action)
+		}
+	})
+	t.Run("child", func(t *testing.T) {
+		for _, action := range []Action{ActionUpdate, ActionDelete, ActionUpload, ActionImport, ActionDownload, ActionShare} {
+			assert.True(t, Permissions.Deny(ResourcePhotos, RoleChild, action), "photos/%s", action)
+			assert.True(t, Permissions.Deny(ResourceAlbums, RoleChild, action), "albums/%s", action)
+		}
+	})
+	t.Run("unknown role", func(t *testing.T) {
+		for _, resource := range []Resource{ResourcePhotos, ResourceAlbums, ResourceConfig, ResourceAccounts} {
+			for _, action := range manage {
+				assert.True(t, Permissions.Deny(resource, RoleDefault, action), "%s/%s", resource, action)
+			}
+		}
+	})
+}
@@ -11,7 +11,10 @@ const (
 	ResourceAlbums     Resource = "albums"
 	ResourceCameras    Resource = "cameras"
 	ResourceCategories Resource = "categories"
+	ResourceComments   Resource = "comments"
 	ResourceCountries  Resource = "countries"
+	ResourceEvents     Resource = "events"
+	ResourceFaces      Resource = "faces"
 	ResourceFiles      Resource = "files"
 	ResourceFolders    Resource = "folders"
 	ResourceLabels     Resource = "labels"
@@ -22,4 +25,5 @@ const (
 	ResourcePeople     Resource = "people"
 	ResourcePhotos     Resource = "photos"
 	ResourcePlaces     Resource = "places"
+	ResourceWebhooks   Resource = "webhooks"
 )
@@ -22,14 +22,7 @@ import (
 
 // GET /api/v1/accounts
 func GetAccounts(router *gin.RouterGroup) {
-	router.GET("/accounts", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourceAccounts, acl.ActionSearch)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
-
+	router.GET("/accounts", Authorize(acl.ResourceAccounts, acl.ActionSearch), func(c *gin.Context) {
 		var f form.AccountSearch
 
 		err := c.MustBindWith(&f, binding.Form)
@@ -59,14 +52,7 @@ func GetAccounts(router *gin.RouterGroup) {
 // Parameters:
 //   id: string Account ID as returned by the API
 func GetAccount(router *gin.RouterGroup) {
-	router.GET("/accounts/:id", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourceAccounts, acl.ActionRead)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
-
+	router.GET("/accounts/:id", Authorize(acl.ResourceAccounts, acl.ActionRead), func(c *gin.Context) {
 		id := ParseUint(c.Param("id"))
 
 		if m, err := query.AccountByID(id); err == nil {
@@ -82,14 +68,7 @@ func GetAccount(router *gin.RouterGroup) {
 // Parameters:
 //   id: string Account ID as returned by the API
 func GetAccountFolders(router *gin.RouterGroup) {
-	router.GET("/accounts/:id/folders", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourceAccounts, acl.ActionRead)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
-
+	router.GET("/accounts/:id/folders", Authorize(acl.ResourceAccounts, acl.ActionRead), func(c *gin.Context) {
 		start := time.Now()
 		id := ParseUint(c.Param("id"))
 		cache := service.Cache()
@@ -136,14 +115,7 @@ func GetAccountFolders(router *gin.RouterGroup) {
 // Parameters:
 //   id: string Account ID as returned by the API
 func ShareWithAccount(router *gin.RouterGroup) {
-	router.POST("/accounts/:id/share", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourceAccounts, acl.ActionUpload)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
-
+	router.POST("/accounts/:id/share", Authorize(acl.ResourceAccounts, acl.ActionUpload), func(c *gin.Context) {
 		id := ParseUint(c.Param("id"))
 
 		m, err := query.AccountByID(id)
@@ -161,6 +133,29 @@ func ShareWithAccount(router *gin.RouterGroup) {
 		}
 
 		dst := f.Destination
+
+		// Shared albums are resolved on the server, including smart albums.
+		if len(f.Albums) > 0 {
+			sel := form.Selection{Albums: f.Albums}
+
+			// Users without admin role may only share their own and shared albums.
+			if s := AuthSession(c); s.Restricted() {
+				sel.Owner = s.User.PersonUID
+				sel.Shared = s.Shares.String()
+			}
+
+			photos, err := query.FileSelection(sel)
+
+			if err != nil {
+				AbortEntityNotFound(c)
+				return
+			}
+
+			for _, file := range photos {
+				f.Photos = append(f.Photos, file.PhotoUID)
+			}
+		}
+
 		files, err := query.FilesByUID(f.Photos, 1000, 0)
 
 		if err != nil {
@@ -183,14 +178,7 @@ func ShareWithAccount(router *gin.RouterGroup) {
 
 // POST /api/v1/accounts
 func CreateAccount(router *gin.RouterGroup) {
-	router.POST("/accounts", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourceAccounts, acl.ActionCreate)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
-
+	router.POST("/accounts", Authorize(acl.ResourceAccounts, acl.ActionCreate), func(c *gin.Context) {
 		var f form.Account
 
 		if err := c.BindJSON(&f); err != nil {
@@ -225,14 +213,7 @@ func CreateAccount(router *gin.RouterGroup) {
 // Parameters:
 //   id: string Account ID as returned by the API
 func UpdateAccount(router *gin.RouterGroup) {
-	router.PUT("/accounts/:id", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourceAccounts, acl.ActionUpdate)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
-
+	router.PUT("/accounts/:id", Authorize(acl.ResourceAccounts, acl.ActionUpdate), func(c *gin.Context) {
 		id := ParseUint(c.Param("id"))
 
 		m, err := query.AccountByID(id)
@@ -283,14 +264,7 @@ func UpdateAccount(router *gin.RouterGroup) {
 // Parameters:
 //   id: string Account ID as returned by the API
 func DeleteAccount(router *gin.RouterGroup) {
-	router.DELETE("/accounts/:id", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourceAccounts, acl.ActionDelete)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
-
+	router.DELETE("/accounts/:id", Authorize(acl.ResourceAccounts, acl.ActionDelete), func(c *gin.Context) {
 		id := ParseUint(c.Param("id"))
 
 		m, err := query.AccountByID(id)
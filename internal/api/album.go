@@ -31,13 +31,8 @@ import (
 
 // GET /api/v1/albums
 func GetAlbums(router *gin.RouterGroup) {
-	router.GET("/albums", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourceAlbums, acl.ActionSearch)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
+	router.GET("/albums", Authorize(acl.ResourceAlbums, acl.ActionSearch), func(c *gin.Context) {
+		s := AuthSession(c)
 
 		var f form.AlbumSearch
 
@@ -51,6 +46,9 @@ func GetAlbums(router *gin.RouterGroup) {
 		// Guest permissions are limited to shared albums.
 		if s.Guest() {
 			f.ID = s.Shares.String()
+		} else if s.Restricted() {
+			f.Owner = s.User.PersonUID
+			f.Shared = s.Shares.String()
 		}
 
 		result, err := query.AlbumSearch(f)
@@ -70,18 +68,13 @@ func GetAlbums(router *gin.RouterGroup) {
 
 // GET /api/v1/albums/:uid
 func GetAlbum(router *gin.RouterGroup) {
-	router.GET("/albums/:uid", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourceAlbums, acl.ActionRead)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
+	router.GET("/albums/:uid", Authorize(acl.ResourceAlbums, acl.ActionRead), func(c *gin.Context) {
+		s := AuthSession(c)
 
 		id := c.Param("uid")
 		m, err := query.AlbumByUID(id)
 
-		if err != nil {
+		if err != nil || !s.Owns(m.OwnerUID) && !s.HasShare(m.AlbumUID) {
 			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
 			return
 		}
@@ -92,13 +85,8 @@ func GetAlbum(router *gin.RouterGroup) {
 
 // POST /api/v1/albums
 func CreateAlbum(router *gin.RouterGroup) {
-	router.POST("/albums", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourceAlbums, acl.ActionCreate)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
+	router.POST("/albums", Authorize(acl.ResourceAlbums, acl.ActionCreate), func(c *gin.Context) {
+		s := AuthSession(c)
 
 		var f form.Album
 
@@ -107,8 +95,24 @@ func CreateAlbum(router *gin.RouterGroup) {
 			return
 		}
 
-		m := entity.NewAlbum(f.AlbumTitle, entity.AlbumDefault)
+		var m *entity.Album
+
+		if f.AlbumType == entity.AlbumSmart {
+			filter, err := form.SmartAlbumFilter(f.AlbumFilter)
+
+			if err != nil {
+				log.Debugf("album: %s", err)
+				AbortBadRequest(c)
+				return
+			}
+
+			m = entity.NewSmartAlbum(f.AlbumTitle, filter)
+		} else {
+			m = entity.NewAlbum(f.AlbumTitle, entity.AlbumDefault)
+		}
+
 		m.AlbumFavorite = f.AlbumFavorite
+		m.OwnerUID = s.User.PersonUID
 
 		log.Debugf("album: creating %+v %+v", f, m)
 
@@ -129,18 +133,13 @@ func CreateAlbum(router *gin.RouterGroup) {
 
 // PUT /api/v1/albums/:uid
 func UpdateAlbum(router *gin.RouterGroup) {
-	router.PUT("/albums/:uid", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourceAlbums, acl.ActionUpdate)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
+	router.PUT("/albums/:uid", Authorize(acl.ResourceAlbums, acl.ActionUpdate), func(c *gin.Context) {
+		s := AuthSession(c)
 
 		uid := c.Param("uid")
 		m, err := query.AlbumByUID(uid)
 
-		if err != nil {
+		if err != nil || !s.Owns(m.OwnerUID) {
 			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
 			return
 		}
@@ -159,6 +158,21 @@ func UpdateAlbum(router *gin.RouterGroup) {
 			return
 		}
 
+		// Users without admin role must not change the album type or search filter.
+		if s.Restricted() && (f.AlbumType != m.AlbumType || f.AlbumFilter != m.AlbumFilter) {
+			AbortForbidden(c)
+			return
+		}
+
+		// The search filter of smart albums can be changed, but must be valid.
+		if f.AlbumType == entity.AlbumSmart {
+			if f.AlbumFilter, err = form.SmartAlbumFilter(f.AlbumFilter); err != nil {
+				log.Debugf("album: %s", err)
+				AbortBadRequest(c)
+				return
+			}
+		}
+
 		if err := m.SaveForm(f); err != nil {
 			log.Error(err)
 			AbortSaveFailed(c)
@@ -177,20 +191,15 @@ func UpdateAlbum(router *gin.RouterGroup) {
 
 // DELETE /api/v1/albums/:uid
 func DeleteAlbum(router *gin.RouterGroup) {
-	router.DELETE("/albums/:uid", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourceAlbums, acl.ActionDelete)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
+	router.DELETE("/albums/:uid", Authorize(acl.ResourceAlbums, acl.ActionDelete), func(c *gin.Context) {
+		s := AuthSession(c)
 
 		conf := service.Config()
 		id := c.Param("uid")
 
 		m, err := query.AlbumByUID(id)
 
-		if err != nil {
+		if err != nil || !s.Owns(m.OwnerUID) {
 			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
 			return
 		}
@@ -212,19 +221,14 @@ func DeleteAlbum(router *gin.RouterGroup) {
 // Parameters:
 //   uid: string Album UID
 func LikeAlbum(router *gin.RouterGroup) {
-	router.POST("/albums/:uid/like", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourceAlbums, acl.ActionLike)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
+	router.POST("/albums/:uid/like", Authorize(acl.ResourceAlbums, acl.ActionLike), func(c *gin.Context) {
+		s := AuthSession(c)
 
 		conf := service.Config()
 		id := c.Param("uid")
 		album, err := query.AlbumByUID(id)
 
-		if err != nil {
+		if err != nil || !s.Owns(album.OwnerUID) {
 			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
 			return
 		}
@@ -232,6 +236,10 @@ func LikeAlbum(router *gin.RouterGroup) {
 		album.AlbumFavorite = true
 		conf.Db().Save(&album)
 
+		if err := entity.AddLike(album.AlbumUID, s.User); err != nil {
+			log.Errorf("album: %s", err)
+		}
+
 		UpdateClientConfig()
 		PublishAlbumEvent(EntityUpdated, id, c)
 
@@ -244,19 +252,14 @@ func LikeAlbum(router *gin.RouterGroup) {
 // Parameters:
 //   uid: string Album UID
 func DislikeAlbum(router *gin.RouterGroup) {
-	router.DELETE("/albums/:uid/like", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourceAlbums, acl.ActionLike)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
+	router.DELETE("/albums/:uid/like", Authorize(acl.ResourceAlbums, acl.ActionLike), func(c *gin.Context) {
+		s := AuthSession(c)
 
 		conf := service.Config()
 		id := c.Param("uid")
 		album, err := query.AlbumByUID(id)
 
-		if err != nil {
+		if err != nil || !s.Owns(album.OwnerUID) {
 			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
 			return
 		}
@@ -264,6 +267,10 @@ func DislikeAlbum(router *gin.RouterGroup) {
 		album.AlbumFavorite = false
 		conf.Db().Save(&album)
 
+		if err := entity.RemoveLike(album.AlbumUID, s.User); err != nil {
+			log.Errorf("album: %s", err)
+		}
+
 		UpdateClientConfig()
 		PublishAlbumEvent(EntityUpdated, id, c)
 
@@ -273,17 +280,12 @@ func DislikeAlbum(router *gin.RouterGroup) {
 
 // POST /api/v1/albums/:uid/clone
 func CloneAlbums(router *gin.RouterGroup) {
-	router.POST("/albums/:uid/clone", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourceAlbums, acl.ActionUpdate)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
+	router.POST("/albums/:uid/clone", Authorize(acl.ResourceAlbums, acl.ActionUpdate), func(c *gin.Context) {
+		s := AuthSession(c)
 
 		a, err := query.AlbumByUID(c.Param("uid"))
 
-		if err != nil {
+		if err != nil || !s.Owns(a.OwnerUID) {
 			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
 			return
 		}
@@ -303,6 +305,9 @@ func CloneAlbums(router *gin.RouterGroup) {
 			if err != nil {
 				log.Errorf("album: %s", err)
 				continue
+			} else if !s.Owns(cloneAlbum.OwnerUID) && !s.HasShare(cloneAlbum.AlbumUID) {
+				log.Errorf("album: %s not found", txt.Quote(uid))
+				continue
 			}
 
 			photos, err := query.AlbumPhotos(cloneAlbum, 10000)
@@ -312,7 +317,16 @@ func CloneAlbums(router *gin.RouterGroup) {
 				continue
 			}
 
-			added = append(added, a.AddPhotos(photos.UIDs())...)
+			var uids []string
+
+			// Users without admin role may only add their own photos.
+			for _, p := range photos {
+				if s.Owns(p.OwnerUID) {
+					uids = append(uids, p.PhotoUID)
+				}
+			}
+
+			added = append(added, a.AddPhotos(uids)...)
 		}
 
 		if len(added) > 0 {
@@ -327,13 +341,8 @@ func CloneAlbums(router *gin.RouterGroup) {
 
 // POST /api/v1/albums/:uid/photos
 func AddPhotosToAlbum(router *gin.RouterGroup) {
-	router.POST("/albums/:uid/photos", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourceAlbums, acl.ActionUpdate)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
+	router.POST("/albums/:uid/photos", Authorize(acl.ResourceAlbums, acl.ActionUpdate), func(c *gin.Context) {
+		s := AuthSession(c)
 
 		var f form.Selection
 
@@ -345,12 +354,18 @@ func AddPhotosToAlbum(router *gin.RouterGroup) {
 		uid := c.Param("uid")
 		a, err := query.AlbumByUID(uid)
 
-		if err != nil {
+		if err != nil || !s.Owns(a.OwnerUID) {
 			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
 			return
 		}
 
-		photos, err := query.PhotoSelection(f)
+		// Users without admin role may only select their own and shared photos.
+		if s.Restricted() {
+			f.Owner = s.User.PersonUID
+			f.Shared = s.Shares.String()
+		}
+
+		selection, err := query.PhotoSelection(f)
 
 		if err != nil {
 			log.Errorf("album: %s", err)
@@ -358,6 +373,20 @@ func AddPhotosToAlbum(router *gin.RouterGroup) {
 			return
 		}
 
+		var photos entity.Photos
+
+		// Users without admin role may only add their own photos.
+		for _, p := range selection {
+			if s.Owns(p.OwnerUID) {
+				photos = append(photos, p)
+			}
+		}
+
+		if len(photos) == 0 {
+			AbortEntityNotFound(c)
+			return
+		}
+
 		added := a.AddPhotos(photos.UIDs())
 
 		if len(added) > 0 {
@@ -376,13 +405,8 @@ func AddPhotosToAlbum(router *gin.RouterGroup) {
 
 // DELETE /api/v1/albums/:uid/photos
 func RemovePhotosFromAlbum(router *gin.RouterGroup) {
-	router.DELETE("/albums/:uid/photos", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourceAlbums, acl.ActionUpdate)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
+	router.DELETE("/albums/:uid/photos", Authorize(acl.ResourceAlbums, acl.ActionUpdate), func(c *gin.Context) {
+		s := AuthSession(c)
 
 		var f form.Selection
 
@@ -398,7 +422,7 @@ func RemovePhotosFromAlbum(router *gin.RouterGroup) {
 
 		a, err := query.AlbumByUID(c.Param("uid"))
 
-		if err != nil {
+		if err != nil || !s.Owns(a.OwnerUID) {
 			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
 			return
 		}
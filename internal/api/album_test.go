@@ -63,6 +63,20 @@ func TestCreateAlbum(t *testing.T) {
 		r := PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"Title": 333, "Description": "Created via unit test", "Notes": "", "Favorite": true}`)
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
+	t.Run("smart album", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateAlbum(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"Title": "Smart Cats", "Type": "smart", "Filter": "label:cat"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "smart", gjson.Get(r.Body.String(), "Type").String())
+		assert.Equal(t, "label:cat", gjson.Get(r.Body.String(), "Filter").String())
+	})
+	t.Run("smart album without filter", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateAlbum(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"Title": "Smart Cats", "Type": "smart", "Filter": ""}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
 }
 func TestUpdateAlbum(t *testing.T) {
 	app, router, _ := NewApiTest()
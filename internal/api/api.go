@@ -33,6 +33,8 @@ package api
 
 import (
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/photoprism/photoprism/internal/event"
@@ -74,10 +76,20 @@ func Error(c *gin.Context, code int, err error, id i18n.Message, params ...inter
 	c.AbortWithStatusJSON(code, resp)
 }
 
+// AbortTooManyRequests aborts with status 429 and a Retry-After header after too many failed attempts.
+func AbortTooManyRequests(c *gin.Context, wait time.Duration) {
+	c.Header("Retry-After", strconv.Itoa(int(wait.Seconds())+1))
+	Abort(c, http.StatusTooManyRequests, i18n.ErrTooManyRequests)
+}
+
 func AbortUnauthorized(c *gin.Context) {
 	Abort(c, http.StatusUnauthorized, i18n.ErrUnauthorized)
 }
 
+func AbortForbidden(c *gin.Context) {
+	Abort(c, http.StatusForbidden, i18n.ErrForbidden)
+}
+
 func AbortEntityNotFound(c *gin.Context) {
 	Abort(c, http.StatusNotFound, i18n.ErrEntityNotFound)
 }
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GET /api/v1/users/:uid/tokens
+//
+// Parameters:
+//   uid: string User UID as returned by the API
+func GetAppTokens(router *gin.RouterGroup) {
+	router.GET("/users/:uid/tokens", Authorize(acl.ResourcePeople, acl.ActionUpdateSelf), func(c *gin.Context) {
+		s := AuthSession(c)
+		uid := c.Param("uid")
+
+		if !s.User.Admin() && s.User.PersonUID != uid {
+			Abort(c, http.StatusNotFound, i18n.ErrUserNotFound)
+			return
+		}
+
+		c.JSON(http.StatusOK, entity.FindUserAppTokens(uid))
+	})
+}
+
+// POST /api/v1/users/:uid/tokens
+//
+// Parameters:
+//   uid: string User UID as returned by the API
+func CreateAppToken(router *gin.RouterGroup) {
+	router.POST("/users/:uid/tokens", Authorize(acl.ResourcePeople, acl.ActionUpdateSelf), func(c *gin.Context) {
+		s := AuthSession(c)
+		uid := c.Param("uid")
+
+		if m := entity.FindPersonByUID(uid); m == nil || !m.Registered() || !s.User.Admin() && s.User.PersonUID != uid {
+			Abort(c, http.StatusNotFound, i18n.ErrUserNotFound)
+			return
+		}
+
+		var f form.AppToken
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		m, secret, err := entity.CreateAppToken(uid, f.Name, f.Scope, f.Expires)
+
+		if err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrSaveFailed)
+			return
+		}
+
+		log.Infof("token: created %s for %s", txt.Quote(m.TokenName), uid)
+
+		// The secret is only returned once and can't be restored later.
+		c.JSON(http.StatusOK, gin.H{"token": m, "secret": secret})
+	})
+}
+
+// DELETE /api/v1/users/:uid/tokens/:tid
+//
+// Parameters:
+//   uid: string User UID as returned by the API
+//   tid: string Token UID as returned by the API
+func DeleteAppToken(router *gin.RouterGroup) {
+	router.DELETE("/users/:uid/tokens/:tid", Authorize(acl.ResourcePeople, acl.ActionUpdateSelf), func(c *gin.Context) {
+		s := AuthSession(c)
+		uid := c.Param("uid")
+
+		if !s.User.Admin() && s.User.PersonUID != uid {
+			Abort(c, http.StatusNotFound, i18n.ErrUserNotFound)
+			return
+		}
+
+		m := entity.FindAppTokenByUID(uid, c.Param("tid"))
+
+		if m == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		if err := m.Delete(); err != nil {
+			Error(c, http.StatusInternalServerError, err, i18n.ErrDeleteFailed)
+			return
+		}
+
+		log.Infof("token: deleted %s", txt.Quote(m.TokenName))
+
+		c.JSON(http.StatusOK, m)
+	})
+}
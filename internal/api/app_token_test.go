@@ -0,0 +1,82 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestCreateAppToken(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateAppToken(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/users/"+entity.Admin.PersonUID+"/tokens", `{"Name": "Backup", "Scope": "read"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Backup", gjson.Get(r.Body.String(), "token.Name").String())
+		assert.Equal(t, 48, len(gjson.Get(r.Body.String(), "secret").String()))
+		assert.False(t, gjson.Get(r.Body.String(), "token.TokenHash").Exists())
+	})
+	t.Run("invalid scope", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateAppToken(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/users/"+entity.Admin.PersonUID+"/tokens", `{"Name": "Backup", "Scope": "xxx"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("user not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateAppToken(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/users/uxxx/tokens", `{"Name": "Backup"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestGetAppTokens(t *testing.T) {
+	if _, _, err := entity.CreateAppToken(entity.Admin.PersonUID, "List", entity.TokenScopeUpload, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	app, router, _ := NewApiTest()
+	GetAppTokens(router)
+	r := PerformRequest(app, "GET", "/api/v1/users/"+entity.Admin.PersonUID+"/tokens")
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Equal(t, "upload", gjson.Get(r.Body.String(), "#(Name=\"List\").Scope").String())
+}
+
+func TestDeleteAppToken(t *testing.T) {
+	m, secret, err := entity.CreateAppToken(entity.Admin.PersonUID, "Delete", entity.TokenScopeAll, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		DeleteAppToken(router)
+		r := PerformRequest(app, "DELETE", "/api/v1/users/"+entity.Admin.PersonUID+"/tokens/"+m.TokenUID)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Nil(t, entity.FindAppToken(secret))
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		DeleteAppToken(router)
+		r := PerformRequest(app, "DELETE", "/api/v1/users/"+entity.Admin.PersonUID+"/tokens/"+m.TokenUID)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestTokenSession(t *testing.T) {
+	m, _, err := entity.CreateAppToken(entity.Admin.PersonUID, "Session", entity.TokenScopeRead, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sess := TokenSession(m)
+
+	assert.True(t, sess.Valid())
+	assert.Equal(t, entity.Admin.PersonUID, sess.User.PersonUID)
+	assert.NotNil(t, m.UsedAt)
+}
@@ -11,18 +11,30 @@ import (
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/session"
 )
 
-// POST /api/v1/batch/photos/archive
-func BatchPhotosArchive(router *gin.RouterGroup) {
-	router.POST("/batch/photos/archive", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionDelete)
+// ownedPhotos returns the UIDs of selected photos the session user may change.
+func ownedPhotos(s session.Data, uids []string) ([]string, error) {
+	if !s.Restricted() {
+		return uids, nil
+	}
 
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
+	return query.OwnedPhotoUIDs(uids, s.User.PersonUID)
+}
+
+// ownedAlbums returns the UIDs of selected albums the session user may change.
+func ownedAlbums(s session.Data, uids []string) ([]string, error) {
+	if !s.Restricted() {
+		return uids, nil
+	}
+
+	return query.OwnedAlbumUIDs(uids, s.User.PersonUID)
+}
 
+// POST /api/v1/batch/photos/archive
+func BatchPhotosArchive(router *gin.RouterGroup) {
+	router.POST("/batch/photos/archive", Authorize(acl.ResourcePhotos, acl.ActionDelete), func(c *gin.Context) {
 		var f form.Selection
 
 		if err := c.BindJSON(&f); err != nil {
@@ -35,41 +47,51 @@ func BatchPhotosArchive(router *gin.RouterGroup) {
 			return
 		}
 
-		log.Infof("archive: adding %s", f.String())
+		var err error
 
-		// Soft delete by setting deleted_at to current date.
-		err := entity.Db().Where("photo_uid IN (?)", f.Photos).Delete(&entity.Photo{}).Error
+		// Users without admin role may only archive their own photos.
+		if f.Photos, err = ownedPhotos(AuthSession(c), f.Photos); err != nil || len(f.Photos) == 0 {
+			AbortEntityNotFound(c)
+			return
+		}
 
-		if err != nil {
+		log.Infof("archive: adding %s", f.String())
+
+		if err := archivePhotos(f.Photos); err != nil {
 			AbortSaveFailed(c)
 			return
 		}
 
-		// Remove archived photos from albums.
-		logError("archive", entity.Db().Model(&entity.PhotoAlbum{}).Where("photo_uid IN (?)", f.Photos).UpdateColumn("hidden", true).Error)
+		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgSelectionArchived))
+	})
+}
 
-		if err := entity.UpdatePhotoCounts(); err != nil {
-			log.Errorf("photos: %s", err)
-		}
+// archivePhotos moves photos to the archive and notifies clients.
+func archivePhotos(uids []string) error {
+	// Soft delete by setting deleted_at to current date.
+	if err := entity.Db().Where("photo_uid IN (?)", uids).Delete(&entity.Photo{}).Error; err != nil {
+		return err
+	}
 
-		UpdateClientConfig()
+	// Remove archived photos from albums.
+	logError("archive", entity.Db().Model(&entity.PhotoAlbum{}).Where("photo_uid IN (?)", uids).UpdateColumn("hidden", true).Error)
 
-		event.EntitiesArchived("photos", f.Photos)
+	if err := entity.UpdatePhotoCounts(); err != nil {
+		log.Errorf("photos: %s", err)
+	}
 
-		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgSelectionArchived))
-	})
+	query.FlushDuplicates()
+
+	UpdateClientConfig()
+
+	event.EntitiesArchived("photos", uids)
+
+	return nil
 }
 
 // POST /api/v1/batch/photos/restore
 func BatchPhotosRestore(router *gin.RouterGroup) {
-	router.POST("/batch/photos/restore", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionDelete)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
-
+	router.POST("/batch/photos/restore", Authorize(acl.ResourcePhotos, acl.ActionDelete), func(c *gin.Context) {
 		var f form.Selection
 
 		if err := c.BindJSON(&f); err != nil {
@@ -82,9 +104,17 @@ func BatchPhotosRestore(router *gin.RouterGroup) {
 			return
 		}
 
+		var err error
+
+		// Users without admin role may only restore their own photos.
+		if f.Photos, err = ownedPhotos(AuthSession(c), f.Photos); err != nil || len(f.Photos) == 0 {
+			AbortEntityNotFound(c)
+			return
+		}
+
 		log.Infof("archive: restoring %s", f.String())
 
-		err := entity.Db().Unscoped().Model(&entity.Photo{}).Where("photo_uid IN (?)", f.Photos).
+		err = entity.Db().Unscoped().Model(&entity.Photo{}).Where("photo_uid IN (?)", f.Photos).
 			UpdateColumn("deleted_at", gorm.Expr("NULL")).Error
 
 		if err != nil {
@@ -96,6 +126,8 @@ func BatchPhotosRestore(router *gin.RouterGroup) {
 			log.Errorf("photos: %s", err)
 		}
 
+		query.FlushDuplicates()
+
 		UpdateClientConfig()
 
 		event.EntitiesRestored("photos", f.Photos)
@@ -106,14 +138,7 @@ func BatchPhotosRestore(router *gin.RouterGroup) {
 
 // POST /api/v1/batch/albums/delete
 func BatchAlbumsDelete(router *gin.RouterGroup) {
-	router.POST("/batch/albums/delete", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourceAlbums, acl.ActionDelete)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
-
+	router.POST("/batch/albums/delete", Authorize(acl.ResourceAlbums, acl.ActionDelete), func(c *gin.Context) {
 		var f form.Selection
 
 		if err := c.BindJSON(&f); err != nil {
@@ -126,6 +151,14 @@ func BatchAlbumsDelete(router *gin.RouterGroup) {
 			return
 		}
 
+		var err error
+
+		// Users without admin role may only delete their own albums.
+		if f.Albums, err = ownedAlbums(AuthSession(c), f.Albums); err != nil || len(f.Albums) == 0 {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		}
+
 		log.Infof("albums: deleting %s", f.String())
 
 		entity.Db().Where("album_uid IN (?)", f.Albums).Delete(&entity.Album{})
@@ -141,14 +174,7 @@ func BatchAlbumsDelete(router *gin.RouterGroup) {
 
 // POST /api/v1/batch/photos/private
 func BatchPhotosPrivate(router *gin.RouterGroup) {
-	router.POST("/batch/photos/private", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionPrivate)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
-
+	router.POST("/batch/photos/private", Authorize(acl.ResourcePhotos, acl.ActionPrivate), func(c *gin.Context) {
 		var f form.Selection
 
 		if err := c.BindJSON(&f); err != nil {
@@ -161,10 +187,18 @@ func BatchPhotosPrivate(router *gin.RouterGroup) {
 			return
 		}
 
+		var err error
+
+		// Users without admin role may only change their own photos.
+		if f.Photos, err = ownedPhotos(AuthSession(c), f.Photos); err != nil || len(f.Photos) == 0 {
+			AbortEntityNotFound(c)
+			return
+		}
+
 		log.Infof("photos: mark %s as private", f.String())
 
-		err := entity.Db().Model(entity.Photo{}).Where("photo_uid IN (?)", f.Photos).UpdateColumn("photo_private",
-			gorm.Expr("CASE WHEN photo_private > 0 THEN 0 ELSE 1 END")).Error
+		err = entity.Db().Model(entity.Photo{}).Where("photo_uid IN (?)", f.Photos).UpdateColumn("photo_private",
+			gorm.Expr("NOT photo_private")).Error
 
 		if err != nil {
 			AbortSaveFailed(c)
@@ -187,14 +221,7 @@ func BatchPhotosPrivate(router *gin.RouterGroup) {
 
 // POST /api/v1/batch/labels/delete
 func BatchLabelsDelete(router *gin.RouterGroup) {
-	router.POST("/batch/labels/delete", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourceLabels, acl.ActionDelete)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
-
+	router.POST("/batch/labels/delete", Authorize(acl.ResourceLabels, acl.ActionDelete), func(c *gin.Context) {
 		var f form.Selection
 
 		if err := c.BindJSON(&f); err != nil {
@@ -3,6 +3,7 @@ package api
 import (
 	"encoding/json"
 	"fmt"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/stretchr/testify/assert"
 	"github.com/tidwall/gjson"
@@ -151,6 +152,26 @@ func TestBatchPhotosPrivate(t *testing.T) {
 		val3 := gjson.Get(r3.Body.String(), "Private")
 		assert.Equal(t, "true", val3.String())
 	})
+	t.Run("postgres", func(t *testing.T) {
+		if !entity.IsDialect(entity.Postgres) {
+			t.Skip("postgres not used")
+		}
+
+		app, router, _ := NewApiTest()
+		GetPhoto(router)
+		BatchPhotosPrivate(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh8")
+		assert.Equal(t, http.StatusOK, r.Code)
+		private := gjson.Get(r.Body.String(), "Private").Bool()
+
+		r2 := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/private", `{"photos": ["pt9jtdre2lvl0yh8"]}`)
+		assert.Equal(t, http.StatusOK, r2.Code)
+
+		r3 := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh8")
+		assert.Equal(t, http.StatusOK, r3.Code)
+		assert.Equal(t, !private, gjson.Get(r3.Body.String(), "Private").Bool())
+	})
 	t.Run("no items selected", func(t *testing.T) {
 		app, router, _ := NewApiTest()
 		BatchPhotosPrivate(router)
@@ -0,0 +1,216 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/session"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// ActivityLimit is the maximum number of activities returned at once.
+const ActivityLimit = 500
+
+// GET /api/v1/albums/:uid/comments
+//
+// Parameters:
+//   uid: string Album UID as returned by the API
+func GetAlbumComments(router *gin.RouterGroup) {
+	router.GET("/albums/:uid/comments", Authorize(acl.ResourceAlbums, acl.ActionRead), func(c *gin.Context) {
+		s := AuthSession(c)
+		m, err := query.AlbumByUID(c.Param("uid"))
+
+		if err != nil || !s.Owns(m.OwnerUID) && !s.HasShare(m.AlbumUID) {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		}
+
+		c.JSON(http.StatusOK, entity.FindComments(m.AlbumUID))
+	})
+}
+
+// POST /api/v1/albums/:uid/comments
+//
+// Parameters:
+//   uid: string Album UID as returned by the API
+func CreateAlbumComment(router *gin.RouterGroup) {
+	router.POST("/albums/:uid/comments", Authorize(acl.ResourceAlbums, acl.ActionComment), func(c *gin.Context) {
+		s := AuthSession(c)
+		m, err := query.AlbumByUID(c.Param("uid"))
+
+		if err != nil || !s.Owns(m.OwnerUID) && !s.HasShare(m.AlbumUID) {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		}
+
+		// Guests need a share link that permits comments.
+		if s.Guest() && !s.CanComment(m.AlbumUID) {
+			AbortForbidden(c)
+			return
+		}
+
+		createComment(c, s, m.AlbumUID)
+	})
+}
+
+// GET /api/v1/photos/:uid/comments
+//
+// Parameters:
+//   uid: string PhotoUID as returned by the API
+func GetPhotoComments(router *gin.RouterGroup) {
+	router.GET("/photos/:uid/comments", Authorize(acl.ResourcePhotos, acl.ActionRead), func(c *gin.Context) {
+		s := AuthSession(c)
+		m, err := query.PhotoByUID(c.Param("uid"))
+
+		if err != nil || !s.Owns(m.OwnerUID) && !s.HasLink(m.LinkUID) && !query.PhotoInAlbums(m.PhotoUID, s.Shares) {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, entity.FindComments(m.PhotoUID))
+	})
+}
+
+// POST /api/v1/photos/:uid/comments
+//
+// Parameters:
+//   uid: string PhotoUID as returned by the API
+func CreatePhotoComment(router *gin.RouterGroup) {
+	router.POST("/photos/:uid/comments", Authorize(acl.ResourcePhotos, acl.ActionComment), func(c *gin.Context) {
+		s := AuthSession(c)
+		m, err := query.PhotoByUID(c.Param("uid"))
+
+		if err != nil || !s.Owns(m.OwnerUID) && !s.HasLink(m.LinkUID) && !query.PhotoInAlbums(m.PhotoUID, s.Shares) {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		// Guests need a share link that permits comments.
+		if s.Guest() && !query.PhotoInAlbums(m.PhotoUID, s.Comments) {
+			AbortForbidden(c)
+			return
+		}
+
+		createComment(c, s, m.PhotoUID)
+	})
+}
+
+// DELETE /api/v1/comments/:uid
+//
+// Parameters:
+//   uid: string Comment UID as returned by the API
+func DeleteComment(router *gin.RouterGroup) {
+	router.DELETE("/comments/:uid", Authorize(acl.ResourceComments, acl.ActionDelete), func(c *gin.Context) {
+		s := AuthSession(c)
+		m := entity.FindComment(c.Param("uid"))
+
+		// Only admins may delete comments of other users.
+		if m == nil || !s.Owns(m.PersonUID) {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		if err := m.Delete(); err != nil {
+			log.Errorf("comment: %s", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		event.EntitiesDeleted("comments", []string{m.CommentUID})
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// GET /api/v1/activity
+//
+// Returns recent comments, likes and additions in albums the user owns or has access to.
+//
+// Parameters:
+//   count: int Maximum number of results
+func GetActivity(router *gin.RouterGroup) {
+	router.GET("/activity", Authorize(acl.ResourceComments, acl.ActionSearch), func(c *gin.Context) {
+		s := AuthSession(c)
+
+		var f form.ActivitySearch
+
+		if err := c.MustBindWith(&f, binding.Form); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if f.Count > ActivityLimit {
+			f.Count = ActivityLimit
+		}
+
+		var albums []string
+
+		if s.Restricted() {
+			albums = append([]string{}, s.Shares...)
+
+			if !s.Guest() {
+				owned, err := query.AlbumUIDsByOwner(s.User.PersonUID)
+
+				if err != nil {
+					log.Errorf("activity: %s", err)
+				}
+
+				albums = append(albums, owned...)
+			}
+		}
+
+		result, err := query.RecentActivity(albums, f.Count)
+
+		if err != nil {
+			log.Errorf("activity: %s", err)
+			AbortBadRequest(c)
+			return
+		}
+
+		c.Header("X-Count", strconv.Itoa(len(result)))
+		c.Header("X-Limit", strconv.Itoa(f.Count))
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// createComment saves a new comment and notifies clients.
+func createComment(c *gin.Context, s session.Data, subjectUID string) {
+	var f form.Comment
+
+	if err := c.BindJSON(&f); err != nil {
+		AbortBadRequest(c)
+		return
+	}
+
+	if strings.TrimSpace(f.Text) == "" {
+		AbortBadRequest(c)
+		return
+	}
+
+	m := entity.NewComment(subjectUID, s.User, f.Text)
+
+	// Guests share a single account, so they may tell their names.
+	if name := strings.TrimSpace(f.Name); s.Guest() && name != "" {
+		m.PersonName = txt.Clip(name, 255)
+	}
+
+	if err := m.Create(); err != nil {
+		log.Errorf("comment: %s", err)
+		AbortSaveFailed(c)
+		return
+	}
+
+	event.EntitiesCreated("comments", entity.Comments{*m})
+
+	c.JSON(http.StatusOK, m)
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestCreateAlbumComment(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateAlbumComment(router)
+		GetAlbumComments(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba8/comments", `{"Text": "Beautiful!"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Beautiful!", gjson.Get(r.Body.String(), "Text").String())
+		assert.Equal(t, "at9lxuqxpogaaba8", gjson.Get(r.Body.String(), "SubjectUID").String())
+
+		r = PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/comments")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.LessOrEqual(t, int64(1), gjson.Get(r.Body.String(), "#").Int())
+	})
+	t.Run("empty text", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateAlbumComment(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba8/comments", `{"Text": " "}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("album not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateAlbumComment(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaxxx/comments", `{"Text": "Hello"}`)
+		val := gjson.Get(r.Body.String(), "error")
+		assert.Equal(t, i18n.Msg(i18n.ErrAlbumNotFound), val.String())
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestCreatePhotoComment(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreatePhotoComment(router)
+		GetPhotoComments(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/pt9jtdre2lvl0yh7/comments", `{"Text": "Funny"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7/comments")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.LessOrEqual(t, int64(1), gjson.Get(r.Body.String(), "#").Int())
+	})
+	t.Run("photo not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreatePhotoComment(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/pt9jtdre2lvl0xxx/comments", `{"Text": "Funny"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestDeleteComment(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateAlbumComment(router)
+		DeleteComment(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba8/comments", `{"Text": "Oops"}`)
+		uid := gjson.Get(r.Body.String(), "UID").String()
+		assert.NotEmpty(t, uid)
+
+		r = PerformRequest(app, "DELETE", "/api/v1/comments/"+uid)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = PerformRequest(app, "DELETE", "/api/v1/comments/"+uid)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestGetActivity(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateAlbumComment(router)
+		GetActivity(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba8/comments", `{"Text": "Wow"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = PerformRequest(app, "GET", "/api/v1/activity?count=10")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.LessOrEqual(t, int64(1), gjson.Get(r.Body.String(), "#").Int())
+		assert.Equal(t, "10", r.Header().Get("X-Limit"))
+	})
+	t.Run("count missing", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetActivity(router)
+		r := PerformRequest(app, "GET", "/api/v1/activity")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
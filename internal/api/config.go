@@ -5,27 +5,27 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/session"
 )
 
 // GET /api/v1/config
 func GetConfig(router *gin.RouterGroup) {
-	router.GET("/config", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourceConfig, acl.ActionRead)
+	router.GET("/config", Authorize(acl.ResourceConfig, acl.ActionRead), func(c *gin.Context) {
+		c.JSON(http.StatusOK, ClientConfig(AuthSession(c)))
+	})
+}
 
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
+// ClientConfig returns the client config for the session user.
+func ClientConfig(s session.Data) config.ClientConfig {
+	conf := service.Config()
 
-		conf := service.Config()
+	if s.User.Guest() {
+		return conf.GuestConfig()
+	} else if s.User.Registered() {
+		return conf.UserConfig()
+	}
 
-		if s.User.Guest() {
-			c.JSON(http.StatusOK, conf.GuestConfig())
-		} else if s.User.Registered() {
-			c.JSON(http.StatusOK, conf.UserConfig())
-		} else {
-			c.JSON(http.StatusOK, conf.PublicConfig())
-		}
-	})
+	return conf.PublicConfig()
 }
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/session"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// duplicatesOwner returns the owner UID to which duplicates are restricted, or an empty string for admins.
+func duplicatesOwner(s session.Data) string {
+	if s.Restricted() {
+		return s.User.PersonUID
+	}
+
+	return ""
+}
+
+// GET /api/v1/duplicates
+//
+// Returns groups of near-duplicates like resized copies, re-encoded versions and burst shots.
+//
+// Parameters:
+//   dist: int Maximum perceptual hash distance (optional)
+//   count: int Maximum number of groups
+//   offset: int Result offset
+func GetDuplicates(router *gin.RouterGroup) {
+	router.GET("/duplicates", Authorize(acl.ResourcePhotos, acl.ActionDelete), func(c *gin.Context) {
+		var f form.DuplicateSearch
+
+		if err := c.MustBindWith(&f, binding.Form); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		result, err := query.NearDuplicates(duplicatesOwner(AuthSession(c)), f.Distance(), f.Count, f.Offset)
+
+		if err != nil {
+			log.Errorf("duplicates: %s", err)
+			AbortBadRequest(c)
+			return
+		}
+
+		c.Header("X-Count", strconv.Itoa(len(result)))
+		c.Header("X-Limit", strconv.Itoa(f.Count))
+		c.Header("X-Offset", strconv.Itoa(f.Offset))
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// POST /api/v1/duplicates/keep
+//
+// Keeps one photo of a group of near-duplicates and archives the others.
+func KeepDuplicate(router *gin.RouterGroup) {
+	router.POST("/duplicates/keep", Authorize(acl.ResourcePhotos, acl.ActionDelete), func(c *gin.Context) {
+		var f form.DuplicateKeep
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		var archive []string
+
+		for _, uid := range f.Archive {
+			if uid != "" && uid != f.Keep {
+				archive = append(archive, uid)
+			}
+		}
+
+		if f.Keep == "" || len(archive) == 0 {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+			return
+		}
+
+		// Only photos of the same group may be archived, which also contains only photos of the user.
+		group, err := query.FindDuplicateGroup(duplicatesOwner(AuthSession(c)), f.Distance(), f.Keep)
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		for _, uid := range archive {
+			if !group.Contains(uid) {
+				AbortEntityNotFound(c)
+				return
+			}
+		}
+
+		log.Infof("duplicates: keeping %s, archiving %d photos", txt.Quote(f.Keep), len(archive))
+
+		if err := archivePhotos(archive); err != nil {
+			AbortSaveFailed(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgSelectionArchived))
+	})
+}
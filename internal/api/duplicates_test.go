@@ -0,0 +1,55 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetDuplicates(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetDuplicates(router)
+		r := PerformRequest(app, "GET", "/api/v1/duplicates?count=10")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "10", r.Header().Get("X-Limit"))
+	})
+	t.Run("exact duplicates", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetDuplicates(router)
+		r := PerformRequest(app, "GET", "/api/v1/duplicates?count=10&dist=0")
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("count missing", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetDuplicates(router)
+		r := PerformRequest(app, "GET", "/api/v1/duplicates")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestKeepDuplicate(t *testing.T) {
+	t.Run("no items selected", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		KeepDuplicate(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/duplicates/keep", `{"keep": "pt9jtdre2lvl0y11", "archive": ["pt9jtdre2lvl0y11"]}`)
+		val := gjson.Get(r.Body.String(), "error")
+		assert.Equal(t, i18n.Msg(i18n.ErrNoItemsSelected), val.String())
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("not a duplicate", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		KeepDuplicate(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/duplicates/keep", `{"keep": "pt9jtdre2lvl0y11", "archive": ["pt9jtdre2lvl0y12"]}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("invalid request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		KeepDuplicate(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/duplicates/keep", `{"archive": 123}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
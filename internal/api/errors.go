@@ -11,14 +11,7 @@ import (
 )
 
 func GetErrors(router *gin.RouterGroup) {
-	router.GET("/errors", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourceLogs, acl.ActionSearch)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
-
+	router.GET("/errors", Authorize(acl.ResourceLogs, acl.ActionSearch), func(c *gin.Context) {
 		limit := txt.Int(c.Query("count"))
 		offset := txt.Int(c.Query("offset"))
 
@@ -13,6 +13,7 @@ const (
 	EntityUpdated EntityEvent = "updated"
 	EntityCreated EntityEvent = "created"
 	EntityDeleted EntityEvent = "deleted"
+	EntityShared  EntityEvent = "shared"
 )
 
 func PublishPhotoEvent(e EntityEvent, uid string, c *gin.Context) {
@@ -0,0 +1,200 @@
+package api
+
+import (
+	"encoding/json"
+	"path"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/session"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// EventPermission represents the resource and action required to receive events of a topic.
+type EventPermission struct {
+	Resource acl.Resource
+	Action   acl.Action
+}
+
+// EventPermissions maps event topic prefixes to the permission required to receive them.
+var EventPermissions = map[string]EventPermission{
+	"log":       {acl.ResourceLogs, acl.ActionSearch},
+	"auth":      {acl.ResourceLogs, acl.ActionSearch},
+	"notify":    {acl.ResourceSettings, acl.ActionRead},
+	"count":     {acl.ResourceSettings, acl.ActionRead},
+	"config":    {acl.ResourceConfig, acl.ActionRead},
+	"index":     {acl.ResourcePhotos, acl.ActionImport},
+	"import":    {acl.ResourcePhotos, acl.ActionImport},
+	"upload":    {acl.ResourcePhotos, acl.ActionUpload},
+	"sync":      {acl.ResourceAccounts, acl.ActionRead},
+	"photos":    {acl.ResourcePhotos, acl.ActionSearch},
+	"memories":  {acl.ResourcePhotos, acl.ActionSearch},
+	"albums":    {acl.ResourceAlbums, acl.ActionSearch},
+	"labels":    {acl.ResourceLabels, acl.ActionSearch},
+	"comments":  {acl.ResourceComments, acl.ActionSearch},
+	"cameras":   {acl.ResourceCameras, acl.ActionSearch},
+	"lenses":    {acl.ResourceLenses, acl.ActionSearch},
+	"countries": {acl.ResourceCountries, acl.ActionSearch},
+}
+
+// EventTopics returns the valid topic patterns in a comma separated list, or all topics if there are none.
+func EventTopics(s string) (result []string) {
+	for _, t := range strings.Split(s, ",") {
+		if t = strings.ToLower(strings.TrimSpace(t)); t == "" {
+			continue
+		} else if _, err := path.Match(t, ""); err != nil {
+			continue
+		}
+
+		result = append(result, t)
+	}
+
+	if len(result) == 0 {
+		return []string{"*"}
+	}
+
+	return result
+}
+
+// SubscribedTo returns true if the event topic matches one of the patterns.
+func SubscribedTo(topics []string, name string) bool {
+	for _, t := range topics {
+		if entity.MatchTopic(t, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FilterEvent returns the event as seen by the session user, or false if the user
+// is not allowed to receive it. Entities of restricted users are limited to those they
+// own or that have been shared with them.
+func FilterEvent(s session.Data, msg event.Message) (event.Message, bool) {
+	if s.Invalid() {
+		return msg, false
+	}
+
+	prefix := strings.SplitN(msg.Name, ".", 2)[0]
+
+	p, ok := EventPermissions[prefix]
+
+	if !ok || acl.Permissions.Deny(p.Resource, s.User.Role(), p.Action) {
+		return msg, false
+	}
+
+	// The config depends on the user role.
+	if prefix == "config" {
+		return event.Message{Name: msg.Name, Fields: event.Data{"config": ClientConfig(s)}}, true
+	}
+
+	entities, ok := msg.Fields["entities"]
+
+	if !ok || !s.Restricted() {
+		return msg, true
+	}
+
+	switch prefix {
+	case "photos", "albums", "comments":
+		visible := filterEntities(s, entities)
+
+		if len(visible) == 0 {
+			return msg, false
+		}
+
+		fields := event.Data{}
+
+		for k, v := range msg.Fields {
+			fields[k] = v
+		}
+
+		fields["entities"] = visible
+
+		return event.Message{Name: msg.Name, Fields: fields}, true
+	default:
+		return msg, true
+	}
+}
+
+// filterEntities returns the entities in an event the session user may see. Entities are
+// either UIDs or objects with UID, OwnerUID, Private and SubjectUID values.
+func filterEntities(s session.Data, entities interface{}) (result []interface{}) {
+	data, err := json.Marshal(entities)
+
+	if err != nil {
+		return result
+	}
+
+	var list []interface{}
+
+	if err := json.Unmarshal(data, &list); err != nil {
+		return result
+	}
+
+	for _, item := range list {
+		switch v := item.(type) {
+		case string:
+			if canSee(s, v, "", false) {
+				result = append(result, v)
+			}
+		case map[string]interface{}:
+			uid, _ := v["UID"].(string)
+			owner, _ := v["OwnerUID"].(string)
+			private, _ := v["Private"].(bool)
+
+			// Comments are visible if their subject is.
+			if subject, ok := v["SubjectUID"].(string); ok {
+				uid, owner = subject, ""
+			}
+
+			if canSee(s, uid, owner, private) {
+				result = append(result, v)
+			}
+		}
+	}
+
+	return result
+}
+
+// canSee returns true if the session user owns a photo or album, or if it has been shared.
+// Guests never own entities, photos they uploaded are identified by the share link.
+func canSee(s session.Data, uid, owner string, private bool) bool {
+	if s.Guest() && private {
+		return false
+	} else if owner != "" && s.Owns(owner) {
+		return true
+	}
+
+	switch {
+	case rnd.IsPPID(uid, 'a'):
+		if s.HasShare(uid) {
+			return true
+		} else if owner != "" || s.Guest() {
+			return false
+		} else if a, err := query.AlbumByUID(uid); err == nil {
+			return s.Owns(a.OwnerUID)
+		}
+	case rnd.IsPPID(uid, 'p'):
+		if query.PhotoInAlbums(uid, s.Shares) {
+			// Guests only see public photos.
+			return !s.Guest() || owner != "" || !photoPrivate(uid)
+		} else if owner != "" && !s.Guest() {
+			return false
+		} else if p, err := query.PhotoByUID(uid); err == nil {
+			// Guests see public photos they uploaded with one of their share links.
+			return s.Owns(p.OwnerUID) || s.HasLink(p.LinkUID) && !p.PhotoPrivate
+		}
+	}
+
+	return false
+}
+
+// photoPrivate returns true if the photo is private or can't be found.
+func photoPrivate(uid string) bool {
+	p, err := query.PhotoByUID(uid)
+
+	return err != nil || p.PhotoPrivate
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/session"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventTopics(t *testing.T) {
+	assert.Equal(t, []string{"*"}, EventTopics(""))
+	assert.Equal(t, []string{"*"}, EventTopics(" , ["))
+	assert.Equal(t, []string{"photos.*", "albums.shared"}, EventTopics("Photos.*, albums.shared"))
+}
+
+func TestSubscribedTo(t *testing.T) {
+	assert.True(t, SubscribedTo([]string{"*"}, "photos.updated"))
+	assert.True(t, SubscribedTo([]string{"albums.*", "photos.*"}, "photos.updated"))
+	assert.False(t, SubscribedTo([]string{"albums.*"}, "photos.updated"))
+	assert.False(t, SubscribedTo(nil, "photos.updated"))
+}
+
+func TestFilterEvent(t *testing.T) {
+	admin := session.Data{User: entity.Admin}
+	guest := session.Data{User: entity.Guest, Shares: session.UIDs{"at9lxuqxpogaaba8"}}
+
+	t.Run("admin", func(t *testing.T) {
+		msg := event.Message{Name: "photos.archived", Fields: event.Data{"entities": []string{"pt9jtdre2lvl0yh7", "pt9jtdre2lvl0y11"}}}
+		result, ok := FilterEvent(admin, msg)
+		assert.True(t, ok)
+		assert.Equal(t, msg, result)
+	})
+	t.Run("invalid session", func(t *testing.T) {
+		_, ok := FilterEvent(session.Data{}, event.Message{Name: "photos.updated", Fields: event.Data{}})
+		assert.False(t, ok)
+	})
+	t.Run("guest without permission", func(t *testing.T) {
+		_, ok := FilterEvent(guest, event.Message{Name: "log.error", Fields: event.Data{"message": "foo"}})
+		assert.False(t, ok)
+		_, ok = FilterEvent(guest, event.Message{Name: "notify.success", Fields: event.Data{"message": "foo"}})
+		assert.False(t, ok)
+		_, ok = FilterEvent(guest, event.Message{Name: "unknown.event", Fields: event.Data{}})
+		assert.False(t, ok)
+	})
+	t.Run("guest albums", func(t *testing.T) {
+		msg := event.Message{Name: "albums.updated", Fields: event.Data{"entities": []entity.Album{
+			{AlbumUID: "at9lxuqxpogaaba8"},
+			{AlbumUID: "at9lxuqxpogaaba9"},
+		}}}
+		result, ok := FilterEvent(guest, msg)
+		assert.True(t, ok)
+		assert.Len(t, result.Fields["entities"], 1)
+		assert.Len(t, msg.Fields["entities"], 2)
+
+		_, ok = FilterEvent(guest, event.Message{Name: "albums.updated", Fields: event.Data{"entities": []entity.Album{{AlbumUID: "at9lxuqxpogaaba9"}}}})
+		assert.False(t, ok)
+	})
+	t.Run("guest photos", func(t *testing.T) {
+		msg := event.Message{Name: "photos.archived", Fields: event.Data{"entities": []string{"pt9jtdre2lvl0yh7", "pt9jtdre2lvl0y11"}}}
+		result, ok := FilterEvent(guest, msg)
+		assert.True(t, ok)
+		assert.Equal(t, []interface{}{"pt9jtdre2lvl0yh7"}, result.Fields["entities"])
+
+		_, ok = FilterEvent(guest, event.Message{Name: "photos.updated", Fields: event.Data{"entities": []entity.Photo{{PhotoUID: "pt9jtdre2lvl0yh7", PhotoPrivate: true}}}})
+		assert.False(t, ok)
+	})
+	t.Run("guest uploads", func(t *testing.T) {
+		msg := event.Message{Name: "photos.updated", Fields: event.Data{"entities": []entity.Photo{{PhotoUID: "pt9jtdre2lvl0y11", OwnerUID: entity.Guest.PersonUID}}}}
+		_, ok := FilterEvent(guest, msg)
+		assert.False(t, ok)
+	})
+	t.Run("guest config", func(t *testing.T) {
+		result, ok := FilterEvent(guest, event.Message{Name: "config.updated", Fields: event.Data{"config": "secret"}})
+		assert.True(t, ok)
+		assert.IsType(t, config.ClientConfig{}, result.Fields["config"])
+	})
+}
@@ -0,0 +1,154 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/service"
+)
+
+var eventsPing = 15 * time.Second
+
+// writeEvent writes a server-sent event.
+func writeEvent(w io.Writer, id uint64, name string, data interface{}) error {
+	b, err := json.Marshal(data)
+
+	if err != nil {
+		return err
+	}
+
+	if id > 0 {
+		if _, err := fmt.Fprintf(w, "id: %d\n", id); err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, b)
+
+	return err
+}
+
+// lastEventID returns the id of the last event a client has received, or false if unknown.
+func lastEventID(c *gin.Context) (uint64, bool) {
+	s := c.GetHeader("Last-Event-ID")
+
+	if s == "" {
+		s = c.Query("last")
+	}
+
+	if s == "" {
+		return 0, false
+	}
+
+	id, err := strconv.ParseUint(s, 10, 64)
+
+	return id, err == nil
+}
+
+// sessionFromQuery accepts the session id as query parameter, since browsers can't set
+// custom headers for EventSource requests.
+func sessionFromQuery(c *gin.Context) {
+	if id := c.Query("session"); id != "" && SessionID(c) == "" {
+		c.Request.Header.Set("X-Session-ID", id)
+	}
+}
+
+// GET /api/v1/events
+//
+// Streams events as text/event-stream, filtered by the permissions of the session user.
+// Log messages are not included.
+//
+// Parameters:
+//   topics: string Comma separated topic patterns like "photos.*,albums.*", default is all topics
+//   last: int Last event ID received, same as the Last-Event-ID header
+//   session: string Session ID, if it can't be sent in the X-Session-ID header
+func GetEvents(router *gin.RouterGroup) {
+	router.GET("/events", sessionFromQuery, Authorize(acl.ResourceEvents, acl.ActionRead), func(c *gin.Context) {
+		s := AuthSession(c)
+		id := SessionID(c)
+		topics := EventTopics(c.Query("topics"))
+		history := event.SharedHistory()
+
+		last, resume := lastEventID(c)
+
+		if !resume {
+			last = history.LastID()
+		}
+
+		c.Header("Content-Type", "text/event-stream")
+		c.Header("Cache-Control", "no-cache")
+		c.Header("Connection", "keep-alive")
+		c.Header("X-Accel-Buffering", "no")
+		c.Status(http.StatusOK)
+
+		ping := time.NewTicker(eventsPing)
+		defer ping.Stop()
+
+		w := c.Writer
+
+		for {
+			wait := history.Wait()
+			entries, complete := history.Since(last)
+
+			// Clients must reload their data if events were missed.
+			if !complete {
+				if err := writeEvent(w, 0, "events.missed", event.Data{"last": last}); err != nil {
+					return
+				}
+
+				if len(entries) == 0 {
+					last = 0
+				}
+			}
+
+			for _, e := range entries {
+				last = e.ID
+
+				if !SubscribedTo(topics, e.Message.Name) {
+					continue
+				}
+
+				msg, ok := FilterEvent(s, e.Message)
+
+				if !ok {
+					continue
+				}
+
+				if err := writeEvent(w, e.ID, msg.Name, msg.Fields); err != nil {
+					return
+				}
+			}
+
+			w.Flush()
+
+			select {
+			case <-c.Request.Context().Done():
+				return
+			case <-wait:
+			case <-ping.C:
+				// Stop streaming once the session has expired or the app token was deleted.
+				if secret := AppToken(c); secret != "" && !service.Config().Public() {
+					if entity.FindAppToken(secret) == nil {
+						return
+					}
+				} else if s = Session(id); s.Invalid() {
+					return
+				}
+
+				if _, err := io.WriteString(w, ": ping\n\n"); err != nil {
+					return
+				}
+
+				w.Flush()
+			}
+		}
+	})
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteEvent(t *testing.T) {
+	var buf bytes.Buffer
+
+	assert.NoError(t, writeEvent(&buf, 42, "photos.updated", event.Data{"count": 1}))
+	assert.Equal(t, "id: 42\nevent: photos.updated\ndata: {\"count\":1}\n\n", buf.String())
+
+	buf.Reset()
+
+	assert.NoError(t, writeEvent(&buf, 0, "events.missed", event.Data{}))
+	assert.Equal(t, "event: events.missed\ndata: {}\n\n", buf.String())
+}
+
+func TestGetEvents(t *testing.T) {
+	t.Run("replay", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetEvents(router)
+
+		last := event.SharedHistory().LastID()
+
+		event.Publish("labels.updated", event.Data{"entities": []string{}})
+		event.Publish("albums.updated", event.Data{"entities": []string{}})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+
+		req, _ := http.NewRequest("GET", "/api/v1/events?topics=labels.*", nil)
+		req = req.WithContext(ctx)
+		req.Header.Set("Last-Event-ID", fmt.Sprintf("%d", last))
+
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Body.String(), fmt.Sprintf("id: %d\nevent: labels.updated\n", last+1))
+		assert.NotContains(t, w.Body.String(), "albums.updated")
+	})
+	t.Run("missed events", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetEvents(router)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/events?last=%d", event.SharedHistory().LastID()+100), nil)
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "event: events.missed\n")
+	})
+}
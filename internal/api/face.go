@@ -0,0 +1,120 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+)
+
+// GET /api/v1/faces
+//
+// Returns clusters of similar faces, clusters linked to a person first.
+func GetFaces(router *gin.RouterGroup) {
+	router.GET("/faces", Authorize(acl.ResourceFaces, acl.ActionSearch), func(c *gin.Context) {
+		s := AuthSession(c)
+
+		var f form.FaceSearch
+
+		if err := c.MustBindWith(&f, binding.Form); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if f.Min < 1 {
+			f.Min = 1
+		}
+
+		owner := ""
+
+		// Users without admin role may only see faces in their own photos.
+		if s.Restricted() {
+			owner = s.User.PersonUID
+		}
+
+		result, err := query.FaceClusterResults(f.Min, f.Count, f.Offset, owner)
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		c.Header("X-Count", strconv.Itoa(len(result)))
+		c.Header("X-Limit", strconv.Itoa(f.Count))
+		c.Header("X-Offset", strconv.Itoa(f.Offset))
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// PUT /api/v1/faces/:uid
+//
+// Links a face cluster to the person with the given name, an empty name removes the link.
+//
+// Parameters:
+//   uid: string Face cluster UID as returned by the API
+func UpdateFaces(router *gin.RouterGroup) {
+	router.PUT("/faces/:uid", Authorize(acl.ResourceFaces, acl.ActionUpdate), func(c *gin.Context) {
+		var f form.FaceCluster
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		s := AuthSession(c)
+
+		m := entity.FindFaceCluster(c.Param("uid"))
+
+		if m == nil || s.Restricted() && !query.FaceClusterOwnedBy(m.ClusterUID, s.User.PersonUID) {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		var person *entity.Person
+
+		if name := strings.TrimSpace(f.PersonName); name != "" {
+			p, err := entity.FirstOrCreateSubject(name)
+
+			if err != nil {
+				AbortSaveFailed(c)
+				return
+			}
+
+			person = p
+		}
+
+		if err := m.SetPerson(person); err != nil {
+			log.Errorf("faces: %s", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		// Write named face regions to XMP sidecar files (optional).
+		if service.Config().SidecarXmp() {
+			if uids, err := query.FaceClusterPhotoUIDs(m.ClusterUID); err != nil {
+				log.Errorf("faces: %s", err)
+			} else {
+				for _, uid := range uids {
+					if err := photoprism.SaveFaceRegions(uid); err != nil {
+						log.Errorf("faces: %s (update xmp)", err)
+					}
+				}
+			}
+		}
+
+		event.SuccessMsg(i18n.MsgFacesSaved)
+
+		c.JSON(http.StatusOK, m)
+	})
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetFaces(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetFaces(router)
+		r := PerformRequest(app, "GET", "/api/v1/faces?count=10")
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("bad request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetFaces(router)
+		r := PerformRequest(app, "GET", "/api/v1/faces")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestUpdateFaces(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		file := entity.FileFixtures["exampleFileName.jpg"]
+		f := entity.NewFace(&file, face.Face{Embedding: face.Embedding{1, 0}})
+
+		if err := f.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		cluster := entity.NewFaceCluster()
+
+		if err := cluster.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := cluster.AddFace(f); err != nil {
+			t.Fatal(err)
+		}
+
+		app, router, _ := NewApiTest()
+		UpdateFaces(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/faces/"+cluster.ClusterUID, `{"Name": "Face Api Test"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		personUID := gjson.Get(r.Body.String(), "PersonUID").String()
+		assert.NotEmpty(t, personUID)
+
+		r = PerformRequestWithBody(app, "PUT", "/api/v1/faces/"+cluster.ClusterUID, `{"Name": ""}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "", gjson.Get(r.Body.String(), "PersonUID").String())
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdateFaces(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/faces/cxxx", `{"Name": "Test"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -13,17 +13,12 @@ import (
 // Parameters:
 //   hash: string SHA-1 hash of the file
 func GetFile(router *gin.RouterGroup) {
-	router.GET("/files/:hash", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourceFiles, acl.ActionRead)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
+	router.GET("/files/:hash", Authorize(acl.ResourceFiles, acl.ActionRead), func(c *gin.Context) {
+		s := AuthSession(c)
 
 		p, err := query.FileByHash(c.Param("hash"))
 
-		if err != nil {
+		if err != nil || p.Photo == nil || !s.Owns(p.Photo.OwnerUID) && !s.HasLink(p.Photo.LinkUID) && !query.PhotoInAlbums(p.PhotoUID, s.Shares) {
 			AbortEntityNotFound(c)
 			return
 		}
@@ -28,13 +28,6 @@ type FoldersResponse struct {
 // GetFolders is a reusable request handler for directory listings (GET /api/v1/folders/*).
 func GetFolders(router *gin.RouterGroup, urlPath, rootName, rootPath string) {
 	handler := func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourceFolders, acl.ActionSearch)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
-
 		var f form.FolderSearch
 
 		start := time.Now()
@@ -100,8 +93,8 @@ func GetFolders(router *gin.RouterGroup, urlPath, rootName, rootPath string) {
 		c.JSON(http.StatusOK, resp)
 	}
 
-	router.GET("/folders/"+urlPath, handler)
-	router.GET("/folders/"+urlPath+"/*path", handler)
+	router.GET("/folders/"+urlPath, Authorize(acl.ResourceFolders, acl.ActionSearch), handler)
+	router.GET("/folders/"+urlPath+"/*path", Authorize(acl.ResourceFolders, acl.ActionSearch), handler)
 }
 
 // GET /api/v1/folders/originals
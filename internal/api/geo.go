@@ -17,13 +17,8 @@ import (
 
 // GET /api/v1/geo
 func GetGeo(router *gin.RouterGroup) {
-	router.GET("/geo", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionSearch)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
+	router.GET("/geo", Authorize(acl.ResourcePlaces, acl.ActionSearch), func(c *gin.Context) {
+		s := AuthSession(c)
 
 		var f form.GeoSearch
 
@@ -34,6 +29,11 @@ func GetGeo(router *gin.RouterGroup) {
 			return
 		}
 
+		if s.Restricted() {
+			f.Owner = s.User.PersonUID
+			f.Shared = s.Shares.String()
+		}
+
 		photos, err := query.Geo(f)
 
 		if err != nil {
@@ -20,13 +20,8 @@ import (
 
 // POST /api/v1/import*
 func StartImport(router *gin.RouterGroup) {
-	router.POST("/import/*path", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionImport)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
+	router.POST("/import/*path", Authorize(acl.ResourcePhotos, acl.ActionImport), func(c *gin.Context) {
+		s := AuthSession(c)
 
 		conf := service.Config()
 
@@ -69,6 +64,9 @@ func StartImport(router *gin.RouterGroup) {
 			opt = photoprism.ImportOptionsCopy(path)
 		}
 
+		// Imported photos are owned by the session user.
+		opt.UserUID = s.User.PersonUID
+
 		if len(f.Albums) > 0 {
 			log.Debugf("import: files will be added to album %s", strings.Join(f.Albums, " and "))
 			opt.Albums = f.Albums
@@ -90,6 +88,10 @@ func StartImport(router *gin.RouterGroup) {
 			log.Error(err)
 		}
 
+		if err := service.Faces().Start(); err != nil {
+			log.Error(err)
+		}
+
 		elapsed := int(time.Since(start).Seconds())
 
 		msg := i18n.Msg(i18n.MsgImportCompletedIn, elapsed)
@@ -110,14 +112,7 @@ func StartImport(router *gin.RouterGroup) {
 
 // DELETE /api/v1/import
 func CancelImport(router *gin.RouterGroup) {
-	router.DELETE("/import", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionImport)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
-
+	router.DELETE("/import", Authorize(acl.ResourcePhotos, acl.ActionImport), func(c *gin.Context) {
 		conf := service.Config()
 
 		if conf.ReadOnly() || !conf.Settings().Features.Import {
@@ -17,14 +17,7 @@ import (
 
 // POST /api/v1/index
 func StartIndexing(router *gin.RouterGroup) {
-	router.POST("/index", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
-
+	router.POST("/index", Authorize(acl.ResourceFiles, acl.ActionUpdate), func(c *gin.Context) {
 		conf := service.Config()
 
 		if !conf.Settings().Features.Library {
@@ -79,6 +72,10 @@ func StartIndexing(router *gin.RouterGroup) {
 			log.Error(err)
 		}
 
+		if err := service.Faces().Start(); err != nil {
+			log.Error(err)
+		}
+
 		elapsed := int(time.Since(start).Seconds())
 
 		msg := i18n.Msg(i18n.MsgIndexingCompletedIn, elapsed)
@@ -94,14 +91,7 @@ func StartIndexing(router *gin.RouterGroup) {
 
 // DELETE /api/v1/index
 func CancelIndexing(router *gin.RouterGroup) {
-	router.DELETE("/index", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
-
+	router.DELETE("/index", Authorize(acl.ResourceFiles, acl.ActionUpdate), func(c *gin.Context) {
 		conf := service.Config()
 
 		if !conf.Settings().Features.Library {
@@ -25,14 +25,7 @@ import (
 
 // GET /api/v1/labels
 func GetLabels(router *gin.RouterGroup) {
-	router.GET("/labels", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourceLabels, acl.ActionSearch)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
-
+	router.GET("/labels", Authorize(acl.ResourceLabels, acl.ActionSearch), func(c *gin.Context) {
 		var f form.LabelSearch
 
 		err := c.MustBindWith(&f, binding.Form)
@@ -59,14 +52,7 @@ func GetLabels(router *gin.RouterGroup) {
 
 // PUT /api/v1/labels/:uid
 func UpdateLabel(router *gin.RouterGroup) {
-	router.PUT("/labels/:uid", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourceLabels, acl.ActionUpdate)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
-
+	router.PUT("/labels/:uid", Authorize(acl.ResourceLabels, acl.ActionUpdate), func(c *gin.Context) {
 		var f form.Label
 
 		if err := c.BindJSON(&f); err != nil {
@@ -85,6 +71,10 @@ func UpdateLabel(router *gin.RouterGroup) {
 		m.SetName(f.LabelName)
 		entity.Db().Save(&m)
 
+		if err := entity.IndexLabelFullText(m.ID); err != nil {
+			log.Errorf("label: %s (update search index)", err)
+		}
+
 		event.SuccessMsg(i18n.MsgLabelSaved)
 
 		PublishLabelEvent(EntityUpdated, id, c)
@@ -98,14 +88,7 @@ func UpdateLabel(router *gin.RouterGroup) {
 // Parameters:
 //   uid: string Label UID
 func LikeLabel(router *gin.RouterGroup) {
-	router.POST("/labels/:uid/like", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourceLabels, acl.ActionUpdate)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
-
+	router.POST("/labels/:uid/like", Authorize(acl.ResourceLabels, acl.ActionUpdate), func(c *gin.Context) {
 		id := c.Param("uid")
 		label, err := query.LabelByUID(id)
 
@@ -136,14 +119,7 @@ func LikeLabel(router *gin.RouterGroup) {
 // Parameters:
 //   uid: string Label UID
 func DislikeLabel(router *gin.RouterGroup) {
-	router.DELETE("/labels/:uid/like", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourceLabels, acl.ActionUpdate)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
-
+	router.DELETE("/labels/:uid/like", Authorize(acl.ResourceLabels, acl.ActionUpdate), func(c *gin.Context) {
 		id := c.Param("uid")
 		label, err := query.LabelByUID(id)
 
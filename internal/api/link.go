@@ -11,18 +11,12 @@ import (
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/rnd"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
 
 // PUT /api/v1/:entity/:uid/links/:link
 func UpdateLink(c *gin.Context) {
-	s := Auth(SessionID(c), acl.ResourceLinks, acl.ActionUpdate)
-
-	if s.Invalid() {
-		AbortUnauthorized(c)
-		return
-	}
-
 	var f form.Link
 
 	if err := c.BindJSON(&f); err != nil {
@@ -32,9 +26,18 @@ func UpdateLink(c *gin.Context) {
 
 	link := entity.FindLink(c.Param("link"))
 
+	// Links may only be changed through the shared entity.
+	if link == nil || link.ShareUID != c.Param("uid") {
+		AbortEntityNotFound(c)
+		return
+	}
+
 	link.SetSlug(f.ShareSlug)
 	link.MaxViews = f.MaxViews
 	link.LinkExpires = f.LinkExpires
+	link.CanComment = f.CanComment
+	link.CanEdit = f.CanEdit
+	link.CanUpload = f.CanUpload && rnd.IsPPID(link.ShareUID, 'a')
 
 	if f.LinkToken != "" {
 		link.LinkToken = strings.TrimSpace(strings.ToLower(f.LinkToken))
@@ -59,15 +62,14 @@ func UpdateLink(c *gin.Context) {
 
 // DELETE /api/v1/:entity/:uid/links/:link
 func DeleteLink(c *gin.Context) {
-	s := Auth(SessionID(c), acl.ResourceLinks, acl.ActionDelete)
+	link := entity.FindLink(c.Param("link"))
 
-	if s.Invalid() {
-		AbortUnauthorized(c)
+	// Links may only be deleted through the shared entity.
+	if link == nil || link.ShareUID != c.Param("uid") {
+		AbortEntityNotFound(c)
 		return
 	}
 
-	link := entity.FindLink(c.Param("link"))
-
 	if err := link.Delete(); err != nil {
 		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": txt.UcFirst(err.Error())})
 		return
@@ -78,15 +80,22 @@ func DeleteLink(c *gin.Context) {
 	c.JSON(http.StatusOK, link)
 }
 
-// CreateLink returns a new link entity initialized with request data
-func CreateLink(c *gin.Context) {
-	s := Auth(SessionID(c), acl.ResourceLinks, acl.ActionCreate)
+// ownsAlbum returns true if the album exists and the session user may share it.
+func ownsAlbum(c *gin.Context) bool {
+	m, err := query.AlbumByUID(c.Param("uid"))
 
-	if s.Invalid() {
-		AbortUnauthorized(c)
-		return
-	}
+	return err == nil && AuthSession(c).Owns(m.OwnerUID)
+}
+
+// ownsPhoto returns true if the photo exists and the session user may share it.
+func ownsPhoto(c *gin.Context) bool {
+	m, err := query.PhotoByUID(c.Param("uid"))
+
+	return err == nil && AuthSession(c).Owns(m.OwnerUID)
+}
 
+// CreateLink returns a new link entity initialized with request data
+func CreateLink(c *gin.Context) {
 	var f form.Link
 
 	if err := c.BindJSON(&f); err != nil {
@@ -100,6 +109,9 @@ func CreateLink(c *gin.Context) {
 	link.MaxViews = f.MaxViews
 	link.LinkExpires = f.LinkExpires
 
+	// Guests may only upload into shared albums.
+	link.CanUpload = f.CanUpload && rnd.IsPPID(link.ShareUID, 'a')
+
 	if f.Password != "" {
 		if err := link.SetPassword(f.Password); err != nil {
 			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": txt.UcFirst(err.Error())})
@@ -119,36 +131,50 @@ func CreateLink(c *gin.Context) {
 
 // POST /api/v1/albums/:uid/links
 func CreateAlbumLink(router *gin.RouterGroup) {
-	router.POST("/albums/:uid/links", func(c *gin.Context) {
-		if _, err := query.AlbumByUID(c.Param("uid")); err != nil {
+	router.POST("/albums/:uid/links", Authorize(acl.ResourceLinks, acl.ActionCreate), func(c *gin.Context) {
+		if !ownsAlbum(c) {
 			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
 			return
 		}
 
 		CreateLink(c)
+
+		if !c.IsAborted() {
+			PublishAlbumEvent(EntityShared, c.Param("uid"), c)
+		}
 	})
 }
 
 // PUT /api/v1/albums/:uid/links/:link
 func UpdateAlbumLink(router *gin.RouterGroup) {
-	router.PUT("/albums/:uid/links/:link", func(c *gin.Context) {
+	router.PUT("/albums/:uid/links/:link", Authorize(acl.ResourceLinks, acl.ActionUpdate), func(c *gin.Context) {
+		if !ownsAlbum(c) {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		}
+
 		UpdateLink(c)
 	})
 }
 
 // DELETE /api/v1/albums/:uid/links/:link
 func DeleteAlbumLink(router *gin.RouterGroup) {
-	router.DELETE("/albums/:uid/links/:link", func(c *gin.Context) {
+	router.DELETE("/albums/:uid/links/:link", Authorize(acl.ResourceLinks, acl.ActionDelete), func(c *gin.Context) {
+		if !ownsAlbum(c) {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		}
+
 		DeleteLink(c)
 	})
 }
 
 // GET /api/v1/albums/:uid/links
 func GetAlbumLinks(router *gin.RouterGroup) {
-	router.GET("/albums/:uid/links", func(c *gin.Context) {
+	router.GET("/albums/:uid/links", Authorize(acl.ResourceLinks, acl.ActionRead), func(c *gin.Context) {
 		m, err := query.AlbumByUID(c.Param("uid"))
 
-		if err != nil {
+		if err != nil || !AuthSession(c).Owns(m.OwnerUID) {
 			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
 			return
 		}
@@ -159,8 +185,8 @@ func GetAlbumLinks(router *gin.RouterGroup) {
 
 // POST /api/v1/photos/:uid/links
 func CreatePhotoLink(router *gin.RouterGroup) {
-	router.POST("/photos/:uid/links", func(c *gin.Context) {
-		if _, err := query.PhotoByUID(c.Param("uid")); err != nil {
+	router.POST("/photos/:uid/links", Authorize(acl.ResourceLinks, acl.ActionCreate), func(c *gin.Context) {
+		if !ownsPhoto(c) {
 			AbortEntityNotFound(c)
 			return
 		}
@@ -171,24 +197,34 @@ func CreatePhotoLink(router *gin.RouterGroup) {
 
 // PUT /api/v1/photos/:uid/links/:link
 func UpdatePhotoLink(router *gin.RouterGroup) {
-	router.PUT("/photos/:uid/links/:link", func(c *gin.Context) {
+	router.PUT("/photos/:uid/links/:link", Authorize(acl.ResourceLinks, acl.ActionUpdate), func(c *gin.Context) {
+		if !ownsPhoto(c) {
+			AbortEntityNotFound(c)
+			return
+		}
+
 		UpdateLink(c)
 	})
 }
 
 // DELETE /api/v1/photos/:uid/links/:link
 func DeletePhotoLink(router *gin.RouterGroup) {
-	router.DELETE("/photos/:uid/links/:link", func(c *gin.Context) {
+	router.DELETE("/photos/:uid/links/:link", Authorize(acl.ResourceLinks, acl.ActionDelete), func(c *gin.Context) {
+		if !ownsPhoto(c) {
+			AbortEntityNotFound(c)
+			return
+		}
+
 		DeleteLink(c)
 	})
 }
 
 // GET /api/v1/photos/:uid/links
 func GetPhotoLinks(router *gin.RouterGroup) {
-	router.GET("/photos/:uid/links", func(c *gin.Context) {
+	router.GET("/photos/:uid/links", Authorize(acl.ResourceLinks, acl.ActionRead), func(c *gin.Context) {
 		m, err := query.PhotoByUID(c.Param("uid"))
 
-		if err != nil {
+		if err != nil || !AuthSession(c).Owns(m.OwnerUID) {
 			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
 			return
 		}
@@ -199,7 +235,13 @@ func GetPhotoLinks(router *gin.RouterGroup) {
 
 // POST /api/v1/labels/:uid/links
 func CreateLabelLink(router *gin.RouterGroup) {
-	router.POST("/labels/:uid/links", func(c *gin.Context) {
+	router.POST("/labels/:uid/links", Authorize(acl.ResourceLinks, acl.ActionCreate), func(c *gin.Context) {
+		// Labels are not owned by users, so that only admins may share them.
+		if AuthSession(c).Restricted() {
+			AbortForbidden(c)
+			return
+		}
+
 		if _, err := query.LabelByUID(c.Param("uid")); err != nil {
 			Abort(c, http.StatusNotFound, i18n.ErrLabelNotFound)
 			return
@@ -211,21 +253,36 @@ func CreateLabelLink(router *gin.RouterGroup) {
 
 // PUT /api/v1/labels/:uid/links/:link
 func UpdateLabelLink(router *gin.RouterGroup) {
-	router.PUT("/labels/:uid/links/:link", func(c *gin.Context) {
+	router.PUT("/labels/:uid/links/:link", Authorize(acl.ResourceLinks, acl.ActionUpdate), func(c *gin.Context) {
+		if AuthSession(c).Restricted() {
+			AbortForbidden(c)
+			return
+		}
+
 		UpdateLink(c)
 	})
 }
 
 // DELETE /api/v1/labels/:uid/links/:link
 func DeleteLabelLink(router *gin.RouterGroup) {
-	router.DELETE("/labels/:uid/links/:link", func(c *gin.Context) {
+	router.DELETE("/labels/:uid/links/:link", Authorize(acl.ResourceLinks, acl.ActionDelete), func(c *gin.Context) {
+		if AuthSession(c).Restricted() {
+			AbortForbidden(c)
+			return
+		}
+
 		DeleteLink(c)
 	})
 }
 
 // GET /api/v1/labels/:uid/links
 func GetLabelLinks(router *gin.RouterGroup) {
-	router.GET("/labels/:uid/links", func(c *gin.Context) {
+	router.GET("/labels/:uid/links", Authorize(acl.ResourceLinks, acl.ActionRead), func(c *gin.Context) {
+		if AuthSession(c).Restricted() {
+			AbortForbidden(c)
+			return
+		}
+
 		m, err := query.LabelByUID(c.Param("uid"))
 
 		if err != nil {
@@ -35,6 +35,21 @@ func TestLinkAlbum(t *testing.T) {
 		assert.Equal(t, 0, link.LinkExpires)
 		assert.False(t, link.CanComment)
 		assert.True(t, link.CanEdit)
+
+		t.Run("update through other album", func(t *testing.T) {
+			UpdateAlbumLink(router)
+			r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/at9lxuqxpogaaba8/links/"+link.LinkUID, `{"CanComment": true}`)
+			assert.Equal(t, http.StatusNotFound, r.Code)
+		})
+		t.Run("delete through other album", func(t *testing.T) {
+			DeleteAlbumLink(router)
+			r := PerformRequest(app, "DELETE", "/api/v1/albums/at9lxuqxpogaaba8/links/"+link.LinkUID)
+			assert.Equal(t, http.StatusNotFound, r.Code)
+		})
+		t.Run("delete", func(t *testing.T) {
+			r := PerformRequest(app, "DELETE", "/api/v1/albums/at9lxuqxpogaaba7/links/"+link.LinkUID)
+			assert.Equal(t, http.StatusOK, r.Code)
+		})
 	})
 	t.Run("album does not exist", func(t *testing.T) {
 		app, router, _ := NewApiTest()
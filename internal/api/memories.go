@@ -0,0 +1,85 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+// GET /api/v1/memories
+//
+// Returns photos taken on this day in previous years, most recent years first.
+//
+// Parameters:
+//   year: int Current year (optional)
+//   month: int Month, defaults to today (optional)
+//   day: int Day of month, defaults to today (optional)
+//   quality: int Minimum quality (optional)
+//   favorite: bool Favorites only (optional)
+//   dist: int Maximum perceptual hash distance of near-duplicates (optional)
+//   count: int Maximum number of results (optional)
+func GetMemories(router *gin.RouterGroup) {
+	router.GET("/memories", Authorize(acl.ResourcePhotos, acl.ActionSearch), func(c *gin.Context) {
+		s := AuthSession(c)
+
+		var f form.MemorySearch
+
+		if err := c.MustBindWith(&f, binding.Form); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		// Registered users without admin role only see their own and shared photos.
+		if s.Restricted() {
+			f.Owner = s.User.PersonUID
+			f.Shared = s.Shares.String()
+		}
+
+		result, err := query.MemoriesOnDay(f)
+
+		if err != nil {
+			log.Errorf("memories: %s", err)
+			AbortBadRequest(c)
+			return
+		}
+
+		c.Header("X-Count", strconv.Itoa(len(result)))
+		c.Header("X-Limit", strconv.Itoa(f.Count))
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// GET /api/v1/memories/highlights
+//
+// Returns the highlights picked from the memories of today.
+func GetHighlights(router *gin.RouterGroup) {
+	router.GET("/memories/highlights", Authorize(acl.ResourcePhotos, acl.ActionSearch), func(c *gin.Context) {
+		s := AuthSession(c)
+
+		var f form.MemorySearch
+
+		if s.Restricted() {
+			f.Owner = s.User.PersonUID
+			f.Shared = s.Shares.String()
+		}
+
+		result, err := query.HighlightsOnDay(time.Now(), f)
+
+		if err != nil {
+			log.Errorf("memories: %s", err)
+			AbortBadRequest(c)
+			return
+		}
+
+		c.Header("X-Count", strconv.Itoa(len(result)))
+
+		c.JSON(http.StatusOK, result)
+	})
+}
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMemories(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetMemories(router)
+		r := PerformRequest(app, "GET", "/api/v1/memories?year=2020&month=7&day=17&count=10")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "10", r.Header().Get("X-Limit"))
+	})
+	t.Run("today", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetMemories(router)
+		r := PerformRequest(app, "GET", "/api/v1/memories")
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("invalid date", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetMemories(router)
+		r := PerformRequest(app, "GET", "/api/v1/memories?month=13&day=1")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestGetHighlights(t *testing.T) {
+	app, router, _ := NewApiTest()
+	GetHighlights(router)
+	r := PerformRequest(app, "GET", "/api/v1/memories/highlights")
+	assert.Equal(t, http.StatusOK, r.Code)
+}
@@ -12,14 +12,7 @@ import (
 
 // GET /api/v1/moments/time
 func GetMomentsTime(router *gin.RouterGroup) {
-	router.GET("/moments/time", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourceAlbums, acl.ActionExport)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
-
+	router.GET("/moments/time", Authorize(acl.ResourceAlbums, acl.ActionExport), func(c *gin.Context) {
 		result, err := query.MomentsTime(1)
 
 		if err != nil {
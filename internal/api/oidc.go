@@ -0,0 +1,60 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	gc "github.com/patrickmn/go-cache"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/session"
+)
+
+// Pending single sign-on logins, the state is mapped to the nonce expected in the ID token.
+var oidcLogins = gc.New(10*time.Minute, 20*time.Minute)
+
+// NewOIDCLogin returns a new random state and nonce for a single sign-on login.
+func NewOIDCLogin() (state, nonce string) {
+	state = session.NewID()
+	nonce = session.NewID()
+
+	oidcLogins.SetDefault(state, nonce)
+
+	return state, nonce
+}
+
+// OIDCLoginNonce returns the nonce of a pending login and removes it so that it can't be used twice.
+func OIDCLoginNonce(state string) string {
+	if nonce, ok := oidcLogins.Get(state); ok {
+		oidcLogins.Delete(state)
+		return nonce.(string)
+	}
+
+	return ""
+}
+
+// GET /api/v1/oidc
+//
+// Returns the identity provider URL users must be redirected to for single sign-on.
+func GetOIDC(router *gin.RouterGroup) {
+	router.GET("/oidc", func(c *gin.Context) {
+		provider := service.OIDC()
+
+		if provider == nil {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		state, nonce := NewOIDCLogin()
+
+		u, err := provider.AuthURL(state, nonce)
+
+		if err != nil {
+			Error(c, http.StatusBadGateway, err, i18n.ErrConnectionFailed)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"url": u, "state": state})
+	})
+}
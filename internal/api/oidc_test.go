@@ -0,0 +1,26 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetOIDC(t *testing.T) {
+	t.Run("not configured", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetOIDC(router)
+		r := PerformRequest(app, "GET", "/api/v1/oidc")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
+
+func TestOIDCLoginNonce(t *testing.T) {
+	state, nonce := NewOIDCLogin()
+
+	assert.NotEqual(t, state, nonce)
+	assert.Equal(t, nonce, OIDCLoginNonce(state))
+	assert.Equal(t, "", OIDCLoginNonce(state))
+	assert.Equal(t, "", OIDCLoginNonce("invalid"))
+}
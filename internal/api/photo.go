@@ -38,17 +38,12 @@ func SavePhotoAsYaml(p entity.Photo) {
 // Parameters:
 //   uid: string PhotoUID as returned by the API
 func GetPhoto(router *gin.RouterGroup) {
-	router.GET("/photos/:uid", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionRead)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
+	router.GET("/photos/:uid", Authorize(acl.ResourcePhotos, acl.ActionRead), func(c *gin.Context) {
+		s := AuthSession(c)
 
 		p, err := query.PhotoPreloadByUID(c.Param("uid"))
 
-		if err != nil {
+		if err != nil || !s.Owns(p.OwnerUID) && !s.HasLink(p.LinkUID) && !query.PhotoInAlbums(p.PhotoUID, s.Shares) {
 			AbortEntityNotFound(c)
 			return
 		}
@@ -59,19 +54,14 @@ func GetPhoto(router *gin.RouterGroup) {
 
 // PUT /api/v1/photos/:uid
 func UpdatePhoto(router *gin.RouterGroup) {
-	router.PUT("/photos/:uid", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
+	router.PUT("/photos/:uid", Authorize(acl.ResourcePhotos, acl.ActionUpdate), func(c *gin.Context) {
+		s := AuthSession(c)
 
 		conf := service.Config()
 		uid := c.Param("uid")
 		m, err := query.PhotoByUID(uid)
 
-		if err != nil {
+		if err != nil || !s.Owns(m.OwnerUID) {
 			AbortEntityNotFound(c)
 			return
 		}
@@ -157,17 +147,12 @@ func GetPhotoDownload(router *gin.RouterGroup) {
 // Parameters:
 //   uid: string PhotoUID as returned by the API
 func GetPhotoYaml(router *gin.RouterGroup) {
-	router.GET("/photos/:uid/yaml", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionExport)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
+	router.GET("/photos/:uid/yaml", Authorize(acl.ResourcePhotos, acl.ActionExport), func(c *gin.Context) {
+		s := AuthSession(c)
 
 		p, err := query.PhotoPreloadByUID(c.Param("uid"))
 
-		if err != nil {
+		if err != nil || !s.Owns(p.OwnerUID) {
 			c.AbortWithStatus(http.StatusNotFound)
 			return
 		}
@@ -192,18 +177,13 @@ func GetPhotoYaml(router *gin.RouterGroup) {
 // Parameters:
 //   uid: string PhotoUID as returned by the API
 func ApprovePhoto(router *gin.RouterGroup) {
-	router.POST("/photos/:uid/approve", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
+	router.POST("/photos/:uid/approve", Authorize(acl.ResourcePhotos, acl.ActionUpdate), func(c *gin.Context) {
+		s := AuthSession(c)
 
 		id := c.Param("uid")
 		m, err := query.PhotoByUID(id)
 
-		if err != nil {
+		if err != nil || !s.Owns(m.OwnerUID) {
 			AbortEntityNotFound(c)
 			return
 		}
@@ -227,18 +207,13 @@ func ApprovePhoto(router *gin.RouterGroup) {
 // Parameters:
 //   uid: string PhotoUID as returned by the API
 func LikePhoto(router *gin.RouterGroup) {
-	router.POST("/photos/:uid/like", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionLike)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
+	router.POST("/photos/:uid/like", Authorize(acl.ResourcePhotos, acl.ActionLike), func(c *gin.Context) {
+		s := AuthSession(c)
 
 		id := c.Param("uid")
 		m, err := query.PhotoByUID(id)
 
-		if err != nil {
+		if err != nil || !s.Owns(m.OwnerUID) {
 			AbortEntityNotFound(c)
 			return
 		}
@@ -249,6 +224,10 @@ func LikePhoto(router *gin.RouterGroup) {
 			return
 		}
 
+		if err := entity.AddLike(m.PhotoUID, s.User); err != nil {
+			log.Errorf("photo: %s", err)
+		}
+
 		SavePhotoAsYaml(m)
 
 		PublishPhotoEvent(EntityUpdated, id, c)
@@ -262,18 +241,13 @@ func LikePhoto(router *gin.RouterGroup) {
 // Parameters:
 //   uid: string PhotoUID as returned by the API
 func DislikePhoto(router *gin.RouterGroup) {
-	router.DELETE("/photos/:uid/like", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionLike)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
+	router.DELETE("/photos/:uid/like", Authorize(acl.ResourcePhotos, acl.ActionLike), func(c *gin.Context) {
+		s := AuthSession(c)
 
 		id := c.Param("uid")
 		m, err := query.PhotoByUID(id)
 
-		if err != nil {
+		if err != nil || !s.Owns(m.OwnerUID) {
 			AbortEntityNotFound(c)
 			return
 		}
@@ -284,6 +258,10 @@ func DislikePhoto(router *gin.RouterGroup) {
 			return
 		}
 
+		if err := entity.RemoveLike(m.PhotoUID, s.User); err != nil {
+			log.Errorf("photo: %s", err)
+		}
+
 		SavePhotoAsYaml(m)
 
 		PublishPhotoEvent(EntityUpdated, id, c)
@@ -298,16 +276,17 @@ func DislikePhoto(router *gin.RouterGroup) {
 //   uid: string PhotoUID as returned by the API
 //   file_uid: string File UID as returned by the API
 func PhotoFilePrimary(router *gin.RouterGroup) {
-	router.POST("/photos/:uid/files/:file_uid/primary", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
+	router.POST("/photos/:uid/files/:file_uid/primary", Authorize(acl.ResourcePhotos, acl.ActionUpdate), func(c *gin.Context) {
+		s := AuthSession(c)
+
+		uid := c.Param("uid")
+		fileUID := c.Param("file_uid")
 
-		if s.Invalid() {
-			AbortUnauthorized(c)
+		if m, err := query.PhotoByUID(uid); err != nil || !s.Owns(m.OwnerUID) {
+			AbortEntityNotFound(c)
 			return
 		}
 
-		uid := c.Param("uid")
-		fileUID := c.Param("file_uid")
 		err := query.SetPhotoPrimary(uid, fileUID)
 
 		if err != nil {
@@ -336,13 +315,8 @@ func PhotoFilePrimary(router *gin.RouterGroup) {
 //   uid: string Photo UID as returned by the API
 //   file_uid: string File UID as returned by the API
 func PhotoFileUngroup(router *gin.RouterGroup) {
-	router.POST("/photos/:uid/files/:file_uid/ungroup", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
+	router.POST("/photos/:uid/files/:file_uid/ungroup", Authorize(acl.ResourcePhotos, acl.ActionUpdate), func(c *gin.Context) {
+		s := AuthSession(c)
 
 		photoUID := c.Param("uid")
 		fileUID := c.Param("file_uid")
@@ -355,6 +329,11 @@ func PhotoFileUngroup(router *gin.RouterGroup) {
 			return
 		}
 
+		if file.Photo == nil || file.PhotoUID != photoUID || !s.Owns(file.Photo.OwnerUID) {
+			AbortEntityNotFound(c)
+			return
+		}
+
 		if file.FilePrimary {
 			log.Errorf("photo: can't ungroup primary files")
 			AbortBadRequest(c)
@@ -363,6 +342,7 @@ func PhotoFileUngroup(router *gin.RouterGroup) {
 
 		existingPhoto := *file.Photo
 		newPhoto := entity.NewPhoto()
+		newPhoto.OwnerUID = existingPhoto.OwnerUID
 
 		if err := newPhoto.Create(); err != nil {
 			log.Errorf("photo: %s", err.Error())
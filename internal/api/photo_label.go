@@ -19,17 +19,12 @@ import (
 // Parameters:
 //   uid: string PhotoUID as returned by the API
 func AddPhotoLabel(router *gin.RouterGroup) {
-	router.POST("/photos/:uid/label", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
+	router.POST("/photos/:uid/label", Authorize(acl.ResourcePhotos, acl.ActionUpdate), func(c *gin.Context) {
+		s := AuthSession(c)
 
 		m, err := query.PhotoByUID(c.Param("uid"))
 
-		if err != nil {
+		if err != nil || !s.Owns(m.OwnerUID) {
 			AbortEntityNotFound(c)
 			return
 		}
@@ -94,17 +89,12 @@ func AddPhotoLabel(router *gin.RouterGroup) {
 //   uid: string PhotoUID as returned by the API
 //   id: int LabelId as returned by the API
 func RemovePhotoLabel(router *gin.RouterGroup) {
-	router.DELETE("/photos/:uid/label/:id", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
+	router.DELETE("/photos/:uid/label/:id", Authorize(acl.ResourcePhotos, acl.ActionUpdate), func(c *gin.Context) {
+		s := AuthSession(c)
 
 		m, err := query.PhotoByUID(c.Param("uid"))
 
-		if err != nil {
+		if err != nil || !s.Owns(m.OwnerUID) {
 			AbortEntityNotFound(c)
 			return
 		}
@@ -158,19 +148,14 @@ func RemovePhotoLabel(router *gin.RouterGroup) {
 //   uid: string PhotoUID as returned by the API
 //   id: int LabelId as returned by the API
 func UpdatePhotoLabel(router *gin.RouterGroup) {
-	router.PUT("/photos/:uid/label/:id", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
+	router.PUT("/photos/:uid/label/:id", Authorize(acl.ResourcePhotos, acl.ActionUpdate), func(c *gin.Context) {
+		s := AuthSession(c)
 
 		// TODO: Code clean-up, simplify
 
 		m, err := query.PhotoByUID(c.Param("uid"))
 
-		if err != nil {
+		if err != nil || !s.Owns(m.OwnerUID) {
 			AbortEntityNotFound(c)
 			return
 		}
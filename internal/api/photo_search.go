@@ -8,6 +8,7 @@ import (
 	"github.com/gin-gonic/gin/binding"
 	"github.com/photoprism/photoprism/internal/acl"
 	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/query"
 )
 
@@ -26,13 +27,8 @@ import (
 //   after:     date   Find photos taken after (format: "2006-01-02")
 //   favorite:  bool   Find favorites only
 func GetPhotos(router *gin.RouterGroup) {
-	router.GET("/photos", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionSearch)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
+	router.GET("/photos", Authorize(acl.ResourcePhotos, acl.ActionSearch), func(c *gin.Context) {
+		s := AuthSession(c)
 
 		var f form.PhotoSearch
 
@@ -55,6 +51,31 @@ func GetPhotos(router *gin.RouterGroup) {
 			f.Hidden = false
 			f.Archived = false
 			f.Review = false
+		} else if s.Restricted() {
+			// Registered users without admin role only see their own and shared photos.
+			f.Owner = s.User.PersonUID
+			f.Shared = s.Shares.String()
+		}
+
+		// Album contents are resolved on the server, e.g. smart albums based on their saved filter.
+		if f.Album != "" {
+			a, err := query.AlbumByUID(f.Album)
+
+			if err != nil || s.Restricted() && !s.Owns(a.OwnerUID) && !s.HasShare(a.AlbumUID) {
+				Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+				return
+			}
+
+			albumSearch := query.AlbumPhotoSearch(a)
+
+			f.Filter = albumSearch.Filter
+
+			// Keep the owner restriction of registered users, so that albums can't be
+			// used to list photos they aren't allowed to see otherwise.
+			if s.Guest() || !s.Restricted() {
+				f.Owner = albumSearch.Owner
+				f.Shared = ""
+			}
 		}
 
 		result, count, err := query.PhotoSearch(f)
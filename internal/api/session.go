@@ -2,15 +2,20 @@ package api
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/auth"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/service"
 	"github.com/photoprism/photoprism/internal/session"
 )
 
+const sessionKey = "session"
+
 // POST /api/v1/session
 func CreateSession(router *gin.RouterGroup) {
 	router.POST("/session", func(c *gin.Context) {
@@ -35,42 +40,182 @@ func CreateSession(router *gin.RouterGroup) {
 		conf := service.Config()
 
 		if f.HasToken() {
+			clientIP := c.ClientIP()
+
+			if wait := auth.Clients.Wait(clientIP); wait > 0 {
+				auth.Throttled("share", "", clientIP, wait)
+				AbortTooManyRequests(c, wait)
+				return
+			}
+
 			links := entity.FindValidLinks(f.Token, "")
 
 			if len(links) == 0 {
+				auth.Clients.Failed(clientIP)
 				c.AbortWithStatusJSON(400, gin.H{"error": "Invalid link"})
+				return
 			}
 
-			data.Tokens = []string{f.Token}
-
 			for _, link := range links {
-				data.Shares = append(data.Shares, link.ShareUID)
-				link.Redeem()
+				if !link.HasPassword {
+					continue
+				} else if f.Password == "" {
+					c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Password required", "password": true})
+					return
+				} else if link.InvalidPassword(f.Password) {
+					auth.Failed("share", "", clientIP, auth.Clients.Failed(clientIP))
+					c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid password", "password": true})
+					return
+				}
+			}
+
+			data.AddToken(f.Token)
+
+			for i := range links {
+				links[i].Redeem()
 			}
 
+			data.RefreshShares()
+
 			// Upgrade from anonymous to guest. Don't downgrade.
 			if data.User.Anonymous() {
 				data.User = entity.Guest
 			}
+		} else if f.HasChallenge() {
+			clientIP := c.ClientIP()
+
+			if wait := auth.Clients.Wait(clientIP); wait > 0 {
+				auth.Throttled("session", "", clientIP, wait)
+				AbortTooManyRequests(c, wait)
+				return
+			}
+
+			user := LoginChallengeUser(f.Challenge)
+
+			if user == nil || user.Disabled() {
+				auth.Clients.Failed(clientIP)
+				c.AbortWithStatusJSON(400, gin.H{"error": "Verification expired, please log in again"})
+				return
+			}
+
+			if wait := user.LoginWait(); wait > 0 {
+				auth.Throttled("session", user.UserName, clientIP, wait)
+				AbortTooManyRequests(c, wait)
+				return
+			}
+
+			if tf := entity.FindTwoFactor(user.PersonUID); tf == nil || !tf.Verify(f.Code) {
+				auth.Clients.Failed(clientIP)
+				auth.Failed("2fa", user.UserName, clientIP, user.LoginFailed())
+				c.AbortWithStatusJSON(400, gin.H{"error": "Invalid verification code"})
+				return
+			}
+
+			loginChallenges.Delete(f.Challenge)
+			user.LoginSucceeded()
+
+			data.User = *user
+		} else if f.HasState() {
+			clientIP := c.ClientIP()
+
+			if wait := auth.Clients.Wait(clientIP); wait > 0 {
+				auth.Throttled("oidc", "", clientIP, wait)
+				AbortTooManyRequests(c, wait)
+				return
+			}
+
+			provider := service.OIDC()
+
+			if provider == nil {
+				AbortFeatureDisabled(c)
+				return
+			}
+
+			nonce := OIDCLoginNonce(f.State)
+
+			if nonce == "" {
+				auth.Clients.Failed(clientIP)
+				c.AbortWithStatusJSON(400, gin.H{"error": "Login expired, please try again"})
+				return
+			}
+
+			claims, err := provider.Login(f.Code, nonce)
+
+			if err != nil {
+				log.Errorf("%s", err)
+				auth.Clients.Failed(clientIP)
+				c.AbortWithStatusJSON(400, gin.H{"error": "Single sign-on failed, please try again"})
+				return
+			}
+
+			user, err := entity.OIDCPerson(claims, conf.OIDCRole(claims.Groups))
+
+			if err != nil {
+				log.Errorf("%s", err)
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Access denied, please contact your administrator"})
+				return
+			}
+
+			user.LoginSucceeded()
+
+			data.User = *user
 		} else if f.HasCredentials() {
+			clientIP := c.ClientIP()
+
+			if wait := auth.Clients.Wait(clientIP); wait > 0 {
+				auth.Throttled("session", f.UserName, clientIP, wait)
+				AbortTooManyRequests(c, wait)
+				return
+			}
+
 			user := entity.FindPersonByUserName(f.UserName)
 
-			if user == nil {
+			// Deactivated users are rejected like unknown users.
+			if user == nil || user.Disabled() {
+				auth.Failed("session", f.UserName, clientIP, auth.Clients.Failed(clientIP))
 				c.AbortWithStatusJSON(400, gin.H{"error": "Invalid user name or password"})
 				return
 			}
 
+			if wait := user.LoginWait(); wait > 0 {
+				auth.Throttled("session", f.UserName, clientIP, wait)
+				AbortTooManyRequests(c, wait)
+				return
+			}
+
 			if user.InvalidPassword(f.Password) {
+				auth.Clients.Failed(clientIP)
+				auth.Failed("session", f.UserName, clientIP, user.LoginFailed())
 				c.AbortWithStatusJSON(400, gin.H{"error": "Invalid user name or password"})
 				return
 			}
 
+			// Users with two-factor authentication must verify a code in a second step,
+			// unless it was already submitted together with the password.
+			if tf := entity.FindTwoFactor(user.PersonUID); tf != nil && tf.Enabled {
+				if !f.HasCode() {
+					c.JSON(http.StatusAccepted, gin.H{"status": "verify", "challenge": NewLoginChallenge(user)})
+					return
+				} else if !tf.Verify(f.Code) {
+					auth.Clients.Failed(clientIP)
+					auth.Failed("2fa", f.UserName, clientIP, user.LoginFailed())
+					c.AbortWithStatusJSON(400, gin.H{"error": "Invalid verification code"})
+					return
+				}
+			}
+
+			user.LoginSucceeded()
+			auth.Clients.Reset(clientIP)
+
 			data.User = *user
 		} else {
 			c.AbortWithStatusJSON(400, gin.H{"error": "Password required, please try again"})
 			return
 		}
 
+		data.UserAgent = c.Request.UserAgent()
+		data.ClientIP = c.ClientIP()
+
 		if err := service.Session().Update(id, data); err != nil {
 			id = service.Session().Create(data)
 		}
@@ -96,11 +241,104 @@ func DeleteSession(router *gin.RouterGroup) {
 	})
 }
 
+// GET /api/v1/users/:uid/sessions
+//
+// Parameters:
+//   uid: string User UID as returned by the API
+func GetUserSessions(router *gin.RouterGroup) {
+	router.GET("/users/:uid/sessions", Authorize(acl.ResourcePeople, acl.ActionUpdateSelf), func(c *gin.Context) {
+		s := AuthSession(c)
+		uid := c.Param("uid")
+
+		if !s.User.Admin() && s.User.PersonUID != uid {
+			Abort(c, http.StatusNotFound, i18n.ErrUserNotFound)
+			return
+		}
+
+		result := service.Session().List(uid)
+		current := entity.SessionHash(SessionID(c))
+
+		for i := range result {
+			result[i].Current = result[i].ID == current
+		}
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// DELETE /api/v1/users/:uid/sessions/:sid
+//
+// Parameters:
+//   uid: string User UID as returned by the API
+//   sid: string Session UID as returned by the API
+func DeleteUserSession(router *gin.RouterGroup) {
+	router.DELETE("/users/:uid/sessions/:sid", Authorize(acl.ResourcePeople, acl.ActionUpdateSelf), func(c *gin.Context) {
+		s := AuthSession(c)
+		uid := c.Param("uid")
+		sid := c.Param("sid")
+
+		if !s.User.Admin() && s.User.PersonUID != uid {
+			Abort(c, http.StatusNotFound, i18n.ErrUserNotFound)
+			return
+		}
+
+		if err := service.Session().Revoke(uid, sid); err != nil {
+			log.Debug(err)
+			AbortEntityNotFound(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"status": "ok", "uid": sid})
+	})
+}
+
+// DELETE /api/v1/users/:uid/sessions
+//
+// Parameters:
+//   uid: string User UID as returned by the API
+func DeleteUserSessions(router *gin.RouterGroup) {
+	router.DELETE("/users/:uid/sessions", Authorize(acl.ResourcePeople, acl.ActionUpdateSelf), func(c *gin.Context) {
+		s := AuthSession(c)
+		uid := c.Param("uid")
+
+		if !s.User.Admin() && s.User.PersonUID != uid {
+			Abort(c, http.StatusNotFound, i18n.ErrUserNotFound)
+			return
+		}
+
+		count := service.Session().RevokeAll(uid)
+
+		c.JSON(http.StatusOK, gin.H{"status": "ok", "count": count})
+	})
+}
+
 // Gets session id from HTTP header.
 func SessionID(c *gin.Context) string {
 	return c.GetHeader("X-Session-ID")
 }
 
+// AppToken returns the app token secret from the HTTP Authorization header.
+func AppToken(c *gin.Context) string {
+	if auth := c.GetHeader("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		return strings.TrimSpace(strings.TrimPrefix(auth, "Bearer "))
+	}
+
+	return ""
+}
+
+// TokenSession returns session data for the user an app token belongs to.
+func TokenSession(token *entity.AppToken) session.Data {
+	user := entity.FindPersonByUID(token.UserUID)
+
+	if user == nil || !user.UserActive {
+		return session.Data{}
+	}
+
+	token.Used()
+
+	return session.Data{User: *user}
+}
+
 // Session returns the current session data.
 func Session(id string) session.Data {
 	// Return fake admin session if site is public.
@@ -109,7 +347,14 @@ func Session(id string) session.Data {
 	}
 
 	// Check if session id is valid.
-	return service.Session().Get(id)
+	sess := service.Session().Get(id)
+
+	// Remove shares whose links have expired in the meantime.
+	if len(sess.Tokens) > 0 {
+		sess.RefreshShares()
+	}
+
+	return sess
 }
 
 // Auth returns the session if user is authorized for the current action.
@@ -123,6 +368,56 @@ func Auth(id string, resource acl.Resource, action acl.Action) session.Data {
 	return sess
 }
 
+// Authorize returns a middleware that aborts the request if the session user is not
+// allowed to perform the action on the resource according to acl.Permissions.
+func Authorize(resource acl.Resource, action acl.Action) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var sess session.Data
+
+		// App tokens are accepted instead of a session if their scope includes the action.
+		if secret := AppToken(c); secret != "" && !service.Config().Public() {
+			token := entity.FindAppToken(secret)
+
+			if token == nil {
+				AbortUnauthorized(c)
+				return
+			}
+
+			if !token.Allow(resource, action) {
+				AbortForbidden(c)
+				return
+			}
+
+			sess = TokenSession(token)
+		} else {
+			sess = Session(SessionID(c))
+		}
+
+		if sess.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		if acl.Permissions.Deny(resource, sess.User.Role(), action) {
+			AbortForbidden(c)
+			return
+		}
+
+		c.Set(sessionKey, sess)
+	}
+}
+
+// AuthSession returns the session data of a request that passed Authorize.
+func AuthSession(c *gin.Context) session.Data {
+	if s, ok := c.Get(sessionKey); ok {
+		if sess, ok := s.(session.Data); ok {
+			return sess
+		}
+	}
+
+	return Session(SessionID(c))
+}
+
 // InvalidPreviewToken returns true if the token is invalid.
 func InvalidPreviewToken(c *gin.Context) bool {
 	token := c.Param("token")
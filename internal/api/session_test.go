@@ -1,6 +1,13 @@
 package api
 
 import (
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/auth"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/rnd"
 	"github.com/stretchr/testify/assert"
 	"github.com/tidwall/gjson"
 	"net/http"
@@ -30,6 +37,70 @@ func TestCreateSession(t *testing.T) {
 		assert.Equal(t, "Invalid user name or password", val.String())
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
+	t.Run("share token", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateSession(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/session", `{"token": "1jxf3jfn2k"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "st9lxuqxpogaaba7", gjson.Get(r.Body.String(), "data.shares.0").String())
+	})
+	t.Run("invalid share token", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateSession(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/session", `{"token": "xxx"}`)
+		val := gjson.Get(r.Body.String(), "error")
+		assert.Equal(t, "Invalid link", val.String())
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("share password", func(t *testing.T) {
+		link := entity.NewLink(rnd.PPID('a'), false, false)
+
+		if err := link.SetPassword("secret"); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := link.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		app, router, _ := NewApiTest()
+		CreateSession(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/session", `{"token": "`+link.LinkToken+`"}`)
+		assert.Equal(t, "Password required", gjson.Get(r.Body.String(), "error").String())
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+
+		r = PerformRequestWithBody(app, "POST", "/api/v1/session", `{"token": "`+link.LinkToken+`", "password": "xxx"}`)
+		assert.Equal(t, "Invalid password", gjson.Get(r.Body.String(), "error").String())
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+
+		r = PerformRequestWithBody(app, "POST", "/api/v1/session", `{"token": "`+link.LinkToken+`", "password": "secret"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, link.ShareUID, gjson.Get(r.Body.String(), "data.shares.0").String())
+	})
+	t.Run("single sign-on disabled", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateSession(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/session", `{"state": "abc", "code": "xyz"}`)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("too many requests", func(t *testing.T) {
+		if _, err := entity.CreatePerson(form.User{UserName: "throttled", Password: "photoprism"}); err != nil {
+			t.Fatal(err)
+		}
+
+		app, router, _ := NewApiTest()
+		CreateSession(router)
+
+		for i := 0; i < auth.AccountAttempts; i++ {
+			r := PerformRequestWithBody(app, "POST", "/api/v1/session", `{"username": "throttled", "password": "xxx"}`)
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+		}
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/session", `{"username": "throttled", "password": "photoprism"}`)
+		assert.Equal(t, http.StatusTooManyRequests, r.Code)
+		assert.NotEmpty(t, r.Header().Get("Retry-After"))
+	})
 }
 
 func TestDeleteSession(t *testing.T) {
@@ -45,3 +116,72 @@ func TestDeleteSession(t *testing.T) {
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
 }
+
+func TestAuthorize(t *testing.T) {
+	t.Run("public mode", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		router.GET("/authorize", Authorize(acl.ResourceLogs, acl.ActionSearch), func(c *gin.Context) {
+			s := AuthSession(c)
+			c.JSON(http.StatusOK, gin.H{"admin": s.User.Admin()})
+		})
+		r := PerformRequest(app, "GET", "/api/v1/authorize")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Get(r.Body.String(), "admin").Bool())
+	})
+}
+
+func TestGetUserSessions(t *testing.T) {
+	app, router, _ := NewApiTest()
+	CreateSession(router)
+	r := PerformRequestWithBody(app, "POST", "/api/v1/session", `{"username": "admin", "password": "photoprism"}`)
+	assert.Equal(t, http.StatusOK, r.Code)
+
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetUserSessions(router)
+		r := PerformRequest(app, "GET", "/api/v1/users/"+entity.Admin.PersonUID+"/sessions")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.LessOrEqual(t, int64(1), gjson.Get(r.Body.String(), "#").Int())
+		assert.NotEmpty(t, gjson.Get(r.Body.String(), "0.UID").String())
+		assert.False(t, gjson.Get(r.Body.String(), "0.ID").Exists())
+	})
+}
+
+func TestDeleteUserSession(t *testing.T) {
+	app, router, _ := NewApiTest()
+	CreateSession(router)
+	GetUserSessions(router)
+	r := PerformRequestWithBody(app, "POST", "/api/v1/session", `{"username": "admin", "password": "photoprism"}`)
+	assert.Equal(t, http.StatusOK, r.Code)
+	r = PerformRequest(app, "GET", "/api/v1/users/"+entity.Admin.PersonUID+"/sessions")
+	sid := gjson.Get(r.Body.String(), "0.UID").String()
+
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		DeleteUserSession(router)
+		r := PerformRequest(app, "DELETE", "/api/v1/users/"+entity.Admin.PersonUID+"/sessions/"+sid)
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		DeleteUserSession(router)
+		r := PerformRequest(app, "DELETE", "/api/v1/users/"+entity.Admin.PersonUID+"/sessions/xxx")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestDeleteUserSessions(t *testing.T) {
+	app, router, _ := NewApiTest()
+	CreateSession(router)
+	r := PerformRequestWithBody(app, "POST", "/api/v1/session", `{"username": "admin", "password": "photoprism"}`)
+	id := gjson.Get(r.Body.String(), "id").String()
+
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		DeleteUserSessions(router)
+		r := PerformRequest(app, "DELETE", "/api/v1/users/"+entity.Admin.PersonUID+"/sessions")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.LessOrEqual(t, int64(1), gjson.Get(r.Body.String(), "count").Int())
+		assert.False(t, service.Session().Exists(id))
+	})
+}
@@ -11,14 +11,7 @@ import (
 
 // GET /api/v1/settings
 func GetSettings(router *gin.RouterGroup) {
-	router.GET("/settings", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourceSettings, acl.ActionRead)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
-
+	router.GET("/settings", Authorize(acl.ResourceSettings, acl.ActionRead), func(c *gin.Context) {
 		if settings := service.Config().Settings(); settings != nil {
 			c.JSON(http.StatusOK, settings)
 		} else {
@@ -29,14 +22,7 @@ func GetSettings(router *gin.RouterGroup) {
 
 // POST /api/v1/settings
 func SaveSettings(router *gin.RouterGroup) {
-	router.POST("/settings", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourceSettings, acl.ActionUpdate)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
-
+	router.POST("/settings", Authorize(acl.ResourceSettings, acl.ActionUpdate), func(c *gin.Context) {
 		conf := service.Config()
 
 		if conf.SettingsHidden() {
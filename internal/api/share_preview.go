@@ -12,7 +12,6 @@ import (
 	"github.com/disintegration/imaging"
 	"github.com/gin-gonic/gin"
 	"github.com/photoprism/photoprism/internal/entity"
-	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/service"
@@ -60,10 +59,16 @@ func SharePreview(router *gin.RouterGroup) {
 			return
 		}
 
-		var f form.PhotoSearch
+		a, err := query.AlbumByUID(share)
+
+		if err != nil {
+			log.Warnf("share: %s (preview)", err)
+			c.Redirect(http.StatusTemporaryRedirect, conf.SitePreview())
+			return
+		}
 
 		// Previews may only contain public content in shared albums.
-		f.Album = share
+		f := query.AlbumPhotoSearch(a)
 		f.Public = true
 		f.Private = false
 		f.Hidden = false
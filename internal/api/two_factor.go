@@ -0,0 +1,183 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	gc "github.com/patrickmn/go-cache"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/session"
+)
+
+// Pending logins that passed the password check and wait for a verification code.
+var loginChallenges = gc.New(5*time.Minute, 10*time.Minute)
+
+// NewLoginChallenge returns a random challenge id for the second login step.
+func NewLoginChallenge(user *entity.Person) string {
+	id := session.NewID()
+
+	loginChallenges.SetDefault(id, user.PersonUID)
+
+	return id
+}
+
+// LoginChallengeUser returns the user of a pending login or nil if the challenge has expired.
+func LoginChallengeUser(id string) *entity.Person {
+	if uid, ok := loginChallenges.Get(id); ok {
+		return entity.FindPersonByUID(uid.(string))
+	}
+
+	return nil
+}
+
+// GET /api/v1/users/:uid/2fa
+//
+// Parameters:
+//   uid: string User UID as returned by the API
+func GetTwoFactor(router *gin.RouterGroup) {
+	router.GET("/users/:uid/2fa", Authorize(acl.ResourcePeople, acl.ActionUpdateSelf), func(c *gin.Context) {
+		s := AuthSession(c)
+		uid := c.Param("uid")
+
+		if !s.User.Admin() && s.User.PersonUID != uid {
+			Abort(c, http.StatusNotFound, i18n.ErrUserNotFound)
+			return
+		}
+
+		enabled := false
+
+		if m := entity.FindTwoFactor(uid); m != nil {
+			enabled = m.Enabled
+		}
+
+		c.JSON(http.StatusOK, gin.H{"enabled": enabled})
+	})
+}
+
+// POST /api/v1/users/:uid/2fa
+//
+// Creates a new secret that must be confirmed with a valid code before it is enabled.
+//
+// Parameters:
+//   uid: string User UID as returned by the API
+func CreateTwoFactor(router *gin.RouterGroup) {
+	router.POST("/users/:uid/2fa", Authorize(acl.ResourcePeople, acl.ActionUpdateSelf), func(c *gin.Context) {
+		s := AuthSession(c)
+		uid := c.Param("uid")
+
+		if s.User.PersonUID != uid {
+			Abort(c, http.StatusNotFound, i18n.ErrUserNotFound)
+			return
+		}
+
+		if existing := entity.FindTwoFactor(uid); existing != nil && existing.Enabled {
+			Abort(c, http.StatusConflict, i18n.ErrAlreadyExists, "Two-factor authentication")
+			return
+		}
+
+		m, err := entity.NewTwoFactor(uid)
+
+		if err != nil {
+			Error(c, http.StatusInternalServerError, err, i18n.ErrSaveFailed)
+			return
+		}
+
+		if err := m.Save(); err != nil {
+			Error(c, http.StatusInternalServerError, err, i18n.ErrSaveFailed)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"secret": m.Secret, "url": m.URL(s.User.UserName)})
+	})
+}
+
+// POST /api/v1/users/:uid/2fa/enable
+//
+// Parameters:
+//   uid: string User UID as returned by the API
+func EnableTwoFactor(router *gin.RouterGroup) {
+	router.POST("/users/:uid/2fa/enable", Authorize(acl.ResourcePeople, acl.ActionUpdateSelf), func(c *gin.Context) {
+		s := AuthSession(c)
+		uid := c.Param("uid")
+
+		if s.User.PersonUID != uid {
+			Abort(c, http.StatusNotFound, i18n.ErrUserNotFound)
+			return
+		}
+
+		var f form.TwoFactor
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		m := entity.FindTwoFactor(uid)
+
+		if m == nil || m.Enabled {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		codes, err := m.Enable(f.Code)
+
+		if err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrInvalidCode)
+			return
+		}
+
+		log.Infof("two-factor: enabled for %s", s.User.String())
+
+		// Recovery codes are only returned once and can't be restored later.
+		c.JSON(http.StatusOK, gin.H{"enabled": true, "recovery": codes})
+	})
+}
+
+// DELETE /api/v1/users/:uid/2fa
+//
+// Parameters:
+//   uid: string User UID as returned by the API
+func DeleteTwoFactor(router *gin.RouterGroup) {
+	router.DELETE("/users/:uid/2fa", Authorize(acl.ResourcePeople, acl.ActionUpdateSelf), func(c *gin.Context) {
+		s := AuthSession(c)
+		uid := c.Param("uid")
+
+		if !s.User.Admin() && s.User.PersonUID != uid {
+			Abort(c, http.StatusNotFound, i18n.ErrUserNotFound)
+			return
+		}
+
+		var f form.TwoFactor
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		// Require the password of the current user so that a stolen session isn't enough.
+		if s.User.InvalidPassword(f.Password) {
+			Abort(c, http.StatusBadRequest, i18n.ErrInvalidPassword)
+			return
+		}
+
+		m := entity.FindTwoFactor(uid)
+
+		if m == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		if err := m.Delete(); err != nil {
+			Error(c, http.StatusInternalServerError, err, i18n.ErrDeleteFailed)
+			return
+		}
+
+		log.Infof("two-factor: disabled for %s", uid)
+
+		c.JSON(http.StatusOK, gin.H{"enabled": false})
+	})
+}
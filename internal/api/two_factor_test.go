@@ -0,0 +1,96 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestCreateTwoFactor(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateTwoFactor(router)
+		r := PerformRequest(app, "POST", "/api/v1/users/"+entity.Admin.PersonUID+"/2fa")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, 32, len(gjson.Get(r.Body.String(), "secret").String()))
+		assert.Contains(t, gjson.Get(r.Body.String(), "url").String(), "otpauth://totp/")
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateTwoFactor(router)
+		r := PerformRequest(app, "POST", "/api/v1/users/uxxx/2fa")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestEnableTwoFactor(t *testing.T) {
+	t.Run("invalid code", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateTwoFactor(router)
+		EnableTwoFactor(router)
+		r := PerformRequest(app, "POST", "/api/v1/users/"+entity.Admin.PersonUID+"/2fa")
+		assert.Equal(t, http.StatusOK, r.Code)
+		r = PerformRequestWithBody(app, "POST", "/api/v1/users/"+entity.Admin.PersonUID+"/2fa/enable", `{"code": "123"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		assert.False(t, entity.Admin.TwoFactorEnabled())
+	})
+}
+
+func TestGetTwoFactor(t *testing.T) {
+	app, router, _ := NewApiTest()
+	GetTwoFactor(router)
+	r := PerformRequest(app, "GET", "/api/v1/users/"+entity.Admin.PersonUID+"/2fa")
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.False(t, gjson.Get(r.Body.String(), "enabled").Bool())
+}
+
+func TestCreateSessionTwoFactor(t *testing.T) {
+	user, err := entity.CreatePerson(form.User{UserName: "two-factor-login", Password: "photoprism"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tf, err := entity.NewTwoFactor(user.PersonUID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	code, _ := totp.Code(tf.Secret, time.Now().Add(-totp.Period))
+
+	if _, err := tf.Enable(code); err != nil {
+		t.Fatal(err)
+	}
+
+	app, router, _ := NewApiTest()
+	CreateSession(router)
+
+	r := PerformRequestWithBody(app, "POST", "/api/v1/session", `{"username": "two-factor-login", "password": "photoprism"}`)
+	assert.Equal(t, http.StatusAccepted, r.Code)
+	assert.False(t, gjson.Get(r.Body.String(), "id").Exists())
+	challenge := gjson.Get(r.Body.String(), "challenge").String()
+	assert.NotEmpty(t, challenge)
+
+	t.Run("invalid code", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "POST", "/api/v1/session", `{"challenge": "`+challenge+`", "code": "000000"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("valid code", func(t *testing.T) {
+		code, _ := totp.Code(tf.Secret, time.Now())
+		r := PerformRequestWithBody(app, "POST", "/api/v1/session", `{"challenge": "`+challenge+`", "code": "`+code+`"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.NotEmpty(t, gjson.Get(r.Body.String(), "id").String())
+	})
+	t.Run("challenge used", func(t *testing.T) {
+		code, _ := totp.Code(tf.Secret, time.Now().Add(totp.Period))
+		r := PerformRequestWithBody(app, "POST", "/api/v1/session", `{"challenge": "`+challenge+`", "code": "`+code+`"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
@@ -1,16 +1,22 @@
 package api
 
 import (
+	"mime/multipart"
 	"net/http"
 	"os"
 	"path"
 	"path/filepath"
+	"strconv"
 	"time"
 
 	"github.com/photoprism/photoprism/internal/acl"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/txt"
 
 	"github.com/gin-gonic/gin"
@@ -18,20 +24,13 @@ import (
 
 // POST /api/v1/upload/:path
 func Upload(router *gin.RouterGroup) {
-	router.POST("/upload/:path", func(c *gin.Context) {
+	router.POST("/upload/:path", Authorize(acl.ResourcePhotos, acl.ActionUpload), func(c *gin.Context) {
 		conf := service.Config()
 		if conf.ReadOnly() || !conf.Settings().Features.Upload {
 			Abort(c, http.StatusForbidden, i18n.ErrReadOnly)
 			return
 		}
 
-		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpload)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
-
 		start := time.Now()
 		subPath := c.Param("path")
 
@@ -46,68 +45,190 @@ func Upload(router *gin.RouterGroup) {
 
 		files := f.File["files"]
 		uploaded := len(files)
-		var uploads []string
 
 		p := path.Join(conf.ImportPath(), "upload", subPath)
 
-		if err := os.MkdirAll(p, os.ModePerm); err != nil {
+		uploads, err := saveUploads(c, files, p)
+
+		if err != nil {
 			AbortBadRequest(c)
 			return
 		}
 
-		for _, file := range files {
-			filename := path.Join(p, filepath.Base(file.Filename))
+		if removeOffensiveUploads(uploads) {
+			Abort(c, http.StatusForbidden, i18n.ErrOffensiveUpload)
+			return
+		}
 
-			log.Debugf("upload: saving file %s", txt.Quote(file.Filename))
+		elapsed := int(time.Since(start).Seconds())
 
-			if err := c.SaveUploadedFile(file, filename); err != nil {
-				AbortBadRequest(c)
-				return
-			}
+		msg := i18n.Msg(i18n.MsgFilesUploadedIn, uploaded, elapsed)
 
-			uploads = append(uploads, filename)
+		log.Info(msg)
+
+		c.JSON(http.StatusOK, i18n.Response{Code: http.StatusOK, Msg: msg})
+	})
+}
+
+// POST /api/v1/albums/:uid/upload
+//
+// Uploads files and imports them into an album, guests need a share link that permits uploads.
+// Photos uploaded by guests remain in review until they are approved.
+//
+// Parameters:
+//   uid: string Album UID as returned by the API
+func UploadToAlbum(router *gin.RouterGroup) {
+	router.POST("/albums/:uid/upload", Authorize(acl.ResourceAlbums, acl.ActionUpload), func(c *gin.Context) {
+		s := AuthSession(c)
+		conf := service.Config()
+
+		if conf.ReadOnly() || !conf.Settings().Features.Upload {
+			Abort(c, http.StatusForbidden, i18n.ErrReadOnly)
+			return
+		}
+
+		uid := c.Param("uid")
+
+		if s.Guest() && !s.CanUpload(uid) {
+			AbortForbidden(c)
+			return
+		}
+
+		a, err := query.AlbumByUID(uid)
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		}
+
+		// Uploads are imported right away, so they are rejected while another import or index is running.
+		if mutex.MainWorker.Busy() {
+			Abort(c, http.StatusConflict, i18n.ErrBusy)
+			return
+		}
+
+		start := time.Now()
+
+		f, err := c.MultipartForm()
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
 		}
 
-		if !conf.UploadNSFW() {
-			nd := service.NsfwDetector()
+		event.Publish("upload.start", event.Data{"time": start})
 
-			containsNSFW := false
+		files := f.File["files"]
 
-			for _, filename := range uploads {
-				labels, err := nd.File(filename)
+		// Each upload gets its own folder, so that it can be imported separately.
+		p := path.Join(conf.ImportPath(), "upload", a.AlbumUID, strconv.FormatInt(start.UnixNano(), 36))
 
-				if err != nil {
-					log.Debug(err)
-					continue
-				}
+		uploads, err := saveUploads(c, files, p)
 
-				if labels.IsSafe() {
-					continue
-				}
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
 
-				log.Infof("nsfw: %s might be offensive", txt.Quote(filename))
+		if removeOffensiveUploads(uploads) {
+			Abort(c, http.StatusForbidden, i18n.ErrOffensiveUpload)
+			return
+		}
 
-				containsNSFW = true
-			}
+		opt := photoprism.ImportOptionsMove(p)
+		opt.Albums = []string{a.AlbumUID}
+
+		// Guest uploads belong to the album owner and remember the share link they were uploaded with.
+		if s.Guest() {
+			opt.UserUID = a.OwnerUID
+			opt.LinkUID = s.UploadLink(a.AlbumUID)
+		} else {
+			opt.UserUID = s.User.PersonUID
+		}
 
-			if containsNSFW {
-				for _, filename := range uploads {
-					if err := os.Remove(filename); err != nil {
-						log.Errorf("nsfw: could not delete %s", txt.Quote(filename))
-					}
-				}
+		service.Import().Start(opt)
 
-				Abort(c, http.StatusForbidden, i18n.ErrOffensiveUpload)
-				return
-			}
+		// Files that were not imported, e.g. because another worker started in the meantime, are removed,
+		// so that a later import doesn't add them without owner and share link.
+		if !fs.IsEmpty(p) {
+			log.Warnf("upload: removing files in %s that could not be imported", txt.Quote(p))
 		}
 
+		if err := os.RemoveAll(p); err != nil {
+			log.Errorf("upload: could not delete folder %s: %s", txt.Quote(p), err)
+		}
+
+		PublishAlbumEvent(EntityUpdated, a.AlbumUID, c)
+
+		UpdateClientConfig()
+
 		elapsed := int(time.Since(start).Seconds())
 
-		msg := i18n.Msg(i18n.MsgFilesUploadedIn, uploaded, elapsed)
+		msg := i18n.Msg(i18n.MsgFilesUploadedIn, len(uploads), elapsed)
 
-		log.Info(msg)
+		log.Infof("upload: %s to album %s", msg, txt.Quote(a.AlbumTitle))
 
 		c.JSON(http.StatusOK, i18n.Response{Code: http.StatusOK, Msg: msg})
 	})
 }
+
+// saveUploads saves uploaded files in a folder and returns their file names.
+func saveUploads(c *gin.Context, files []*multipart.FileHeader, dir string) (uploads []string, err error) {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return uploads, err
+	}
+
+	for _, file := range files {
+		filename := path.Join(dir, filepath.Base(file.Filename))
+
+		log.Debugf("upload: saving file %s", txt.Quote(file.Filename))
+
+		if err := c.SaveUploadedFile(file, filename); err != nil {
+			return uploads, err
+		}
+
+		uploads = append(uploads, filename)
+	}
+
+	return uploads, nil
+}
+
+// removeOffensiveUploads deletes all uploaded files and returns true if one of them might be offensive.
+func removeOffensiveUploads(uploads []string) bool {
+	if service.Config().UploadNSFW() {
+		return false
+	}
+
+	nd := service.NsfwDetector()
+
+	containsNSFW := false
+
+	for _, filename := range uploads {
+		labels, err := nd.File(filename)
+
+		if err != nil {
+			log.Debug(err)
+			continue
+		}
+
+		if labels.IsSafe() {
+			continue
+		}
+
+		log.Infof("nsfw: %s might be offensive", txt.Quote(filename))
+
+		containsNSFW = true
+	}
+
+	if !containsNSFW {
+		return false
+	}
+
+	for _, filename := range uploads {
+		if err := os.Remove(filename); err != nil {
+			log.Errorf("nsfw: could not delete %s", txt.Quote(filename))
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,27 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestUploadToAlbum(t *testing.T) {
+	t.Run("album not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UploadToAlbum(router)
+		r := PerformRequest(app, "POST", "/api/v1/albums/at9lxuqxpogaaxxx/upload")
+		val := gjson.Get(r.Body.String(), "error")
+		assert.Equal(t, i18n.Msg(i18n.ErrAlbumNotFound), val.String())
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("no files", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UploadToAlbum(router)
+		r := PerformRequest(app, "POST", "/api/v1/albums/at9lxuqxpogaaba7/upload")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
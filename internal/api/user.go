@@ -2,18 +2,194 @@ package api
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/photoprism/photoprism/internal/acl"
 	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/txt"
 )
 
+// GET /api/v1/users
+func GetUsers(router *gin.RouterGroup) {
+	router.GET("/users", Authorize(acl.ResourcePeople, acl.ActionSearch), func(c *gin.Context) {
+		var f form.UserSearch
+
+		err := c.MustBindWith(&f, binding.Form)
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		result, err := query.UserSearch(f)
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		c.Header("X-Count", strconv.Itoa(len(result)))
+		c.Header("X-Limit", strconv.Itoa(f.Count))
+		c.Header("X-Offset", strconv.Itoa(f.Offset))
+
+		c.JSON(http.StatusOK, query.NewUserResults(result))
+	})
+}
+
+// GET /api/v1/users/:uid
+//
+// Parameters:
+//   uid: string User UID as returned by the API
+func GetUser(router *gin.RouterGroup) {
+	router.GET("/users/:uid", Authorize(acl.ResourcePeople, acl.ActionRead), func(c *gin.Context) {
+		s := AuthSession(c)
+		uid := c.Param("uid")
+
+		// Users without search permission may only see their own account.
+		if acl.Permissions.Deny(acl.ResourcePeople, s.User.Role(), acl.ActionSearch) && s.User.PersonUID != uid {
+			Abort(c, http.StatusNotFound, i18n.ErrUserNotFound)
+			return
+		}
+
+		if m := entity.FindPersonByUID(uid); m != nil && m.Registered() {
+			c.JSON(http.StatusOK, m)
+		} else {
+			Abort(c, http.StatusNotFound, i18n.ErrUserNotFound)
+		}
+	})
+}
+
+// POST /api/v1/users
+func CreateUser(router *gin.RouterGroup) {
+	router.POST("/users", Authorize(acl.ResourcePeople, acl.ActionCreate), func(c *gin.Context) {
+		// New users are active unless specified otherwise.
+		f := form.User{UserActive: true}
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		m, err := entity.CreatePerson(f)
+
+		if err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrSaveFailed)
+			return
+		}
+
+		log.Infof("user: created %s", txt.Quote(m.UserName))
+
+		event.SuccessMsg(i18n.MsgUserCreated)
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// PUT /api/v1/users/:uid
+//
+// Parameters:
+//   uid: string User UID as returned by the API
+func UpdateUser(router *gin.RouterGroup) {
+	router.PUT("/users/:uid", Authorize(acl.ResourcePeople, acl.ActionUpdateSelf), func(c *gin.Context) {
+		s := AuthSession(c)
+		uid := c.Param("uid")
+
+		// Users without update permission may only change their own profile.
+		canUpdate := acl.Permissions.Allow(acl.ResourcePeople, s.User.Role(), acl.ActionUpdate)
+
+		m := entity.FindPersonByUID(uid)
+
+		if m == nil || !m.Registered() || !canUpdate && s.User.PersonUID != uid {
+			Abort(c, http.StatusNotFound, i18n.ErrUserNotFound)
+			return
+		}
+
+		// 1) Init form with model values
+		f, err := form.NewUser(m)
+
+		if err != nil {
+			log.Error(err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		// 2) Update form with values from request
+		if err := c.BindJSON(&f); err != nil {
+			log.Error(err)
+			AbortBadRequest(c)
+			return
+		}
+
+		// 3) Save model with values from form
+		if canUpdate {
+			err = m.SaveForm(f)
+		} else {
+			err = m.SaveProfile(f)
+		}
+
+		if err != nil {
+			log.Errorf("user: %s", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		// Deactivated users are logged out everywhere.
+		if m.Disabled() {
+			service.Session().RevokeAll(m.PersonUID)
+		}
+
+		event.SuccessMsg(i18n.MsgUserSaved)
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// DELETE /api/v1/users/:uid
+//
+// Parameters:
+//   uid: string User UID as returned by the API
+func DeleteUser(router *gin.RouterGroup) {
+	router.DELETE("/users/:uid", Authorize(acl.ResourcePeople, acl.ActionDelete), func(c *gin.Context) {
+		s := AuthSession(c)
+		uid := c.Param("uid")
+
+		m := entity.FindPersonByUID(uid)
+
+		if m == nil || !m.Registered() {
+			Abort(c, http.StatusNotFound, i18n.ErrUserNotFound)
+			return
+		}
+
+		if m.PersonUID == s.User.PersonUID {
+			Abort(c, http.StatusForbidden, i18n.ErrForbidden)
+			return
+		}
+
+		if err := m.Delete(); err != nil {
+			Error(c, http.StatusInternalServerError, err, i18n.ErrDeleteFailed)
+			return
+		}
+
+		service.Session().RevokeAll(m.PersonUID)
+
+		log.Infof("user: deleted %s", txt.Quote(m.UserName))
+
+		event.SuccessMsg(i18n.MsgUserDeleted, txt.Quote(m.UserName))
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
 // PUT /api/v1/users/:uid/password
 func ChangePassword(router *gin.RouterGroup) {
-	router.PUT("/users/:uid/password", func(c *gin.Context) {
+	router.PUT("/users/:uid/password", Authorize(acl.ResourcePasswords, acl.ActionUpdateSelf), func(c *gin.Context) {
 		conf := service.Config()
 
 		if conf.Public() {
@@ -21,17 +197,12 @@ func ChangePassword(router *gin.RouterGroup) {
 			return
 		}
 
-		s := Auth(SessionID(c), acl.ResourcePeople, acl.ActionUpdateSelf)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
+		s := AuthSession(c)
 
 		uid := c.Param("uid")
 		m := entity.FindPersonByUID(uid)
 
-		if m == nil {
+		if m == nil || !s.User.Admin() && s.User.PersonUID != uid {
 			Abort(c, http.StatusNotFound, i18n.ErrUserNotFound)
 			return
 		}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetUsers(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetUsers(router)
+		r := PerformRequest(app, "GET", "/api/v1/users?count=10")
+		val := gjson.Get(r.Body.String(), "#(UserName=\"admin\").Admin")
+		assert.True(t, val.Bool())
+		assert.False(t, gjson.Get(r.Body.String(), "0.ApiToken").Exists())
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("invalid request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetUsers(router)
+		r := PerformRequest(app, "GET", "/api/v1/users?xxx=10")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestGetUser(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetUser(router)
+		r := PerformRequest(app, "GET", "/api/v1/users/"+entity.Admin.PersonUID)
+		val := gjson.Get(r.Body.String(), "UserName")
+		assert.Equal(t, "admin", val.String())
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("user not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetUser(router)
+		r := PerformRequest(app, "GET", "/api/v1/users/uxxx")
+		val := gjson.Get(r.Body.String(), "error")
+		assert.Equal(t, i18n.Msg(i18n.ErrUserNotFound), val.String())
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestCreateUser(t *testing.T) {
+	t.Run("invalid request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateUser(router)
+		r := PerformRequest(app, "POST", "/api/v1/users")
+		val := gjson.Get(r.Body.String(), "error")
+		assert.Equal(t, i18n.Msg(i18n.ErrBadRequest), val.String())
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("already exists", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateUser(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/users", `{"UserName": "admin", "Password": "photoprism"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateUser(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/users", `{"UserName": "api-create", "DisplayName": "Create Test", "Family": true, "Password": "photoprism"}`)
+		assert.Equal(t, "api-create", gjson.Get(r.Body.String(), "UserName").String())
+		assert.True(t, gjson.Get(r.Body.String(), "Family").Bool())
+		assert.False(t, gjson.Get(r.Body.String(), "Password").Exists())
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+}
+
+func TestUpdateUser(t *testing.T) {
+	app, router, _ := NewApiTest()
+	CreateUser(router)
+	r := PerformRequestWithBody(app, "POST", "/api/v1/users", `{"UserName": "api-update", "DisplayName": "Update Test", "Friend": true}`)
+	assert.Equal(t, http.StatusOK, r.Code)
+	uid := gjson.Get(r.Body.String(), "UID").String()
+
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdateUser(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/users/"+uid, `{"UserName": "renamed", "DisplayName": "Updated", "Friend": false, "Family": true}`)
+		assert.Equal(t, "api-update", gjson.Get(r.Body.String(), "UserName").String())
+		assert.Equal(t, "Updated", gjson.Get(r.Body.String(), "DisplayName").String())
+		assert.True(t, gjson.Get(r.Body.String(), "Family").Bool())
+		assert.False(t, gjson.Get(r.Body.String(), "Friend").Bool())
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdateUser(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/users/uxxx", `{"DisplayName": "Updated"}`)
+		val := gjson.Get(r.Body.String(), "error")
+		assert.Equal(t, i18n.Msg(i18n.ErrUserNotFound), val.String())
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestDeleteUser(t *testing.T) {
+	app, router, _ := NewApiTest()
+	CreateUser(router)
+	r := PerformRequestWithBody(app, "POST", "/api/v1/users", `{"UserName": "api-delete"}`)
+	assert.Equal(t, http.StatusOK, r.Code)
+	uid := gjson.Get(r.Body.String(), "UID").String()
+
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		DeleteUser(router)
+		r := PerformRequest(app, "DELETE", "/api/v1/users/"+uid)
+		assert.Equal(t, "api-delete", gjson.Get(r.Body.String(), "UserName").String())
+		assert.Equal(t, http.StatusOK, r.Code)
+		GetUser(router)
+		r2 := PerformRequest(app, "GET", "/api/v1/users/"+uid)
+		assert.Equal(t, http.StatusNotFound, r2.Code)
+	})
+	t.Run("can't delete self", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		DeleteUser(router)
+		r := PerformRequest(app, "DELETE", "/api/v1/users/"+entity.Admin.PersonUID)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
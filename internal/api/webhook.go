@@ -0,0 +1,146 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GET /api/v1/webhooks
+func GetWebhooks(router *gin.RouterGroup) {
+	router.GET("/webhooks", Authorize(acl.ResourceWebhooks, acl.ActionSearch), func(c *gin.Context) {
+		c.JSON(http.StatusOK, entity.FindWebhooks())
+	})
+}
+
+// GET /api/v1/webhooks/:uid
+//
+// Parameters:
+//   uid: string Webhook UID as returned by the API
+func GetWebhook(router *gin.RouterGroup) {
+	router.GET("/webhooks/:uid", Authorize(acl.ResourceWebhooks, acl.ActionRead), func(c *gin.Context) {
+		if m := entity.FindWebhook(c.Param("uid")); m != nil {
+			c.JSON(http.StatusOK, m)
+		} else {
+			AbortEntityNotFound(c)
+		}
+	})
+}
+
+// GET /api/v1/webhooks/:uid/deliveries
+//
+// Parameters:
+//   uid: string Webhook UID as returned by the API
+func GetWebhookDeliveries(router *gin.RouterGroup) {
+	router.GET("/webhooks/:uid/deliveries", Authorize(acl.ResourceWebhooks, acl.ActionRead), func(c *gin.Context) {
+		m := entity.FindWebhook(c.Param("uid"))
+
+		if m == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		limit := txt.Int(c.Query("count"))
+		offset := txt.Int(c.Query("offset"))
+
+		if limit <= 0 || limit > 1000 {
+			limit = 100
+		}
+
+		result := entity.FindWebhookDeliveries(m.WebhookUID, limit, offset)
+
+		c.Header("X-Count", strconv.Itoa(len(result)))
+		c.Header("X-Limit", strconv.Itoa(limit))
+		c.Header("X-Offset", strconv.Itoa(offset))
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// POST /api/v1/webhooks
+func CreateWebhook(router *gin.RouterGroup) {
+	router.POST("/webhooks", Authorize(acl.ResourceWebhooks, acl.ActionCreate), func(c *gin.Context) {
+		var f form.Webhook
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		m, secret, err := entity.CreateWebhook(f)
+
+		if err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrSaveFailed)
+			return
+		}
+
+		log.Infof("webhook: created %s", txt.Quote(m.WebhookName))
+
+		// The secret is only returned once, so that it can be configured on the receiving side.
+		c.JSON(http.StatusOK, gin.H{"webhook": m, "secret": secret})
+	})
+}
+
+// PUT /api/v1/webhooks/:uid
+//
+// Parameters:
+//   uid: string Webhook UID as returned by the API
+func UpdateWebhook(router *gin.RouterGroup) {
+	router.PUT("/webhooks/:uid", Authorize(acl.ResourceWebhooks, acl.ActionUpdate), func(c *gin.Context) {
+		m := entity.FindWebhook(c.Param("uid"))
+
+		if m == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		// 1) Init form with model values
+		f := m.Form()
+
+		// 2) Update form with values from request
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		// 3) Save model with values from form
+		if err := m.SaveForm(f); err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrSaveFailed)
+			return
+		}
+
+		log.Infof("webhook: updated %s", txt.Quote(m.WebhookName))
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// DELETE /api/v1/webhooks/:uid
+//
+// Parameters:
+//   uid: string Webhook UID as returned by the API
+func DeleteWebhook(router *gin.RouterGroup) {
+	router.DELETE("/webhooks/:uid", Authorize(acl.ResourceWebhooks, acl.ActionDelete), func(c *gin.Context) {
+		m := entity.FindWebhook(c.Param("uid"))
+
+		if m == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		if err := m.Delete(); err != nil {
+			Error(c, http.StatusInternalServerError, err, i18n.ErrDeleteFailed)
+			return
+		}
+
+		log.Infof("webhook: deleted %s", txt.Quote(m.WebhookName))
+
+		c.JSON(http.StatusOK, m)
+	})
+}
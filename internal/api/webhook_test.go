@@ -0,0 +1,111 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestCreateWebhook(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateWebhook(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/webhooks", `{"Name": "Home Automation", "URL": "http://localhost:8123/hook", "Events": "photos.created,import.*", "Enabled": true}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Home Automation", gjson.Get(r.Body.String(), "webhook.Name").String())
+		assert.Equal(t, 48, len(gjson.Get(r.Body.String(), "secret").String()))
+		assert.False(t, gjson.Get(r.Body.String(), "webhook.WebhookSecret").Exists())
+
+		if m := entity.FindWebhook(gjson.Get(r.Body.String(), "webhook.UID").String()); m != nil {
+			_ = m.Delete()
+		}
+	})
+	t.Run("invalid url", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateWebhook(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/webhooks", `{"Name": "Invalid", "URL": "localhost", "Events": "*"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestGetWebhooks(t *testing.T) {
+	m, _, err := entity.CreateWebhook(form.Webhook{Name: "List", URL: "http://localhost:8123/", Events: "albums.*"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer m.Delete()
+
+	app, router, _ := NewApiTest()
+	GetWebhooks(router)
+	r := PerformRequest(app, "GET", "/api/v1/webhooks")
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Equal(t, "albums.*", gjson.Get(r.Body.String(), "#(Name=\"List\").Events").String())
+}
+
+func TestUpdateWebhook(t *testing.T) {
+	m, secret, err := entity.CreateWebhook(form.Webhook{Name: "Update", URL: "http://localhost:8123/", Events: "*"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer m.Delete()
+
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdateWebhook(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/webhooks/"+m.WebhookUID, `{"Events": "photos.archived", "Enabled": true}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Update", gjson.Get(r.Body.String(), "Name").String())
+		assert.Equal(t, "photos.archived", gjson.Get(r.Body.String(), "Events").String())
+		assert.True(t, gjson.Get(r.Body.String(), "Enabled").Bool())
+		assert.Equal(t, secret, entity.FindWebhook(m.WebhookUID).WebhookSecret)
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdateWebhook(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/webhooks/wxxx", `{"Enabled": true}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestGetWebhookDeliveries(t *testing.T) {
+	m, _, err := entity.CreateWebhook(form.Webhook{Name: "Deliveries", URL: "http://localhost:8123/", Events: "*"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer m.Delete()
+
+	if _, err := entity.NewWebhookDelivery(m.WebhookUID, "photos.created", "{}"); err != nil {
+		t.Fatal(err)
+	}
+
+	app, router, _ := NewApiTest()
+	GetWebhookDeliveries(router)
+	r := PerformRequest(app, "GET", "/api/v1/webhooks/"+m.WebhookUID+"/deliveries")
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Equal(t, "photos.created", gjson.Get(r.Body.String(), "0.Event").String())
+	assert.Equal(t, "pending", gjson.Get(r.Body.String(), "0.Status").String())
+}
+
+func TestDeleteWebhook(t *testing.T) {
+	m, _, err := entity.CreateWebhook(form.Webhook{Name: "Delete", URL: "http://localhost:8123/", Events: "*"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app, router, _ := NewApiTest()
+	DeleteWebhook(router)
+	r := PerformRequest(app, "DELETE", "/api/v1/webhooks/"+m.WebhookUID)
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Nil(t, entity.FindWebhook(m.WebhookUID))
+}
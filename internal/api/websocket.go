@@ -3,15 +3,16 @@ package api
 import (
 	"encoding/json"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
-	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/session"
 	"github.com/photoprism/photoprism/pkg/rnd"
 )
 
@@ -23,21 +24,91 @@ var wsConnection = websocket.Upgrader{
 var wsTimeout = 90 * time.Second
 
 type clientInfo struct {
-	SessionToken string `json:"session"`
-	JsHash       string `json:"js"`
-	CssHash      string `json:"css"`
-	Version      string `json:"version"`
+	SessionToken string   `json:"session"`
+	JsHash       string   `json:"js"`
+	CssHash      string   `json:"css"`
+	Version      string   `json:"version"`
+	Subscribe    []string `json:"subscribe"`
+	Unsubscribe  []string `json:"unsubscribe"`
+}
+
+// wsClient represents the session and topic subscriptions of a websocket connection.
+type wsClient struct {
+	id     string
+	sess   session.Data
+	topics []string
 }
 
 var wsAuth = struct {
-	user  map[string]entity.Person
-	mutex sync.RWMutex
-}{user: make(map[string]entity.Person)}
+	client map[string]wsClient
+	mutex  sync.RWMutex
+}{client: make(map[string]wsClient)}
+
+// wsSubscribe updates the topic subscriptions of a connection.
+func wsSubscribe(connId string, info clientInfo) {
+	if info.Subscribe == nil && info.Unsubscribe == nil {
+		return
+	}
+
+	wsAuth.mutex.Lock()
+	defer wsAuth.mutex.Unlock()
+
+	client, ok := wsAuth.client[connId]
+
+	if !ok {
+		return
+	}
+
+	if info.Subscribe != nil {
+		client.topics = EventTopics(strings.Join(info.Subscribe, ","))
+	}
+
+	if len(info.Unsubscribe) > 0 {
+		var topics []string
+
+		for _, t := range client.topics {
+			if !SubscribedTo(info.Unsubscribe, t) {
+				topics = append(topics, t)
+			}
+		}
+
+		client.topics = topics
+	}
+
+	wsAuth.client[connId] = client
+}
+
+// wsRefresh reloads the session of a connection, so that new shares are picked up,
+// and returns false if the session has expired or was deleted in the meantime.
+func wsRefresh(connId string) bool {
+	wsAuth.mutex.RLock()
+	client, ok := wsAuth.client[connId]
+	wsAuth.mutex.RUnlock()
+
+	if !ok || client.id == "" {
+		return true
+	}
+
+	sess := Session(client.id)
+
+	if sess.Invalid() {
+		return false
+	}
+
+	wsAuth.mutex.Lock()
+	if client, ok := wsAuth.client[connId]; ok {
+		client.sess = sess
+		wsAuth.client[connId] = client
+	}
+	wsAuth.mutex.Unlock()
 
-func wsReader(ws *websocket.Conn, writeMutex *sync.Mutex, connId string, conf *config.Config) {
+	return true
+}
+
+func wsReader(ws *websocket.Conn, writeMutex *sync.Mutex, connId string) {
 	defer ws.Close()
 
-	ws.SetReadLimit(512)
+	ws.SetReadLimit(4096)
 	ws.SetReadDeadline(time.Now().Add(wsTimeout))
 	ws.SetPongHandler(func(string) error { ws.SetReadDeadline(time.Now().Add(wsTimeout)); return nil })
 
@@ -53,25 +124,25 @@ func wsReader(ws *websocket.Conn, writeMutex *sync.Mutex, connId string, conf *c
 		if err := json.Unmarshal(m, &info); err != nil {
 			// Do nothing.
 		} else {
-			if sess := Session(info.SessionToken); sess.Valid() {
-				wsAuth.mutex.Lock()
-				wsAuth.user[connId] = sess.User
-				wsAuth.mutex.Unlock()
+			wsSubscribe(connId, info)
 
-				var clientConfig config.ClientConfig
+			if info.SessionToken == "" {
+				continue
+			}
 
-				if sess.User.Guest() {
-					clientConfig = conf.GuestConfig()
-				} else if sess.User.Registered() {
-					clientConfig = conf.UserConfig()
-				} else {
-					clientConfig = conf.PublicConfig()
+			if sess := Session(info.SessionToken); sess.Valid() {
+				wsAuth.mutex.Lock()
+				if client, ok := wsAuth.client[connId]; ok {
+					client.id = info.SessionToken
+					client.sess = sess
+					wsAuth.client[connId] = client
 				}
+				wsAuth.mutex.Unlock()
 
 				writeMutex.Lock()
 				ws.SetWriteDeadline(time.Now().Add(30 * time.Second))
 
-				if err := ws.WriteJSON(gin.H{"event": "config.updated", "data": event.Data{"config": clientConfig}}); err != nil {
+				if err := ws.WriteJSON(gin.H{"event": "config.updated", "data": event.Data{"config": ClientConfig(sess)}}); err != nil {
 					// Do nothing.
 				}
 
@@ -92,10 +163,12 @@ func wsWriter(ws *websocket.Conn, writeMutex *sync.Mutex, connId string) {
 		"config.*",
 		"count.*",
 		"photos.*",
+		"memories.*",
 		"cameras.*",
 		"lenses.*",
 		"countries.*",
 		"albums.*",
+		"comments.*",
 		"labels.*",
 		"sync.*",
 	)
@@ -106,13 +179,18 @@ func wsWriter(ws *websocket.Conn, writeMutex *sync.Mutex, connId string) {
 		ws.Close()
 
 		wsAuth.mutex.Lock()
-		wsAuth.user[connId] = entity.UnknownPerson
+		delete(wsAuth.client, connId)
 		wsAuth.mutex.Unlock()
 	}()
 
 	for {
 		select {
 		case <-pingTicker.C:
+			// Stop sending events once the session has expired or was deleted.
+			if !wsRefresh(connId) {
+				return
+			}
+
 			writeMutex.Lock()
 			ws.SetWriteDeadline(time.Now().Add(30 * time.Second))
 
@@ -124,26 +202,29 @@ func wsWriter(ws *websocket.Conn, writeMutex *sync.Mutex, connId string) {
 			writeMutex.Unlock()
 		case msg := <-s.Receiver:
 			wsAuth.mutex.RLock()
+			client := wsAuth.client[connId]
+			wsAuth.mutex.RUnlock()
 
-			user := entity.UnknownPerson
-
-			if hit, ok := wsAuth.user[connId]; ok {
-				user = hit
+			if !SubscribedTo(client.topics, msg.Name) {
+				continue
 			}
 
-			wsAuth.mutex.RUnlock()
+			// Only send events the session user is allowed to see.
+			msg, ok := FilterEvent(client.sess, msg)
 
-			if user.Registered() {
-				writeMutex.Lock()
-				ws.SetWriteDeadline(time.Now().Add(30 * time.Second))
+			if !ok {
+				continue
+			}
 
-				if err := ws.WriteJSON(gin.H{"event": msg.Name, "data": msg.Fields}); err != nil {
-					writeMutex.Unlock()
-					return
-				}
+			writeMutex.Lock()
+			ws.SetWriteDeadline(time.Now().Add(30 * time.Second))
 
+			if err := ws.WriteJSON(gin.H{"event": msg.Name, "data": msg.Fields}); err != nil {
 				writeMutex.Unlock()
+				return
 			}
+
+			writeMutex.Unlock()
 		}
 	}
 }
@@ -176,17 +257,19 @@ func Websocket(router *gin.RouterGroup) {
 
 		connId := rnd.UUID()
 
-		// Init connection.
-		wsAuth.mutex.Lock()
+		// Init connection, clients are subscribed to all topics by default.
+		client := wsClient{topics: []string{"*"}}
+
 		if conf.Public() {
-			wsAuth.user[connId] = entity.Admin
-		} else {
-			wsAuth.user[connId] = entity.UnknownPerson
+			client.sess = session.Data{User: entity.Admin}
 		}
+
+		wsAuth.mutex.Lock()
+		wsAuth.client[connId] = client
 		wsAuth.mutex.Unlock()
 
 		go wsWriter(ws, &writeMutex, connId)
 
-		wsReader(ws, &writeMutex, connId, conf)
+		wsReader(ws, &writeMutex, connId)
 	})
 }
@@ -21,3 +21,22 @@ func TestWebsocket(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, r.Code)
 	})
 }
+
+func TestWsRefresh(t *testing.T) {
+	t.Run("unknown connection", func(t *testing.T) {
+		assert.True(t, wsRefresh("unknown"))
+	})
+	t.Run("no session", func(t *testing.T) {
+		wsAuth.mutex.Lock()
+		wsAuth.client["ws-refresh"] = wsClient{topics: []string{"*"}}
+		wsAuth.mutex.Unlock()
+
+		defer func() {
+			wsAuth.mutex.Lock()
+			delete(wsAuth.client, "ws-refresh")
+			wsAuth.mutex.Unlock()
+		}()
+
+		assert.True(t, wsRefresh("ws-refresh"))
+	})
+}
@@ -25,14 +25,8 @@ import (
 
 // POST /api/v1/zip
 func CreateZip(router *gin.RouterGroup) {
-	router.POST("/zip", func(c *gin.Context) {
-		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionDownload)
-
-		if s.Invalid() {
-			AbortUnauthorized(c)
-			return
-		}
-
+	router.POST("/zip", Authorize(acl.ResourcePhotos, acl.ActionDownload), func(c *gin.Context) {
+		s := AuthSession(c)
 		conf := service.Config()
 
 		if !conf.Settings().Features.Download {
@@ -53,6 +47,12 @@ func CreateZip(router *gin.RouterGroup) {
 			return
 		}
 
+		// Users without admin role may only download their own and shared photos.
+		if s.Restricted() {
+			f.Owner = s.User.PersonUID
+			f.Shared = s.Shares.String()
+		}
+
 		files, err := query.FileSelection(f)
 
 		if err != nil {
@@ -0,0 +1,118 @@
+/*
+
+Package auth provides brute-force protection for logins with exponential backoff and temporary lockout.
+
+Copyright (c) 2018 - 2020 Michael Mayer <hello@photoprism.org>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism™ is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.org if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.org/developer-guide/
+
+*/
+package auth
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+var log = event.Log
+
+const (
+	AccountAttempts = 3                // Failed attempts per account before the backoff starts.
+	ClientAttempts  = 10               // Failed attempts per client IP before the backoff starts.
+	LockoutAttempts = 10               // Failed attempts after which the maximum delay applies.
+	BaseDelay       = time.Second      // Delay after the first throttled attempt.
+	MaxDelay        = 15 * time.Minute // Maximum delay, equals a temporary lockout.
+)
+
+// Delay returns the backoff delay after a number of consecutive failed attempts,
+// free is the number of attempts that are not throttled.
+func Delay(failures, free int) time.Duration {
+	if failures < free {
+		return 0
+	}
+
+	if failures-free >= LockoutAttempts {
+		return MaxDelay
+	}
+
+	delay := BaseDelay << uint(failures-free)
+
+	if delay > MaxDelay {
+		return MaxDelay
+	}
+
+	return delay
+}
+
+// Wait returns the remaining time to wait after the last failed attempt.
+func Wait(failures, free int, lastAttempt time.Time) time.Duration {
+	if lastAttempt.IsZero() {
+		return 0
+	}
+
+	wait := time.Until(lastAttempt.Add(Delay(failures, free)))
+
+	if wait < 0 {
+		return 0
+	}
+
+	return wait
+}
+
+// Failed logs a failed login attempt and publishes it on the event hub.
+func Failed(source, userName, clientIP string, failures int) {
+	log.Warnf("auth: failed %s login as %s from %s (%d attempts)", source, txt.Quote(userName), clientIP, failures)
+
+	event.Publish("auth.failed", event.Data{
+		"source":   source,
+		"username": userName,
+		"ip":       clientIP,
+		"attempts": failures,
+	})
+
+	if Delay(failures, AccountAttempts) == MaxDelay {
+		log.Warnf("auth: %s temporarily locked", txt.Quote(userName))
+
+		event.Publish("auth.locked", event.Data{
+			"source":   source,
+			"username": userName,
+			"ip":       clientIP,
+		})
+	}
+}
+
+// Throttled logs a rejected login attempt that was not checked because of too many failures.
+func Throttled(source, userName, clientIP string, wait time.Duration) {
+	log.Warnf("auth: rejected %s login as %s from %s, retry in %s", source, txt.Quote(userName), clientIP, wait.Round(time.Second))
+
+	event.Publish("auth.throttled", event.Data{
+		"source":   source,
+		"username": userName,
+		"ip":       clientIP,
+		"wait":     int(wait.Seconds()),
+	})
+}
@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDelay(t *testing.T) {
+	assert.Equal(t, time.Duration(0), Delay(0, 3))
+	assert.Equal(t, time.Duration(0), Delay(2, 3))
+	assert.Equal(t, time.Second, Delay(3, 3))
+	assert.Equal(t, 2*time.Second, Delay(4, 3))
+	assert.Equal(t, 4*time.Second, Delay(5, 3))
+	assert.Equal(t, MaxDelay, Delay(13, 3))
+	assert.Equal(t, MaxDelay, Delay(100, 3))
+}
+
+func TestWait(t *testing.T) {
+	assert.Equal(t, time.Duration(0), Wait(5, 3, time.Time{}))
+	assert.Equal(t, time.Duration(0), Wait(5, 3, time.Now().Add(-time.Minute)))
+	assert.Equal(t, time.Duration(0), Wait(1, 3, time.Now()))
+
+	wait := Wait(13, 3, time.Now())
+
+	assert.True(t, wait > MaxDelay-time.Minute)
+	assert.True(t, wait <= MaxDelay)
+}
+
+func TestThrottle(t *testing.T) {
+	throttle := NewThrottle(2)
+
+	assert.Equal(t, time.Duration(0), throttle.Wait("127.0.0.1"))
+	assert.Equal(t, 1, throttle.Failed("127.0.0.1"))
+	assert.Equal(t, time.Duration(0), throttle.Wait("127.0.0.1"))
+	assert.Equal(t, 2, throttle.Failed("127.0.0.1"))
+	assert.True(t, throttle.Wait("127.0.0.1") > 0)
+	assert.Equal(t, time.Duration(0), throttle.Wait("127.0.0.2"))
+
+	throttle.Reset("127.0.0.1")
+
+	assert.Equal(t, time.Duration(0), throttle.Wait("127.0.0.1"))
+}
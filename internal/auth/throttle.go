@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"sync"
+	"time"
+
+	gc "github.com/patrickmn/go-cache"
+)
+
+// Clients throttles failed login attempts per client IP.
+var Clients = NewThrottle(ClientAttempts)
+
+type attempts struct {
+	Failures int
+	LastAt   time.Time
+}
+
+// Throttle keeps track of failed login attempts in memory, entries expire after MaxDelay.
+type Throttle struct {
+	free  int
+	cache *gc.Cache
+	mutex sync.Mutex
+}
+
+// NewThrottle returns a new throttle that doesn't delay the first free attempts.
+func NewThrottle(free int) *Throttle {
+	return &Throttle{free: free, cache: gc.New(MaxDelay, time.Minute)}
+}
+
+// Wait returns the remaining time to wait before the next attempt is allowed.
+func (t *Throttle) Wait(key string) time.Duration {
+	if hit, ok := t.cache.Get(key); ok {
+		a := hit.(attempts)
+		return Wait(a.Failures, t.free, a.LastAt)
+	}
+
+	return 0
+}
+
+// Failed records a failed attempt and returns the number of consecutive failures.
+func (t *Throttle) Failed(key string) int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	a := attempts{}
+
+	if hit, ok := t.cache.Get(key); ok {
+		a = hit.(attempts)
+	}
+
+	a.Failures++
+	a.LastAt = time.Now()
+
+	t.cache.Set(key, a, gc.DefaultExpiration)
+
+	return a.Failures
+}
+
+// Reset removes all failed attempts.
+func (t *Throttle) Reset(key string) {
+	t.cache.Delete(key)
+}
@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/txt"
+	"github.com/urfave/cli"
+)
+
+// BackupCommand is used to register the backup cli command
+var BackupCommand = cli.Command{
+	Name:      "backup",
+	Usage:     "Creates an index backup including albums, labels, edits, links and accounts",
+	ArgsUsage: "[filename]",
+	Flags:     backupFlags,
+	Action:    backupAction,
+}
+
+var backupFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "sidecars, s",
+		Usage: "include YAML sidecar files of photos",
+	},
+}
+
+// backupAction creates an index backup.
+func backupAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	conf := config.NewConfig(ctx)
+	service.SetConfig(conf)
+
+	cctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := conf.Init(cctx); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+
+	fileName := strings.TrimSpace(ctx.Args().First())
+
+	if fileName == "" {
+		fileName = photoprism.BackupFileName(conf.BackupPath(), start)
+	} else {
+		fileName, _ = filepath.Abs(fileName)
+	}
+
+	log.Infof("creating backup in %s", txt.Quote(fileName))
+
+	info, err := photoprism.NewBackup(conf).Start(fileName, ctx.Bool("sidecars"))
+
+	if err != nil {
+		return err
+	}
+
+	elapsed := time.Since(start)
+
+	log.Infof("saved %d photos and %d albums in %s", info.Tables["photos"], info.Tables["albums"], elapsed)
+
+	conf.Shutdown()
+
+	return nil
+}
@@ -48,6 +48,12 @@ func configAction(ctx *cli.Context) error {
 	// Passwords.
 	fmt.Printf("%-25s %s\n", "admin-password", conf.AdminPassword())
 
+	// Single sign-on.
+	fmt.Printf("%-25s %s\n", "oidc-issuer", conf.OIDCIssuer())
+	fmt.Printf("%-25s %s\n", "oidc-client", conf.OIDCClient())
+	fmt.Printf("%-25s %s\n", "oidc-redirect-url", conf.OIDCRedirectUrl())
+	fmt.Printf("%-25s %s\n", "oidc-default-role", conf.OIDCDefaultRole())
+
 	// Background workers and logging.
 	fmt.Printf("%-25s %d\n", "workers", conf.Workers())
 	fmt.Printf("%-25s %d\n", "wakeup-interval", conf.WakeupInterval()/time.Second)
@@ -79,6 +85,9 @@ func configAction(ctx *cli.Context) error {
 	fmt.Printf("%-25s %s\n", "templates-path", conf.TemplatesPath())
 	fmt.Printf("%-25s %s\n", "template-name", conf.TemplateName())
 	fmt.Printf("%-25s %s\n", "cache-path", conf.CachePath())
+	fmt.Printf("%-25s %s\n", "backup-path", conf.BackupPath())
+	fmt.Printf("%-25s %d\n", "backup-interval", conf.BackupInterval()/time.Hour)
+	fmt.Printf("%-25s %d\n", "backup-count", conf.BackupCount())
 	fmt.Printf("%-25s %s\n", "temp-path", conf.TempPath())
 	fmt.Printf("%-25s %s\n", "config-file", conf.ConfigFile())
 	fmt.Printf("%-25s %s\n", "settings-path", conf.SettingsPath())
@@ -92,6 +101,7 @@ func configAction(ctx *cli.Context) error {
 	fmt.Printf("%-25s %s\n", "exiftool-bin", conf.ExifToolBin())
 	fmt.Printf("%-25s %t\n", "sidecar-json", conf.SidecarJson())
 	fmt.Printf("%-25s %t\n", "sidecar-yaml", conf.SidecarYaml())
+	fmt.Printf("%-25s %t\n", "sidecar-xmp", conf.SidecarXmp())
 	fmt.Printf("%-25s %s\n", "sidecar-path", conf.SidecarPath())
 
 	// Places / Geocoding API configuration.
@@ -18,6 +18,7 @@ var CopyCommand = cli.Command{
 	Name:    "copy",
 	Aliases: []string{"cp"},
 	Usage:   "Copies files to originals folder, converts and indexes them as needed",
+	Flags:   importFlags,
 	Action:  copyAction,
 }
 
@@ -25,6 +26,10 @@ var CopyCommand = cli.Command{
 func copyAction(ctx *cli.Context) error {
 	start := time.Now()
 
+	if err := photoprism.ValidateImportDest(ctx.String("dest")); err != nil {
+		return err
+	}
+
 	conf := config.NewConfig(ctx)
 	service.SetConfig(conf)
 
@@ -68,6 +73,7 @@ func copyAction(ctx *cli.Context) error {
 
 	imp := service.Import()
 	opt := photoprism.ImportOptionsCopy(sourcePath)
+	opt.Dest = ctx.String("dest")
 
 	imp.Start(opt)
 
@@ -18,13 +18,25 @@ var ImportCommand = cli.Command{
 	Name:    "import",
 	Aliases: []string{"mv"},
 	Usage:   "Moves files to originals folder, converts and indexes them as needed",
+	Flags:   importFlags,
 	Action:  importAction,
 }
 
+var importFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "dest, d",
+		Usage: "destination `TEMPLATE` relative to originals, e.g. \"{yyyy}/{yyyy}-{mm}-{dd} {folder}/{name}\"",
+	},
+}
+
 // importAction moves photos to originals path. Default import path is used if no path argument provided
 func importAction(ctx *cli.Context) error {
 	start := time.Now()
 
+	if err := photoprism.ValidateImportDest(ctx.String("dest")); err != nil {
+		return err
+	}
+
 	conf := config.NewConfig(ctx)
 	service.SetConfig(conf)
 
@@ -68,6 +80,7 @@ func importAction(ctx *cli.Context) error {
 
 	imp := service.Import()
 	opt := photoprism.ImportOptionsMove(sourcePath)
+	opt.Dest = ctx.String("dest")
 
 	imp.Start(opt)
 
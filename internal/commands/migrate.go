@@ -2,9 +2,11 @@ package commands
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/urfave/cli"
 )
 
@@ -13,6 +15,43 @@ var MigrateCommand = cli.Command{
 	Name:   "migrate",
 	Usage:  "Automatically initializes and migrates the database",
 	Action: migrateAction,
+	Subcommands: []cli.Command{
+		{
+			Name:   "status",
+			Usage:  "Lists versioned migrations and whether they were applied",
+			Action: migrateStatusAction,
+		},
+		{
+			Name:  "up",
+			Usage: "Applies pending migrations",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  "version, v",
+					Usage: "target `VERSION`, all pending migrations if 0",
+				},
+				migrateDryRunFlag,
+			},
+			Action: migrateUpAction,
+		},
+		{
+			Name:  "down",
+			Usage: "Reverts applied migrations, starting with the latest",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  "steps, s",
+					Usage: "`NUMBER` of migrations to revert",
+					Value: 1,
+				},
+				migrateDryRunFlag,
+			},
+			Action: migrateDownAction,
+		},
+	},
+}
+
+var migrateDryRunFlag = cli.BoolFlag{
+	Name:  "dry-run, n",
+	Usage: "show migrations without changing the database",
 }
 
 // migrateAction automatically migrates or initializes database
@@ -38,3 +77,95 @@ func migrateAction(ctx *cli.Context) error {
 
 	return nil
 }
+
+// migrateStatusAction lists versioned migrations and their status.
+func migrateStatusAction(ctx *cli.Context) error {
+	return withMigrations(ctx, func() error {
+		status, err := entity.Migrations.Status()
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%-12s %-40s %s\n", "VERSION", "NAME", "APPLIED")
+
+		for _, s := range status {
+			applied := "pending"
+
+			if s.Applied() {
+				applied = s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+
+			fmt.Printf("%-12d %-40s %s\n", s.Version, s.Name, applied)
+		}
+
+		return nil
+	})
+}
+
+// migrateUpAction applies pending migrations.
+func migrateUpAction(ctx *cli.Context) error {
+	dryRun := ctx.Bool("dry-run")
+
+	return withMigrations(ctx, func() error {
+		// Data migrations require existing tables.
+		if !dryRun {
+			entity.Entities.Migrate()
+			entity.Entities.WaitForMigration()
+		}
+
+		result, err := entity.Migrations.Up(ctx.Int("version"), dryRun)
+
+		logMigrations("apply", result, dryRun)
+
+		return err
+	})
+}
+
+// migrateDownAction reverts applied migrations.
+func migrateDownAction(ctx *cli.Context) error {
+	steps := ctx.Int("steps")
+
+	if steps < 1 {
+		return fmt.Errorf("number of steps must be at least 1")
+	}
+
+	dryRun := ctx.Bool("dry-run")
+
+	return withMigrations(ctx, func() error {
+		result, err := entity.Migrations.Down(steps, dryRun)
+
+		logMigrations("revert", result, dryRun)
+
+		return err
+	})
+}
+
+// logMigrations logs the migrations that were or would be applied or reverted.
+func logMigrations(action string, result entity.MigrationList, dryRun bool) {
+	if len(result) == 0 {
+		log.Infof("migrate: nothing to %s", action)
+	} else if dryRun {
+		for _, m := range result {
+			log.Infof("migrate: would %s %s", action, m)
+		}
+	}
+}
+
+// withMigrations connects to the database without applying migrations.
+func withMigrations(ctx *cli.Context, f func() error) error {
+	conf := config.NewConfig(ctx)
+
+	cctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := conf.Init(cctx); err != nil {
+		return err
+	}
+
+	conf.RegisterDb()
+
+	defer conf.Shutdown()
+
+	return f()
+}
@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/txt"
+	"github.com/urfave/cli"
+)
+
+// RestoreCommand is used to register the restore cli command
+var RestoreCommand = cli.Command{
+	Name:      "restore",
+	Usage:     "Restores the index from a backup, uses the latest backup by default",
+	ArgsUsage: "[filename]",
+	Flags:     restoreFlags,
+	Action:    restoreAction,
+}
+
+var restoreFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "sidecars, s",
+		Usage: "restore YAML sidecar files of photos",
+	},
+	cli.BoolFlag{
+		Name:  "force, f",
+		Usage: "replace the existing index",
+	},
+}
+
+// restoreAction restores the index from a backup.
+func restoreAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	conf := config.NewConfig(ctx)
+	service.SetConfig(conf)
+
+	cctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := conf.Init(cctx); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+
+	fileName := strings.TrimSpace(ctx.Args().First())
+
+	if fileName == "" {
+		files, err := photoprism.BackupFiles(conf.BackupPath())
+
+		if err != nil {
+			return err
+		} else if len(files) == 0 {
+			return fmt.Errorf("no backup found in %s", txt.Quote(conf.BackupPath()))
+		}
+
+		fileName = files[len(files)-1]
+	} else {
+		fileName, _ = filepath.Abs(fileName)
+	}
+
+	var count int
+
+	if err := entity.UnscopedDb().Model(&entity.Photo{}).Count(&count).Error; err != nil {
+		return err
+	} else if count > 0 && !ctx.Bool("force") {
+		return fmt.Errorf("index already contains %d photos, use --force to replace it", count)
+	}
+
+	log.Infof("restoring index from %s", txt.Quote(fileName))
+
+	info, err := photoprism.NewRestore(conf).Start(fileName, ctx.Bool("sidecars"))
+
+	if err != nil {
+		return err
+	}
+
+	elapsed := time.Since(start)
+
+	log.Infof("restored %d photos and %d albums in %s", info.Tables["photos"], info.Tables["albums"], elapsed)
+
+	conf.Shutdown()
+
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/server"
 	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/webhook"
 	"github.com/photoprism/photoprism/internal/workers"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/txt"
@@ -118,6 +119,9 @@ func startAction(ctx *cli.Context) error {
 	// start share & sync workers
 	workers.Start(conf)
 
+	// start webhook dispatcher
+	webhook.Start()
+
 	// set up proper shutdown of daemon and web server
 	quit := make(chan os.Signal)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -127,6 +131,9 @@ func startAction(ctx *cli.Context) error {
 	// stop share & sync workers
 	workers.Stop()
 
+	// stop webhook dispatcher
+	webhook.Stop()
+
 	log.Info("shutting down...")
 	conf.Shutdown()
 	cancel()
@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/txt"
+	"github.com/urfave/cli"
+)
+
+// TokensCommand is used to register the tokens cli command
+var TokensCommand = cli.Command{
+	Name:  "tokens",
+	Usage: "App token management sub-commands",
+	Subcommands: []cli.Command{
+		{
+			Name:      "add",
+			Usage:     "Creates a new app token for a user",
+			ArgsUsage: "[username]",
+			Flags:     tokenFlags,
+			Action:    tokensAddAction,
+		},
+		{
+			Name:      "list",
+			Usage:     "Lists the app tokens of a user",
+			ArgsUsage: "[username]",
+			Action:    tokensListAction,
+		},
+		{
+			Name:      "remove",
+			Usage:     "Removes an app token",
+			ArgsUsage: "[username] [token uid]",
+			Action:    tokensRemoveAction,
+		},
+	},
+}
+
+var tokenFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "name, n",
+		Usage: "token name, e.g. the script or app using it",
+		Value: "App",
+	},
+	cli.StringFlag{
+		Name:  "scope, s",
+		Usage: "token scope (read, upload or webdav), full access if empty",
+	},
+	cli.IntFlag{
+		Name:  "expires, e",
+		Usage: "expiration time in `SECONDS`, never expires if 0",
+	},
+}
+
+// tokensAddAction creates a new app token.
+func tokensAddAction(ctx *cli.Context) error {
+	return withTokenUser(ctx, func(user *entity.Person) error {
+		token, secret, err := entity.CreateAppToken(user.PersonUID, ctx.String("name"), ctx.String("scope"), ctx.Int("expires"))
+
+		if err != nil {
+			return err
+		}
+
+		log.Infof("created app token %s for %s, it can't be displayed again:", txt.Quote(token.TokenName), txt.Quote(user.UserName))
+
+		fmt.Println(secret)
+
+		return nil
+	})
+}
+
+// tokensListAction lists the app tokens of a user.
+func tokensListAction(ctx *cli.Context) error {
+	return withTokenUser(ctx, func(user *entity.Person) error {
+		fmt.Printf("%-20s %-32s %-8s %-20s %s\n", "UID", "NAME", "SCOPE", "EXPIRES", "LAST USED")
+
+		for _, token := range entity.FindUserAppTokens(user.PersonUID) {
+			scope := token.TokenScope
+			expires := "never"
+			used := "never"
+
+			if scope == entity.TokenScopeAll {
+				scope = "all"
+			}
+
+			if token.ExpiresAt != nil {
+				expires = token.ExpiresAt.Format("2006-01-02 15:04:05")
+			}
+
+			if token.UsedAt != nil {
+				used = token.UsedAt.Format("2006-01-02 15:04:05")
+			}
+
+			fmt.Printf("%-20s %-32s %-8s %-20s %s\n", token.TokenUID, token.TokenName, scope, expires, used)
+		}
+
+		return nil
+	})
+}
+
+// tokensRemoveAction removes an app token.
+func tokensRemoveAction(ctx *cli.Context) error {
+	tokenUID := strings.TrimSpace(ctx.Args().Get(1))
+
+	if tokenUID == "" {
+		return errors.New("please provide a token uid")
+	}
+
+	return withTokenUser(ctx, func(user *entity.Person) error {
+		token := entity.FindAppTokenByUID(user.PersonUID, tokenUID)
+
+		if token == nil {
+			return fmt.Errorf("app token %s not found", txt.Quote(tokenUID))
+		}
+
+		if err := token.Delete(); err != nil {
+			return err
+		}
+
+		log.Infof("removed app token %s", txt.Quote(token.TokenName))
+
+		return nil
+	})
+}
+
+// withTokenUser finds the user passed as first argument after initializing the database.
+func withTokenUser(ctx *cli.Context, f func(user *entity.Person) error) error {
+	userName := strings.TrimSpace(ctx.Args().First())
+
+	if userName == "" {
+		return errors.New("please provide a user name")
+	}
+
+	return withDb(ctx, func(conf *config.Config) error {
+		user := entity.FindPersonByUserName(userName)
+
+		if user == nil {
+			return fmt.Errorf("user %s not found", txt.Quote(userName))
+		}
+
+		return f(user)
+	})
+}
@@ -0,0 +1,274 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+	"github.com/urfave/cli"
+)
+
+// UsersCommand is used to register the users cli command
+var UsersCommand = cli.Command{
+	Name:  "users",
+	Usage: "User management sub-commands",
+	Subcommands: []cli.Command{
+		{
+			Name:      "add",
+			Usage:     "Adds a new user account",
+			ArgsUsage: "[username]",
+			Flags:     userFlags,
+			Action:    usersAddAction,
+		},
+		{
+			Name:   "list",
+			Usage:  "Lists registered user accounts",
+			Action: usersListAction,
+		},
+		{
+			Name:      "update",
+			Usage:     "Updates an existing user account",
+			ArgsUsage: "[username]",
+			Flags:     userFlags,
+			Action:    usersUpdateAction,
+		},
+		{
+			Name:      "remove",
+			Usage:     "Removes a user account",
+			ArgsUsage: "[username]",
+			Action:    usersRemoveAction,
+		},
+	},
+}
+
+var userFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "name, n",
+		Usage: "full name displayed in the user interface",
+	},
+	cli.StringFlag{
+		Name:  "email, m",
+		Usage: "email address",
+	},
+	cli.StringFlag{
+		Name:  "password, p",
+		Usage: "password (at least 6 characters), you'll be asked if not provided",
+	},
+	cli.StringFlag{
+		Name:  "role, r",
+		Usage: "user role (admin, family, friend, child or guest)",
+	},
+	cli.BoolFlag{
+		Name:  "enable",
+		Usage: "activate the account so that the user can log in",
+	},
+	cli.BoolFlag{
+		Name:  "disable",
+		Usage: "deactivate the account and log out the user",
+	},
+}
+
+// usersAddAction creates a new user account.
+func usersAddAction(ctx *cli.Context) error {
+	userName := strings.TrimSpace(ctx.Args().First())
+
+	if userName == "" {
+		return errors.New("please provide a user name")
+	}
+
+	return withDb(ctx, func(conf *config.Config) error {
+		f := form.User{
+			UserName:    userName,
+			DisplayName: ctx.String("name"),
+			UserEmail:   ctx.String("email"),
+			UserActive:  !ctx.Bool("disable"),
+		}
+
+		// Check the role first, so that no user is created if it is invalid.
+		if role := ctx.String("role"); role != "" {
+			var m entity.Person
+
+			if err := m.SetRole(acl.Role(role)); err != nil {
+				return err
+			}
+
+			f.RoleAdmin, f.RoleFamily, f.RoleFriend, f.RoleChild, f.RoleGuest = m.RoleAdmin, m.RoleFamily, m.RoleFriend, m.RoleChild, m.RoleGuest
+		}
+
+		password := ctx.String("password")
+
+		if password != "" {
+			if err := entity.ValidatePassword(userName, password); err != nil {
+				return err
+			}
+		} else {
+			log.Infof("please enter a password for %s (at least 6 characters)\n", txt.Quote(userName))
+
+			var err error
+
+			if password, err = readPassword(); err != nil {
+				return err
+			}
+		}
+
+		f.Password = password
+
+		if f.DisplayName == "" {
+			f.DisplayName = userName
+		}
+
+		user, err := entity.CreatePerson(f)
+
+		if err != nil {
+			return err
+		}
+
+		log.Infof("created user %s with role %s", txt.Quote(user.UserName), user.Role())
+
+		return nil
+	})
+}
+
+// usersListAction lists registered user accounts.
+func usersListAction(ctx *cli.Context) error {
+	return withDb(ctx, func(conf *config.Config) error {
+		users, err := query.UserSearch(form.UserSearch{Count: query.MaxResults})
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%-20s %-32s %-8s %-6s %s\n", "UID", "USERNAME", "ROLE", "ACTIVE", "NAME")
+
+		for _, user := range users {
+			fmt.Printf("%-20s %-32s %-8s %-6t %s\n", user.PersonUID, user.UserName, user.Role(), user.UserActive, user.DisplayName)
+		}
+
+		return nil
+	})
+}
+
+// usersUpdateAction updates an existing user account.
+func usersUpdateAction(ctx *cli.Context) error {
+	userName := strings.TrimSpace(ctx.Args().First())
+
+	if userName == "" {
+		return errors.New("please provide a user name")
+	}
+
+	return withDb(ctx, func(conf *config.Config) error {
+		user := entity.FindPersonByUserName(userName)
+
+		if user == nil {
+			return fmt.Errorf("user %s not found", txt.Quote(userName))
+		}
+
+		if ctx.IsSet("name") {
+			user.DisplayName = ctx.String("name")
+		}
+
+		if ctx.IsSet("email") {
+			user.UserEmail = ctx.String("email")
+		}
+
+		if ctx.IsSet("role") {
+			if err := user.SetRole(acl.Role(ctx.String("role"))); err != nil {
+				return err
+			}
+		}
+
+		if ctx.Bool("enable") {
+			user.UserActive = true
+		} else if ctx.Bool("disable") {
+			user.UserActive = false
+		}
+
+		if err := user.Save(); err != nil {
+			return err
+		}
+
+		// Deactivated users are logged out everywhere.
+		if user.Disabled() {
+			entity.DeleteUserSessions(user.PersonUID)
+		}
+
+		if password := ctx.String("password"); password != "" {
+			if err := user.SetPassword(password); err != nil {
+				return err
+			}
+		}
+
+		log.Infof("updated user %s", txt.Quote(user.UserName))
+
+		return nil
+	})
+}
+
+// usersRemoveAction removes a user account.
+func usersRemoveAction(ctx *cli.Context) error {
+	userName := strings.TrimSpace(ctx.Args().First())
+
+	if userName == "" {
+		return errors.New("please provide a user name")
+	}
+
+	return withDb(ctx, func(conf *config.Config) error {
+		user := entity.FindPersonByUserName(userName)
+
+		if user == nil {
+			return fmt.Errorf("user %s not found", txt.Quote(userName))
+		}
+
+		if err := user.Delete(); err != nil {
+			return err
+		}
+
+		entity.DeleteUserSessions(user.PersonUID)
+
+		log.Infof("removed user %s", txt.Quote(user.UserName))
+
+		return nil
+	})
+}
+
+// withDb initializes config and database before running the given function.
+func withDb(ctx *cli.Context, f func(conf *config.Config) error) error {
+	conf := config.NewConfig(ctx)
+
+	cctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := conf.Init(cctx); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+
+	defer conf.Shutdown()
+
+	return f(conf)
+}
+
+// readPassword prompts for a new password twice and returns it if both match.
+func readPassword() (string, error) {
+	newPassword := getPassword("New Password: ")
+
+	if len(newPassword) < 6 {
+		return "", errors.New("new password is too short, please try again")
+	}
+
+	retypePassword := getPassword("Retype Password: ")
+
+	if newPassword != retypePassword {
+		return "", errors.New("passwords did not match, please try again")
+	}
+
+	return newPassword, nil
+}
@@ -2,11 +2,30 @@ package config
 
 import (
 	"regexp"
+	"strings"
 
 	"github.com/photoprism/photoprism/pkg/rnd"
 	"golang.org/x/crypto/bcrypt"
 )
 
+const (
+	SessionStoreDb     = "db"
+	SessionStoreMemory = "memory"
+)
+
+// SessionStore returns the session store type, sessions are kept in the database by default.
+func (c *Config) SessionStore() string {
+	switch strings.ToLower(strings.TrimSpace(c.params.SessionStore)) {
+	case SessionStoreMemory, "cache":
+		return SessionStoreMemory
+	case SessionStoreDb, "database", "":
+		return SessionStoreDb
+	default:
+		log.Warnf("config: unsupported session store %s, using db", c.params.SessionStore)
+		return SessionStoreDb
+	}
+}
+
 func isBcrypt(s string) bool {
 	b, err := regexp.MatchString(`^\$2[ayb]\$.{56}$`, s)
 	if err != nil {
@@ -38,3 +38,15 @@ func TestUtils_isBcrypt(t *testing.T) {
 	p = "admin"
 	assert.False(t, isBcrypt(p))
 }
+
+func TestConfig_SessionStore(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, SessionStoreDb, c.SessionStore())
+
+	c.params.SessionStore = "Memory"
+	assert.Equal(t, SessionStoreMemory, c.SessionStore())
+
+	c.params.SessionStore = "redis"
+	assert.Equal(t, SessionStoreDb, c.SessionStore())
+}
@@ -26,6 +26,7 @@ type ClientConfig struct {
 	ReadOnly        bool                `json:"readonly"`
 	UploadNSFW      bool                `json:"uploadNSFW"`
 	Public          bool                `json:"public"`
+	OIDC            bool                `json:"oidc"`
 	Experimental    bool                `json:"experimental"`
 	DisableSettings bool                `json:"disableSettings"`
 	AlbumCategories []string            `json:"albumCategories"`
@@ -137,6 +138,7 @@ func (c *Config) PublicConfig() ClientConfig {
 		Debug:           c.Debug(),
 		ReadOnly:        c.ReadOnly(),
 		Public:          c.Public(),
+		OIDC:            c.OIDC(),
 		Experimental:    c.Experimental(),
 		Thumbnails:      Thumbnails,
 		Colors:          colors.All.List(),
@@ -208,6 +210,7 @@ func (c *Config) UserConfig() ClientConfig {
 		UploadNSFW:      c.UploadNSFW(),
 		DisableSettings: c.SettingsHidden(),
 		Public:          c.Public(),
+		OIDC:            c.OIDC(),
 		Experimental:    c.Experimental(),
 		Colors:          colors.All.List(),
 		Thumbnails:      Thumbnails,
@@ -237,8 +240,8 @@ func (c *Config) UserConfig() ClientConfig {
 		Take(&result.Count)
 
 	c.Db().Table("photos").
-		Select("SUM(photo_type = 'video' AND photo_quality >= 0 AND photo_private = 0) AS videos, SUM(photo_type IN ('image','raw','live') AND photo_quality < 3 AND photo_quality >= 0 AND photo_private = 0) AS review, SUM(photo_quality = -1) AS hidden, SUM(photo_type IN ('image','raw','live') AND photo_private = 0 AND photo_quality >= 0) AS photos, SUM(photo_favorite = 1 AND photo_private = 0 AND photo_quality >= 0) AS favorites, SUM(photo_private = 1 AND photo_quality >= 0) AS private").
-		Where("photos.id NOT IN (SELECT photo_id FROM files WHERE file_primary = 1 AND (file_missing = 1 OR file_error <> ''))").
+		Select("SUM(CASE WHEN photo_type = 'video' AND photo_quality >= 0 AND photo_private = FALSE THEN 1 ELSE 0 END) AS videos, SUM(CASE WHEN photo_type IN ('image','raw','live') AND photo_quality < 3 AND photo_quality >= 0 AND photo_private = FALSE THEN 1 ELSE 0 END) AS review, SUM(CASE WHEN photo_quality = -1 THEN 1 ELSE 0 END) AS hidden, SUM(CASE WHEN photo_type IN ('image','raw','live') AND photo_private = FALSE AND photo_quality >= 0 THEN 1 ELSE 0 END) AS photos, SUM(CASE WHEN photo_favorite = TRUE AND photo_private = FALSE AND photo_quality >= 0 THEN 1 ELSE 0 END) AS favorites, SUM(CASE WHEN photo_private = TRUE AND photo_quality >= 0 THEN 1 ELSE 0 END) AS private").
+		Where("photos.id NOT IN (SELECT photo_id FROM files WHERE file_primary = TRUE AND (file_missing = TRUE OR file_error <> ''))").
 		Where("deleted_at IS NULL").
 		Take(&result.Count)
 
@@ -246,17 +249,17 @@ func (c *Config) UserConfig() ClientConfig {
 		Select("MAX(photo_count) as label_max_photos, COUNT(*) AS labels").
 		Where("photo_count > 0").
 		Where("deleted_at IS NULL").
-		Where("(label_priority >= 0 OR label_favorite = 1)").
+		Where("(label_priority >= 0 OR label_favorite = TRUE)").
 		Take(&result.Count)
 
 	c.Db().Table("albums").
-		Select("SUM(album_type = ?) AS albums, SUM(album_type = ?) AS moments, SUM(album_type = ?) AS months, SUM(album_type = ?) AS states, SUM(album_type = ?) AS folders", entity.AlbumDefault, entity.AlbumMoment, entity.AlbumMonth, entity.AlbumState, entity.AlbumFolder).
+		Select("SUM(CASE WHEN album_type IN (?, ?) THEN 1 ELSE 0 END) AS albums, SUM(CASE WHEN album_type = ? THEN 1 ELSE 0 END) AS moments, SUM(CASE WHEN album_type = ? THEN 1 ELSE 0 END) AS months, SUM(CASE WHEN album_type = ? THEN 1 ELSE 0 END) AS states, SUM(CASE WHEN album_type = ? THEN 1 ELSE 0 END) AS folders", entity.AlbumDefault, entity.AlbumSmart, entity.AlbumMoment, entity.AlbumMonth, entity.AlbumState, entity.AlbumFolder).
 		Where("deleted_at IS NULL").
 		Take(&result.Count)
 
 	c.Db().Table("files").
 		Select("COUNT(*) AS files").
-		Where("file_missing = 0").
+		Where("file_missing = FALSE").
 		Where("deleted_at IS NULL").
 		Take(&result.Count)
 
@@ -265,7 +268,7 @@ func (c *Config) UserConfig() ClientConfig {
 		Take(&result.Count)
 
 	c.Db().Table("places").
-		Select("SUM(photo_count > 0) AS places").
+		Select("SUM(CASE WHEN photo_count > 0 THEN 1 ELSE 0 END) AS places").
 		Where("id != 'zz'").
 		Take(&result.Count)
 
@@ -280,7 +283,7 @@ func (c *Config) UserConfig() ClientConfig {
 		Limit(10000).Order("lens_slug").
 		Find(&result.Lenses)
 
-	c.Db().Where("deleted_at IS NULL AND album_favorite = 1").
+	c.Db().Where("deleted_at IS NULL AND album_favorite = TRUE").
 		Limit(20).Order("album_title").
 		Find(&result.Albums)
 
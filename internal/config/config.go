@@ -10,6 +10,7 @@ import (
 	"github.com/jinzhu/gorm"
 	_ "github.com/jinzhu/gorm/dialects/mysql"
 	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/thumb"
@@ -203,6 +204,10 @@ func (c *Config) Shutdown() {
 	mutex.SyncWorker.Cancel()
 	mutex.MetaWorker.Cancel()
 
+	if _, err := entity.FlushFullText(); err != nil {
+		log.Errorf("could not update search index: %s", err)
+	}
+
 	if err := c.CloseDb(); err != nil {
 		log.Errorf("could not close database connection: %s", err)
 	} else {
@@ -234,6 +239,24 @@ func (c *Config) WakeupInterval() time.Duration {
 	return time.Duration(c.params.WakeupInterval) * time.Second
 }
 
+// BackupInterval returns the interval for automatic index backups, or 0 if disabled.
+func (c *Config) BackupInterval() time.Duration {
+	if c.params.BackupInterval <= 0 {
+		return 0
+	}
+
+	return time.Duration(c.params.BackupInterval) * time.Hour
+}
+
+// BackupCount returns the number of automatic index backups to keep.
+func (c *Config) BackupCount() int {
+	if c.params.BackupCount <= 0 {
+		return 3
+	}
+
+	return c.params.BackupCount
+}
+
 // GeoCodingApi returns the preferred geo coding api (none, osm or places).
 func (c *Config) GeoCodingApi() string {
 	switch c.params.GeoCodingApi {
@@ -194,6 +194,16 @@ func (c *Config) SidecarYaml() bool {
 	return c.params.SidecarYaml
 }
 
+// SidecarXmp returns true if confirmed face regions should be written to XMP sidecar files,
+// which must be stored next to the originals so that other apps can find them.
+func (c *Config) SidecarXmp() bool {
+	if c.ReadOnly() {
+		return false
+	}
+
+	return c.params.SidecarXmp
+}
+
 // SidecarPath returns the storage path for automatically created sidecar files.
 func (c *Config) SidecarPath() string {
 	if c.params.SidecarPath == "" {
@@ -241,6 +251,15 @@ func (c *Config) CachePath() string {
 	return fs.Abs(c.params.CachePath)
 }
 
+// BackupPath returns the path to index backups.
+func (c *Config) BackupPath() string {
+	if c.params.BackupPath == "" {
+		return filepath.Join(c.StoragePath(), "backup")
+	}
+
+	return fs.Abs(c.params.BackupPath)
+}
+
 // StoragePath returns the path for generated files like cache and index.
 func (c *Config) StoragePath() string {
 	if c.params.StoragePath == "" {
@@ -36,6 +36,42 @@ var GlobalFlags = []cli.Flag{
 		Usage:  "initial admin password (can be changed in settings)",
 		EnvVar: "PHOTOPRISM_ADMIN_PASSWORD",
 	},
+	cli.StringFlag{
+		Name:   "session-store",
+		Usage:  "where sessions are stored, db or memory",
+		Value:  SessionStoreDb,
+		EnvVar: "PHOTOPRISM_SESSION_STORE",
+	},
+	cli.StringFlag{
+		Name:   "oidc-issuer",
+		Usage:  "OpenID Connect issuer `URL` for single sign-on",
+		EnvVar: "PHOTOPRISM_OIDC_ISSUER",
+	},
+	cli.StringFlag{
+		Name:   "oidc-client",
+		Usage:  "OpenID Connect client id",
+		EnvVar: "PHOTOPRISM_OIDC_CLIENT",
+	},
+	cli.StringFlag{
+		Name:   "oidc-secret",
+		Usage:  "OpenID Connect client secret",
+		EnvVar: "PHOTOPRISM_OIDC_SECRET",
+	},
+	cli.StringFlag{
+		Name:   "oidc-redirect-url",
+		Usage:  "OpenID Connect redirect `URL` (default is site url + auth/oidc)",
+		EnvVar: "PHOTOPRISM_OIDC_REDIRECT_URL",
+	},
+	cli.StringFlag{
+		Name:   "oidc-roles",
+		Usage:  "maps group claims to roles, e.g. admin=admins,family=family (no groups are mapped by default)",
+		EnvVar: "PHOTOPRISM_OIDC_ROLES",
+	},
+	cli.StringFlag{
+		Name:   "oidc-default-role",
+		Usage:  "role of single sign-on users without matching group, login is denied if empty",
+		EnvVar: "PHOTOPRISM_OIDC_DEFAULT_ROLE",
+	},
 	cli.IntFlag{
 		Name:   "workers, w",
 		Usage:  "number of workers for indexing",
@@ -97,7 +133,7 @@ var GlobalFlags = []cli.Flag{
 	},
 	cli.StringFlag{
 		Name:   "database-driver",
-		Usage:  "database driver `NAME` (sqlite or mysql)",
+		Usage:  "database driver `NAME` (sqlite, mysql or postgres)",
 		Value:  "sqlite",
 		EnvVar: "PHOTOPRISM_DATABASE_DRIVER",
 	},
@@ -160,6 +196,23 @@ var GlobalFlags = []cli.Flag{
 		Usage:  "cache `PATH`",
 		EnvVar: "PHOTOPRISM_CACHE_PATH",
 	},
+	cli.StringFlag{
+		Name:   "backup-path",
+		Usage:  "index backup `PATH`",
+		EnvVar: "PHOTOPRISM_BACKUP_PATH",
+	},
+	cli.IntFlag{
+		Name:   "backup-interval",
+		Usage:  "index backup interval in `HOURS`, disabled if 0",
+		Value:  24,
+		EnvVar: "PHOTOPRISM_BACKUP_INTERVAL",
+	},
+	cli.IntFlag{
+		Name:   "backup-count",
+		Usage:  "`NUMBER` of index backups to keep",
+		Value:  3,
+		EnvVar: "PHOTOPRISM_BACKUP_COUNT",
+	},
 	cli.StringFlag{
 		Name:   "temp-path",
 		Usage:  "temporary `PATH` for uploads and downloads",
@@ -220,6 +273,11 @@ var GlobalFlags = []cli.Flag{
 		Usage:  "backup photo metadata to YAML sidecar files",
 		EnvVar: "PHOTOPRISM_SIDECAR_YAML",
 	},
+	cli.BoolFlag{
+		Name:   "sidecar-xmp",
+		Usage:  "write confirmed face regions to XMP sidecar files next to originals",
+		EnvVar: "PHOTOPRISM_SIDECAR_XMP",
+	},
 	cli.BoolFlag{
 		Name:   "sidecar-hidden",
 		Usage:  "create JSON and YAML sidecar files in .photoprism if enabled",
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/acl"
+)
+
+// OIDCRolePriority lists roles that can be assigned by single sign-on, highest first.
+var OIDCRolePriority = []acl.Role{acl.RoleAdmin, acl.RoleFamily, acl.RoleFriend, acl.RoleChild, acl.RoleGuest}
+
+// OIDC returns true if OpenID Connect single sign-on is configured.
+func (c *Config) OIDC() bool {
+	return c.OIDCIssuer() != "" && c.OIDCClient() != ""
+}
+
+// OIDCIssuer returns the OpenID Connect issuer URL.
+func (c *Config) OIDCIssuer() string {
+	return strings.TrimRight(c.params.OIDCIssuer, "/")
+}
+
+// OIDCClient returns the OpenID Connect client id.
+func (c *Config) OIDCClient() string {
+	return c.params.OIDCClient
+}
+
+// OIDCSecret returns the OpenID Connect client secret.
+func (c *Config) OIDCSecret() string {
+	return c.params.OIDCSecret
+}
+
+// OIDCRedirectUrl returns the URL the identity provider redirects to after login.
+func (c *Config) OIDCRedirectUrl() string {
+	if c.params.OIDCRedirectUrl == "" {
+		return c.SiteUrl() + "auth/oidc"
+	}
+
+	return c.params.OIDCRedirectUrl
+}
+
+// OIDCRoles returns the group claims mapped to each role, no groups are mapped by default.
+func (c *Config) OIDCRoles() map[acl.Role][]string {
+	result := make(map[acl.Role][]string)
+
+	if c.params.OIDCRoles == "" {
+		return result
+	}
+
+	for _, s := range strings.Split(c.params.OIDCRoles, ",") {
+		v := strings.SplitN(s, "=", 2)
+
+		if len(v) != 2 {
+			log.Warnf("config: invalid oidc role mapping %s", s)
+			continue
+		}
+
+		role := acl.Role(strings.ToLower(strings.TrimSpace(v[0])))
+		group := strings.TrimSpace(v[1])
+
+		if group == "" {
+			continue
+		}
+
+		result[role] = append(result[role], group)
+	}
+
+	return result
+}
+
+// OIDCDefaultRole returns the role of single sign-on users without matching group claim.
+func (c *Config) OIDCDefaultRole() acl.Role {
+	return acl.Role(strings.ToLower(strings.TrimSpace(c.params.OIDCDefaultRole)))
+}
+
+// OIDCRole returns the highest role matching the group claims or the default role,
+// an empty role means that the user must not log in.
+func (c *Config) OIDCRole(groups []string) acl.Role {
+	roles := c.OIDCRoles()
+
+	for _, role := range OIDCRolePriority {
+		for _, group := range roles[role] {
+			for _, g := range groups {
+				if g == group {
+					return role
+				}
+			}
+		}
+	}
+
+	return c.OIDCDefaultRole()
+}
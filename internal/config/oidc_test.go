@@ -0,0 +1,40 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_OIDC(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.OIDC())
+
+	c.params.OIDCIssuer = "https://id.example.com/"
+	c.params.OIDCClient = "photoprism"
+
+	assert.True(t, c.OIDC())
+	assert.Equal(t, "https://id.example.com", c.OIDCIssuer())
+	assert.Equal(t, c.SiteUrl()+"auth/oidc", c.OIDCRedirectUrl())
+}
+
+func TestConfig_OIDCRole(t *testing.T) {
+	t.Run("default mapping", func(t *testing.T) {
+		c := NewConfig(CliTestContext())
+
+		assert.Empty(t, c.OIDCRoles())
+		assert.Equal(t, acl.Role(""), c.OIDCRole([]string{"family", "admin"}))
+		assert.Equal(t, acl.Role(""), c.OIDCRole([]string{"staff"}))
+	})
+	t.Run("custom mapping", func(t *testing.T) {
+		c := NewConfig(CliTestContext())
+		c.params.OIDCRoles = "admin=photo-admins, friend=friends,friend=neighbors, invalid"
+		c.params.OIDCDefaultRole = "Guest"
+
+		assert.Equal(t, acl.RoleAdmin, c.OIDCRole([]string{"photo-admins"}))
+		assert.Equal(t, acl.RoleFriend, c.OIDCRole([]string{"neighbors"}))
+		assert.Equal(t, acl.RoleGuest, c.OIDCRole([]string{"admin"}))
+	})
+}
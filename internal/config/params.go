@@ -15,8 +15,9 @@ import (
 
 // Database drivers (sql dialects).
 const (
-	MySQL  = "mysql"
-	SQLite = "sqlite3"
+	MySQL    = "mysql"
+	SQLite   = "sqlite3"
+	Postgres = "postgres"
 )
 
 // Params provides a struct in which application configuration is stored.
@@ -45,6 +46,13 @@ type Params struct {
 	Workers            int    `yaml:"workers" flag:"workers"`
 	WakeupInterval     int    `yaml:"wakeup-interval" flag:"wakeup-interval"`
 	AdminPassword      string `yaml:"admin-password" flag:"admin-password"`
+	SessionStore       string `yaml:"session-store" flag:"session-store"`
+	OIDCIssuer         string `yaml:"oidc-issuer" flag:"oidc-issuer"`
+	OIDCClient         string `yaml:"oidc-client" flag:"oidc-client"`
+	OIDCSecret         string `yaml:"oidc-secret" flag:"oidc-secret"`
+	OIDCRedirectUrl    string `yaml:"oidc-redirect-url" flag:"oidc-redirect-url"`
+	OIDCRoles          string `yaml:"oidc-roles" flag:"oidc-roles"`
+	OIDCDefaultRole    string `yaml:"oidc-default-role" flag:"oidc-default-role"`
 	LogLevel           string `yaml:"log-level" flag:"log-level"`
 	AssetsPath         string `yaml:"assets-path" flag:"assets-path"`
 	StoragePath        string `yaml:"storage-path" flag:"storage-path"`
@@ -56,6 +64,9 @@ type Params struct {
 	SettingsHidden     bool   `yaml:"settings-hidden" flag:"settings-hidden"`
 	TempPath           string `yaml:"temp-path" flag:"temp-path"`
 	CachePath          string `yaml:"cache-path" flag:"cache-path"`
+	BackupPath         string `yaml:"backup-path" flag:"backup-path"`
+	BackupInterval     int    `yaml:"backup-interval" flag:"backup-interval"`
+	BackupCount        int    `yaml:"backup-count" flag:"backup-count"`
 	DatabaseDriver     string `yaml:"database-driver" flag:"database-driver"`
 	DatabaseDsn        string `yaml:"database-dsn" flag:"database-dsn"`
 	DatabaseConns      int    `yaml:"database-conns" flag:"database-conns"`
@@ -70,6 +81,7 @@ type Params struct {
 	ExifToolBin        string `yaml:"exiftool-bin" flag:"exiftool-bin"`
 	SidecarJson        bool   `yaml:"sidecar-json" flag:"sidecar-json"`
 	SidecarYaml        bool   `yaml:"sidecar-yaml" flag:"sidecar-yaml"`
+	SidecarXmp         bool   `yaml:"sidecar-xmp" flag:"sidecar-xmp"`
 	SidecarPath        string `yaml:"sidecar-path" flag:"sidecar-path"`
 	PIDFilename        string `yaml:"pid-filename" flag:"pid-filename"`
 	LogFilename        string `yaml:"log-filename" flag:"log-filename"`
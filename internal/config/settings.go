@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"os"
 
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/txt"
@@ -35,6 +36,7 @@ type IndexSettings struct {
 type ImportSettings struct {
 	Path string `json:"path" yaml:"path"`
 	Move bool   `json:"move" yaml:"move"`
+	Dest string `json:"dest" yaml:"dest"`
 }
 
 type FeatureSettings struct {
@@ -109,6 +111,17 @@ func NewSettings() *Settings {
 // Propagate updates settings in other packages as needed.
 func (s Settings) Propagate() {
 	i18n.SetLang(s.Language)
+
+	// Indexed words are stemmed in the user interface language.
+	if entity.SetFullTextLang(s.Language) && entity.FullTextEnabled() {
+		go func() {
+			if count, err := entity.RebuildFullText(); err != nil {
+				log.Errorf("fulltext: %s", err)
+			} else {
+				log.Infof("fulltext: indexed %d photos", count)
+			}
+		}()
+	}
 }
 
 // Load uses a yaml config file to initiate the configuration entity.
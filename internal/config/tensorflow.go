@@ -25,3 +25,8 @@ func (c *Config) TensorFlowModelPath() string {
 func (c *Config) NSFWModelPath() string {
 	return filepath.Join(c.AssetsPath(), "nsfw")
 }
+
+// FaceNetModelPath returns the face detection and embedding TensorFlow model path.
+func (c *Config) FaceNetModelPath() string {
+	return filepath.Join(c.AssetsPath(), "facenet")
+}
@@ -2,11 +2,13 @@ package entity
 
 import (
 	"database/sql"
+	"fmt"
 	"sort"
 	"time"
 
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/remote"
+	"github.com/photoprism/photoprism/internal/remote/s3"
 	"github.com/photoprism/photoprism/internal/remote/webdav"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/ulule/deepcopier"
@@ -74,8 +76,8 @@ func (m *Account) SaveForm(form form.Account) error {
 		return err
 	}
 
-	if m.AccType != string(remote.ServiceWebDAV) {
-		// TODO: Only WebDAV supported at the moment
+	if !remote.CanSync(m.AccType) {
+		// TODO: Only WebDAV and S3 supported at the moment
 		m.AccShare = false
 		m.AccSync = false
 	}
@@ -102,10 +104,27 @@ func (m *Account) Delete() error {
 	return Db().Delete(m).Error
 }
 
+// Client returns a client for sharing and syncing files with the remote service.
+func (m *Account) Client() (remote.Client, error) {
+	switch m.AccType {
+	case remote.ServiceWebDAV:
+		return webdav.New(m.AccURL, m.AccUser, m.AccPass), nil
+	case remote.ServiceS3:
+		return s3.New(m.AccURL, m.AccUser, m.AccPass)
+	default:
+		return nil, fmt.Errorf("account: %s not supported", m.AccType)
+	}
+}
+
 // Directories returns a list of directories or albums in an account.
 func (m *Account) Directories() (result fs.FileInfos, err error) {
-	if m.AccType == remote.ServiceWebDAV {
-		c := webdav.New(m.AccURL, m.AccUser, m.AccPass)
+	if remote.CanSync(m.AccType) {
+		var c remote.Client
+
+		if c, err = m.Client(); err != nil {
+			return result, err
+		}
+
 		result, err = c.Directories("/", true)
 	}
 
@@ -2,6 +2,8 @@ package entity
 
 import (
 	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/remote/s3"
+	"github.com/photoprism/photoprism/internal/remote/webdav"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -164,3 +166,42 @@ func TestAccount_Directories(t *testing.T) {
 		assert.Empty(t, result.Abs())
 	})
 }
+
+func TestAccount_Client(t *testing.T) {
+	t.Run("webdav", func(t *testing.T) {
+		account := Account{AccURL: "http://webdav-dummy/", AccType: "webdav", AccUser: "admin", AccPass: "photoprism"}
+
+		c, err := account.Client()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.IsType(t, webdav.Client{}, c)
+	})
+	t.Run("s3", func(t *testing.T) {
+		account := Account{AccURL: "https://s3.eu-central-1.wasabisys.com/photos", AccType: "s3", AccUser: "key", AccPass: "secret"}
+
+		c, err := account.Client()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.IsType(t, s3.Client{}, c)
+	})
+	t.Run("s3 bucket missing", func(t *testing.T) {
+		account := Account{AccURL: "https://s3.eu-central-1.wasabisys.com/", AccType: "s3", AccUser: "key", AccPass: "secret"}
+
+		_, err := account.Client()
+
+		assert.Error(t, err)
+	})
+	t.Run("not supported", func(t *testing.T) {
+		account := Account{AccURL: "https://www.facebook.com/", AccType: "facebook"}
+
+		_, err := account.Client()
+
+		assert.Error(t, err)
+	})
+}
@@ -20,6 +20,7 @@ const (
 	AlbumMoment  = "moment"
 	AlbumMonth   = "month"
 	AlbumState   = "state"
+	AlbumSmart   = "smart"
 )
 
 type Albums []Album
@@ -28,6 +29,7 @@ type Albums []Album
 type Album struct {
 	ID               uint       `gorm:"primary_key" json:"ID" yaml:"-"`
 	AlbumUID         string     `gorm:"type:varbinary(42);unique_index;" json:"UID" yaml:"UID"`
+	OwnerUID         string     `gorm:"type:varbinary(42);index;" json:"OwnerUID" yaml:"OwnerUID,omitempty"`
 	CoverUID         string     `gorm:"type:varbinary(42);" json:"CoverUID" yaml:"CoverUID,omitempty"`
 	FolderUID        string     `gorm:"type:varbinary(42);index;" json:"FolderUID" yaml:"FolderUID,omitempty"`
 	AlbumSlug        string     `gorm:"type:varbinary(255);index;" json:"Slug" yaml:"Slug"`
@@ -116,6 +118,19 @@ func NewAlbum(albumTitle, albumType string) *Album {
 	return result
 }
 
+// NewSmartAlbum creates a new smart album whose photos are found based on a saved search filter.
+func NewSmartAlbum(albumTitle, albumFilter string) *Album {
+	if albumFilter == "" {
+		return nil
+	}
+
+	result := NewAlbum(albumTitle, AlbumSmart)
+	result.AlbumOrder = SortOrderNewest
+	result.AlbumFilter = albumFilter
+
+	return result
+}
+
 // NewFolderAlbum creates a new folder album.
 func NewFolderAlbum(albumTitle, albumSlug, albumFilter string) *Album {
 	if albumTitle == "" || albumSlug == "" || albumFilter == "" {
@@ -318,7 +333,7 @@ func (m *Album) Create() error {
 	}
 
 	switch m.AlbumType {
-	case AlbumDefault:
+	case AlbumDefault, AlbumSmart:
 		event.Publish("count.albums", event.Data{"count": 1})
 	case AlbumMoment:
 		event.Publish("count.moments", event.Data{"count": 1})
@@ -330,6 +345,11 @@ func (m *Album) Create() error {
 	return nil
 }
 
+// Dynamic returns true if the album photos are found based on a filter, e.g. moments and smart albums.
+func (m *Album) Dynamic() bool {
+	return m.AlbumFilter != ""
+}
+
 // Returns the album title.
 func (m *Album) Title() string {
 	return m.AlbumTitle
@@ -27,6 +27,26 @@ func TestNewAlbum(t *testing.T) {
 	})
 }
 
+func TestNewSmartAlbum(t *testing.T) {
+	t.Run("label cat", func(t *testing.T) {
+		album := NewSmartAlbum("Cats", "label:cat")
+		assert.Equal(t, "Cats", album.AlbumTitle)
+		assert.Equal(t, "cats", album.AlbumSlug)
+		assert.Equal(t, AlbumSmart, album.AlbumType)
+		assert.Equal(t, SortOrderNewest, album.AlbumOrder)
+		assert.Equal(t, "label:cat", album.AlbumFilter)
+		assert.True(t, album.Dynamic())
+	})
+	t.Run("filter empty", func(t *testing.T) {
+		assert.Nil(t, NewSmartAlbum("Cats", ""))
+	})
+}
+
+func TestAlbum_Dynamic(t *testing.T) {
+	assert.False(t, NewAlbum("Christmas 2018", AlbumDefault).Dynamic())
+	assert.True(t, NewFolderAlbum("April 1990", "april-1990", "path:\"1990/04\"").Dynamic())
+}
+
 func TestAlbum_SetName(t *testing.T) {
 	t.Run("valid name", func(t *testing.T) {
 		album := NewAlbum("initial name", AlbumDefault)
@@ -0,0 +1,197 @@
+package entity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// App token scopes.
+const (
+	TokenScopeAll    = ""
+	TokenScopeRead   = "read"
+	TokenScopeUpload = "upload"
+	TokenScopeWebDAV = "webdav"
+)
+
+// TokenScopes lists valid app token scopes.
+var TokenScopes = []string{TokenScopeAll, TokenScopeRead, TokenScopeUpload, TokenScopeWebDAV}
+
+type AppTokens []AppToken
+
+// AppToken represents a named api token for scripts and apps, only a hash of the secret is stored.
+type AppToken struct {
+	TokenUID   string     `gorm:"type:varbinary(42);primary_key;" json:"UID" yaml:"UID"`
+	UserUID    string     `gorm:"type:varbinary(42);index;" json:"UserUID" yaml:"UserUID"`
+	TokenName  string     `gorm:"type:varchar(64);" json:"Name" yaml:"Name"`
+	TokenScope string     `gorm:"type:varbinary(32);" json:"Scope" yaml:"Scope,omitempty"`
+	TokenHash  string     `gorm:"type:varbinary(64);unique_index;" json:"-" yaml:"-"`
+	ExpiresAt  *time.Time `json:"ExpiresAt" yaml:"ExpiresAt,omitempty"`
+	UsedAt     *time.Time `json:"UsedAt" yaml:"-"`
+	CreatedAt  time.Time  `json:"CreatedAt" yaml:"CreatedAt"`
+}
+
+// BeforeCreate creates a random UID if needed before inserting a new row to the database.
+func (m *AppToken) BeforeCreate(scope *gorm.Scope) error {
+	if rnd.IsUID(m.TokenUID, 't') {
+		return nil
+	}
+
+	return scope.SetColumn("TokenUID", rnd.PPID('t'))
+}
+
+// AppTokenHash returns the hash under which a secret app token is stored.
+func AppTokenHash(secret string) string {
+	h := sha256.Sum256([]byte(secret))
+
+	return hex.EncodeToString(h[:])
+}
+
+// ValidTokenScope returns true if the app token scope is known.
+func ValidTokenScope(scope string) bool {
+	for _, s := range TokenScopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CreateAppToken creates a new app token for a user and returns it together with the secret,
+// which can't be restored later. Tokens don't expire if expires is 0 seconds.
+func CreateAppToken(userUID, name, scope string, expires int) (m *AppToken, secret string, err error) {
+	name = txt.Clip(strings.TrimSpace(name), 64)
+	scope = strings.ToLower(strings.TrimSpace(scope))
+
+	if userUID == "" {
+		return nil, "", fmt.Errorf("app tokens require a user")
+	}
+
+	if name == "" {
+		return nil, "", fmt.Errorf("app token name must not be empty")
+	}
+
+	if !ValidTokenScope(scope) {
+		return nil, "", fmt.Errorf("unknown app token scope %s", txt.Quote(scope))
+	}
+
+	b := make([]byte, 24)
+
+	if _, err := rand.Read(b); err != nil {
+		return nil, "", err
+	}
+
+	secret = hex.EncodeToString(b)
+
+	m = &AppToken{
+		UserUID:    userUID,
+		TokenName:  name,
+		TokenScope: scope,
+		TokenHash:  AppTokenHash(secret),
+		CreatedAt:  Timestamp(),
+	}
+
+	if expires > 0 {
+		expiresAt := m.CreatedAt.Add(Seconds(expires))
+		m.ExpiresAt = &expiresAt
+	}
+
+	if err := Db().Create(m).Error; err != nil {
+		return nil, "", err
+	}
+
+	return m, secret, nil
+}
+
+// FindAppToken returns a valid app token by secret or nil if not found or expired.
+func FindAppToken(secret string) *AppToken {
+	if secret == "" {
+		return nil
+	}
+
+	result := AppToken{}
+
+	if err := Db().Where("token_hash = ?", AppTokenHash(secret)).First(&result).Error; err != nil {
+		return nil
+	}
+
+	if result.Expired() {
+		return nil
+	}
+
+	return &result
+}
+
+// FindAppTokenByUID returns an app token of a user or nil if not found.
+func FindAppTokenByUID(userUID, tokenUID string) *AppToken {
+	result := AppToken{}
+
+	if err := Db().Where("user_uid = ? AND token_uid = ?", userUID, tokenUID).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// FindUserAppTokens returns all app tokens of a user.
+func FindUserAppTokens(userUID string) (result AppTokens) {
+	if err := Db().Where("user_uid = ?", userUID).Order("created_at DESC").Find(&result).Error; err != nil {
+		log.Errorf("token: %s", err)
+	}
+
+	return result
+}
+
+// Delete removes the app token from the database.
+func (m *AppToken) Delete() error {
+	return Db().Delete(m).Error
+}
+
+// Expired returns true if the app token has expired.
+func (m *AppToken) Expired() bool {
+	return m.ExpiresAt != nil && m.ExpiresAt.Before(Timestamp())
+}
+
+// Used updates the last used time, at most once per minute.
+func (m *AppToken) Used() {
+	now := Timestamp()
+
+	if m.UsedAt != nil && now.Sub(*m.UsedAt) < time.Minute {
+		return
+	}
+
+	m.UsedAt = &now
+
+	if err := Db().Model(m).UpdateColumn("used_at", now).Error; err != nil {
+		log.Errorf("token: %s", err)
+	}
+}
+
+// Allow returns true if the token scope includes the action on the resource,
+// user role permissions must be checked separately.
+func (m *AppToken) Allow(resource acl.Resource, action acl.Action) bool {
+	switch m.TokenScope {
+	case TokenScopeAll:
+		return true
+	case TokenScopeRead:
+		return action == acl.ActionSearch || action == acl.ActionRead || action == acl.ActionDownload
+	case TokenScopeUpload:
+		return resource == acl.ResourcePhotos && (action == acl.ActionUpload || action == acl.ActionImport)
+	default:
+		return false
+	}
+}
+
+// AllowWebDAV returns true if the token may be used to access WebDAV.
+func (m *AppToken) AllowWebDAV() bool {
+	return m.TokenScope == TokenScopeAll || m.TokenScope == TokenScopeWebDAV
+}
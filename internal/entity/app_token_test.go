@@ -0,0 +1,102 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateAppToken(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		m, secret, err := CreateAppToken(Admin.PersonUID, "Backup", TokenScopeRead, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 48, len(secret))
+		assert.Equal(t, "Backup", m.TokenName)
+		assert.Equal(t, AppTokenHash(secret), m.TokenHash)
+		assert.Nil(t, m.ExpiresAt)
+
+		found := FindAppToken(secret)
+
+		if found == nil {
+			t.Fatal("token should be found")
+		}
+
+		assert.Equal(t, m.TokenUID, found.TokenUID)
+	})
+	t.Run("unknown scope", func(t *testing.T) {
+		_, _, err := CreateAppToken(Admin.PersonUID, "Backup", "admin", 0)
+
+		assert.Error(t, err)
+	})
+	t.Run("empty name", func(t *testing.T) {
+		_, _, err := CreateAppToken(Admin.PersonUID, " ", TokenScopeAll, 0)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestFindAppToken(t *testing.T) {
+	t.Run("expired", func(t *testing.T) {
+		m, secret, err := CreateAppToken(Admin.PersonUID, "Expired", TokenScopeAll, -10)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, m.Expired())
+		assert.Nil(t, FindAppToken(secret))
+	})
+	t.Run("not found", func(t *testing.T) {
+		assert.Nil(t, FindAppToken("xxx"))
+		assert.Nil(t, FindAppToken(""))
+	})
+}
+
+func TestAppToken_Delete(t *testing.T) {
+	m, secret, err := CreateAppToken(Admin.PersonUID, "Delete", TokenScopeWebDAV, 3600)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotNil(t, FindAppTokenByUID(Admin.PersonUID, m.TokenUID))
+	assert.Nil(t, FindAppTokenByUID(Guest.PersonUID, m.TokenUID))
+
+	if err := m.Delete(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Nil(t, FindAppToken(secret))
+}
+
+func TestAppToken_Allow(t *testing.T) {
+	t.Run("all", func(t *testing.T) {
+		m := AppToken{TokenScope: TokenScopeAll}
+		assert.True(t, m.Allow(acl.ResourcePhotos, acl.ActionDelete))
+		assert.True(t, m.AllowWebDAV())
+	})
+	t.Run("read", func(t *testing.T) {
+		m := AppToken{TokenScope: TokenScopeRead}
+		assert.True(t, m.Allow(acl.ResourcePhotos, acl.ActionSearch))
+		assert.True(t, m.Allow(acl.ResourceAlbums, acl.ActionDownload))
+		assert.False(t, m.Allow(acl.ResourcePhotos, acl.ActionUpdate))
+		assert.False(t, m.AllowWebDAV())
+	})
+	t.Run("upload", func(t *testing.T) {
+		m := AppToken{TokenScope: TokenScopeUpload}
+		assert.True(t, m.Allow(acl.ResourcePhotos, acl.ActionUpload))
+		assert.True(t, m.Allow(acl.ResourcePhotos, acl.ActionImport))
+		assert.False(t, m.Allow(acl.ResourcePhotos, acl.ActionRead))
+		assert.False(t, m.AllowWebDAV())
+	})
+	t.Run("webdav", func(t *testing.T) {
+		m := AppToken{TokenScope: TokenScopeWebDAV}
+		assert.False(t, m.Allow(acl.ResourcePhotos, acl.ActionRead))
+		assert.True(t, m.AllowWebDAV())
+	})
+}
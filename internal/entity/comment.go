@@ -0,0 +1,100 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// CommentTextMax is the maximum length of a comment in characters.
+const CommentTextMax = 2048
+
+type Comments []Comment
+
+// Comment represents a comment on a photo or album.
+type Comment struct {
+	CommentUID  string    `gorm:"type:varbinary(42);primary_key;" json:"UID" yaml:"UID"`
+	SubjectUID  string    `gorm:"type:varbinary(42);index;" json:"SubjectUID" yaml:"SubjectUID"`
+	PersonUID   string    `gorm:"type:varbinary(42);index;" json:"PersonUID" yaml:"PersonUID"`
+	PersonName  string    `gorm:"type:varchar(255);" json:"PersonName" yaml:"PersonName,omitempty"`
+	CommentText string    `gorm:"type:text;" json:"Text" yaml:"Text"`
+	CreatedAt   time.Time `gorm:"index;" json:"CreatedAt" yaml:"CreatedAt"`
+	UpdatedAt   time.Time `json:"UpdatedAt" yaml:"UpdatedAt"`
+}
+
+// BeforeCreate creates a random UID if needed before inserting a new row to the database.
+func (m *Comment) BeforeCreate(scope *gorm.Scope) error {
+	if rnd.IsUID(m.CommentUID, 'k') {
+		return nil
+	}
+
+	return scope.SetColumn("CommentUID", rnd.PPID('k'))
+}
+
+// NewComment returns a new comment on a photo or album.
+func NewComment(subjectUID string, person Person, text string) *Comment {
+	return &Comment{
+		CommentUID:  rnd.PPID('k'),
+		SubjectUID:  subjectUID,
+		PersonUID:   person.PersonUID,
+		PersonName:  txt.Clip(person.DisplayName, 255),
+		CommentText: txt.Clip(strings.TrimSpace(text), CommentTextMax),
+	}
+}
+
+// Create inserts a new row to the database.
+func (m *Comment) Create() error {
+	if m.SubjectUID == "" {
+		return fmt.Errorf("comment: subject uid must not be empty")
+	}
+
+	if m.CommentText == "" {
+		return fmt.Errorf("comment: text must not be empty")
+	}
+
+	return Db().Create(m).Error
+}
+
+// SetText updates the comment text.
+func (m *Comment) SetText(text string) error {
+	text = txt.Clip(strings.TrimSpace(text), CommentTextMax)
+
+	if text == "" {
+		return fmt.Errorf("comment: text must not be empty")
+	}
+
+	m.CommentText = text
+
+	return Db().Model(m).Updates(Comment{CommentText: m.CommentText}).Error
+}
+
+// Delete removes the comment from the database.
+func (m *Comment) Delete() error {
+	return Db().Delete(m).Error
+}
+
+// FindComment returns a comment by UID or nil if not found.
+func FindComment(uid string) *Comment {
+	result := Comment{}
+
+	if err := Db().Where("comment_uid = ?", uid).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// FindComments returns the comments on a photo or album, the oldest first.
+func FindComments(subjectUID string) (result Comments) {
+	result = Comments{}
+
+	if err := Db().Where("subject_uid = ?", subjectUID).Order("created_at, comment_uid").Find(&result).Error; err != nil {
+		log.Errorf("comment: %s", err)
+	}
+
+	return result
+}
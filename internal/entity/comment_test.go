@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewComment(t *testing.T) {
+	m := NewComment("at9lxuqxpogaaba7", Guest, "  Hello World  ")
+
+	assert.Equal(t, "at9lxuqxpogaaba7", m.SubjectUID)
+	assert.Equal(t, Guest.PersonUID, m.PersonUID)
+	assert.Equal(t, "Guest", m.PersonName)
+	assert.Equal(t, "Hello World", m.CommentText)
+	assert.Len(t, NewComment("at9lxuqxpogaaba7", Guest, strings.Repeat("x", 3000)).CommentText, CommentTextMax)
+}
+
+func TestComment_Create(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		m := NewComment("at9lxuqxpogaaba7", Admin, "First!")
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		found := FindComment(m.CommentUID)
+
+		if found == nil {
+			t.Fatal("comment not found")
+		}
+
+		assert.Equal(t, "First!", found.CommentText)
+		assert.NotEmpty(t, FindComments("at9lxuqxpogaaba7"))
+
+		if err := found.SetText("Edited"); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Edited", FindComment(m.CommentUID).CommentText)
+
+		if err := found.Delete(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Nil(t, FindComment(m.CommentUID))
+	})
+	t.Run("empty text", func(t *testing.T) {
+		m := NewComment("at9lxuqxpogaaba7", Admin, " ")
+		assert.Error(t, m.Create())
+	})
+}
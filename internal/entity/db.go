@@ -12,8 +12,9 @@ import (
 
 // Database drivers (sql dialects).
 const (
-	MySQL  = "mysql"
-	SQLite = "sqlite3"
+	MySQL    = "mysql"
+	SQLite   = "sqlite3"
+	Postgres = "postgres"
 )
 
 var dbProvider DbProvider
@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"reflect"
+	"regexp"
+
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/postgres"
+)
+
+// postgresTypes maps MySQL specific column types used in struct tags to their PostgreSQL equivalent.
+var postgresTypes = []struct {
+	expr *regexp.Regexp
+	repl string
+}{
+	{regexp.MustCompile(`(?i)^varbinary\b`), "varchar"},
+	{regexp.MustCompile(`(?i)^datetime\b`), "timestamp with time zone"},
+	{regexp.MustCompile(`(?i)^(tiny|medium|long)?blob\b`), "bytea"},
+	{regexp.MustCompile(`(?i)^(medium|long)text\b`), "text"},
+}
+
+// PostgresType returns the PostgreSQL equivalent of a column type definition.
+func PostgresType(s string) string {
+	for _, t := range postgresTypes {
+		if t.expr.MatchString(s) {
+			return t.expr.ReplaceAllString(s, t.repl)
+		}
+	}
+
+	return s
+}
+
+// postgresBase is the original PostgreSQL dialect provided by gorm.
+var postgresBase = gorm.Dialect(nil)
+
+// postgresDialect wraps the PostgreSQL dialect to translate MySQL specific column types.
+type postgresDialect struct {
+	gorm.Dialect
+}
+
+// GetName returns the dialect name.
+func (postgresDialect) GetName() string {
+	return Postgres
+}
+
+// SetDB initializes the wrapped dialect with a database connection.
+func (d *postgresDialect) SetDB(db gorm.SQLCommon) {
+	d.Dialect = reflect.New(reflect.TypeOf(postgresBase).Elem()).Interface().(gorm.Dialect)
+	d.Dialect.SetDB(db)
+}
+
+// DataTypeOf returns the column type definition of a struct field.
+func (d *postgresDialect) DataTypeOf(field *gorm.StructField) string {
+	return PostgresType(d.Dialect.DataTypeOf(field))
+}
+
+func init() {
+	if base, ok := gorm.GetDialect(Postgres); ok {
+		postgresBase = base
+		gorm.RegisterDialect(Postgres, &postgresDialect{})
+	}
+}
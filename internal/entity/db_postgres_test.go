@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostgresType(t *testing.T) {
+	t.Run("varbinary", func(t *testing.T) {
+		assert.Equal(t, "varchar(42)", PostgresType("varbinary(42)"))
+		assert.Equal(t, "varchar(255) NOT NULL", PostgresType("VARBINARY(255) NOT NULL"))
+	})
+	t.Run("datetime", func(t *testing.T) {
+		assert.Equal(t, "timestamp with time zone", PostgresType("datetime"))
+	})
+	t.Run("blob", func(t *testing.T) {
+		assert.Equal(t, "bytea", PostgresType("mediumblob"))
+		assert.Equal(t, "bytea", PostgresType("blob"))
+	})
+	t.Run("text", func(t *testing.T) {
+		assert.Equal(t, "text", PostgresType("mediumtext"))
+		assert.Equal(t, "text", PostgresType("longtext"))
+	})
+	t.Run("unchanged", func(t *testing.T) {
+		assert.Equal(t, "varchar(255)", PostgresType("varchar(255)"))
+		assert.Equal(t, "FLOAT", PostgresType("FLOAT"))
+		assert.Equal(t, "SMALLINT", PostgresType("SMALLINT"))
+		assert.Equal(t, "text", PostgresType("text"))
+	})
+}
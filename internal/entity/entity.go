@@ -29,29 +29,39 @@ type Types map[string]interface{}
 
 // List of database entities and their table names.
 var Entities = Types{
-	"errors":          &Error{},
-	"people":          &Person{},
-	"accounts":        &Account{},
-	"folders":         &Folder{},
-	"files":           &File{},
-	"files_share":     &FileShare{},
-	"files_sync":      &FileSync{},
-	"photos":          &Photo{},
-	"details":         &Details{},
-	"places":          &Place{},
-	"locations":       &Location{},
-	"cameras":         &Camera{},
-	"lenses":          &Lens{},
-	"countries":       &Country{},
-	"albums":          &Album{},
-	"photos_albums":   &PhotoAlbum{},
-	"labels":          &Label{},
-	"categories":      &Category{},
-	"photos_labels":   &PhotoLabel{},
-	"keywords":        &Keyword{},
-	"photos_keywords": &PhotoKeyword{},
-	"passwords":       &Password{},
-	"links":           &Link{},
+	"errors":             &Error{},
+	"people":             &Person{},
+	"accounts":           &Account{},
+	"folders":            &Folder{},
+	"files":              &File{},
+	"files_share":        &FileShare{},
+	"files_sync":         &FileSync{},
+	"photos":             &Photo{},
+	"details":            &Details{},
+	"places":             &Place{},
+	"locations":          &Location{},
+	"cameras":            &Camera{},
+	"lenses":             &Lens{},
+	"countries":          &Country{},
+	"albums":             &Album{},
+	"photos_albums":      &PhotoAlbum{},
+	"labels":             &Label{},
+	"categories":         &Category{},
+	"photos_labels":      &PhotoLabel{},
+	"keywords":           &Keyword{},
+	"photos_keywords":    &PhotoKeyword{},
+	"passwords":          &Password{},
+	"links":              &Link{},
+	"sessions":           &Session{},
+	"app_tokens":         &AppToken{},
+	"two_factors":        &TwoFactor{},
+	"faces":              &Face{},
+	"face_clusters":      &FaceCluster{},
+	"comments":           &Comment{},
+	"likes":              &Like{},
+	"webhooks":           &Webhook{},
+	"webhook_deliveries": &WebhookDelivery{},
+	"highlights":         &Highlight{},
 }
 
 type RowCount struct {
@@ -84,7 +94,7 @@ func (list Types) WaitForMigration() {
 func (list Types) Truncate() {
 	for name := range list {
 		row := RowCount{}
-		if err := Db().Raw(fmt.Sprintf("DELETE FROM %s WHERE 1", name)).Scan(&row).Error; err == nil {
+		if err := Db().Raw(fmt.Sprintf("DELETE FROM %s WHERE 1 = 1", name)).Scan(&row).Error; err == nil {
 			log.Debugf("entity: removed all data from %s", name)
 			break
 		} else if err.Error() != "record not found" {
@@ -134,6 +144,14 @@ func MigrateDb() {
 	Entities.WaitForMigration()
 
 	CreateDefaultFixtures()
+
+	if err := MigrateFullText(); err != nil {
+		log.Warnf("fulltext: %s (search index disabled)", err)
+	}
+
+	if _, err := Migrations.Up(0, false); err != nil {
+		log.Errorf("migrate: %s", err)
+	}
 }
 
 // ResetTestFixtures drops database tables for all known entities and re-creates them with fixtures.
@@ -145,6 +163,12 @@ func ResetTestFixtures() {
 	CreateDefaultFixtures()
 
 	CreateTestFixtures()
+
+	if err := MigrateFullText(); err != nil {
+		log.Warnf("fulltext: %s (search index disabled)", err)
+	} else if _, err := RebuildFullText(); err != nil {
+		log.Errorf("fulltext: %s", err)
+	}
 }
 
 // InitTestDb connects to and completely initializes the test database incl fixtures.
@@ -0,0 +1,151 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/meta"
+)
+
+// Face sources.
+const (
+	FaceSrcImage = "image"
+	FaceSrcXmp   = "xmp"
+)
+
+type Faces []Face
+
+// Face represents a face region found in a file, faces are grouped into clusters by similarity.
+type Face struct {
+	ID         uint      `gorm:"primary_key" json:"ID" yaml:"-"`
+	FileID     uint      `gorm:"index;" json:"-" yaml:"-"`
+	FileUID    string    `gorm:"type:varbinary(42);index;" json:"FileUID" yaml:"FileUID"`
+	PhotoID    uint      `gorm:"index;" json:"-" yaml:"-"`
+	PhotoUID   string    `gorm:"type:varbinary(42);index;" json:"PhotoUID" yaml:"PhotoUID"`
+	ClusterUID string    `gorm:"type:varbinary(42);index;" json:"ClusterUID" yaml:"ClusterUID,omitempty"`
+	PersonUID  string    `gorm:"type:varbinary(42);index;" json:"PersonUID" yaml:"PersonUID,omitempty"`
+	FaceSrc    string    `gorm:"type:varbinary(8);" json:"Src" yaml:"Src"`
+	FaceX      float32   `gorm:"type:FLOAT;" json:"X" yaml:"X"`
+	FaceY      float32   `gorm:"type:FLOAT;" json:"Y" yaml:"Y"`
+	FaceW      float32   `gorm:"type:FLOAT;" json:"W" yaml:"W"`
+	FaceH      float32   `gorm:"type:FLOAT;" json:"H" yaml:"H"`
+	FaceScore  float32   `gorm:"type:FLOAT;" json:"Score" yaml:"Score,omitempty"`
+	Embedding  string    `gorm:"type:mediumblob;" json:"-" yaml:"-"`
+	CreatedAt  time.Time `json:"CreatedAt" yaml:"-"`
+	UpdatedAt  time.Time `json:"UpdatedAt" yaml:"-"`
+}
+
+// NewFace returns a new face entity for a detected face in a file.
+func NewFace(file *File, f face.Face) *Face {
+	return &Face{
+		FileID:    file.ID,
+		FileUID:   file.FileUID,
+		PhotoID:   file.PhotoID,
+		PhotoUID:  file.PhotoUID,
+		FaceSrc:   FaceSrcImage,
+		FaceX:     f.Area.X,
+		FaceY:     f.Area.Y,
+		FaceW:     f.Area.W,
+		FaceH:     f.Area.H,
+		FaceScore: f.Score,
+		Embedding: f.Embedding.JSON(),
+	}
+}
+
+// NewRegionFace returns a new face entity for a named region found in XMP metadata.
+func NewRegionFace(file *File, r meta.Region, personUID string) *Face {
+	return &Face{
+		FileID:    file.ID,
+		FileUID:   file.FileUID,
+		PhotoID:   file.PhotoID,
+		PhotoUID:  file.PhotoUID,
+		PersonUID: personUID,
+		FaceSrc:   FaceSrcXmp,
+		FaceX:     r.X,
+		FaceY:     r.Y,
+		FaceW:     r.W,
+		FaceH:     r.H,
+	}
+}
+
+// Create inserts a new row to the database.
+func (m *Face) Create() error {
+	return Db().Create(m).Error
+}
+
+// Save updates the existing or inserts a new row.
+func (m *Face) Save() error {
+	return Db().Save(m).Error
+}
+
+// Vector returns the face embedding.
+func (m *Face) Vector() face.Embedding {
+	return face.NewEmbedding(m.Embedding)
+}
+
+// ReplaceFileFaces replaces the detected faces of a file, e.g. after the file was changed.
+func ReplaceFileFaces(file *File, faces face.Faces) error {
+	if err := Db().Where("file_id = ? AND face_src = ?", file.ID, FaceSrcImage).Delete(&Face{}).Error; err != nil {
+		return err
+	}
+
+	for _, f := range faces {
+		if err := NewFace(file, f).Create(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Region returns the face area as image region.
+func (m *Face) Region() meta.Region {
+	return meta.Region{Type: meta.RegionTypeFace, X: m.FaceX, Y: m.FaceY, W: m.FaceW, H: m.FaceH}
+}
+
+// ReplaceFileRegions replaces the named face regions of an XMP sidecar file and returns their number,
+// people are created as needed. Regions already confirmed for a detected face are skipped.
+func ReplaceFileRegions(file *File, regions meta.Regions) (count int, err error) {
+	if err := Db().Where("file_id = ? AND face_src = ?", file.ID, FaceSrcXmp).Delete(&Face{}).Error; err != nil {
+		return 0, err
+	}
+
+	var confirmed Faces
+
+	if err := Db().Where("photo_id = ? AND face_src = ? AND person_uid <> ''", file.PhotoID, FaceSrcImage).Find(&confirmed).Error; err != nil {
+		return 0, err
+	}
+
+	var added meta.Regions
+
+regions:
+	for _, r := range regions.Faces() {
+		// Some apps store the same region in multiple formats.
+		for _, a := range added {
+			if a.Name == r.Name && a.Overlaps(r) {
+				continue regions
+			}
+		}
+
+		person, err := FirstOrCreateSubject(r.Name)
+
+		if err != nil {
+			return count, err
+		}
+
+		for _, f := range confirmed {
+			if f.PersonUID == person.PersonUID && f.Region().Overlaps(r) {
+				continue regions
+			}
+		}
+
+		if err := NewRegionFace(file, r, person.PersonUID).Create(); err != nil {
+			return count, err
+		}
+
+		added = append(added, r)
+		count++
+	}
+
+	return count, nil
+}
@@ -0,0 +1,103 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+type FaceClusters []FaceCluster
+
+// FaceCluster represents a group of similar faces that may be linked to a person.
+type FaceCluster struct {
+	ClusterUID string    `gorm:"type:varbinary(42);primary_key;" json:"UID" yaml:"UID"`
+	PersonUID  string    `gorm:"type:varbinary(42);index;" json:"PersonUID" yaml:"PersonUID,omitempty"`
+	Embedding  string    `gorm:"type:mediumblob;" json:"-" yaml:"-"`
+	FaceCount  int       `json:"FaceCount" yaml:"-"`
+	CreatedAt  time.Time `json:"CreatedAt" yaml:"-"`
+	UpdatedAt  time.Time `json:"UpdatedAt" yaml:"-"`
+}
+
+// BeforeCreate creates a random UID if needed before inserting a new row to the database.
+func (m *FaceCluster) BeforeCreate(scope *gorm.Scope) error {
+	if rnd.IsUID(m.ClusterUID, 'c') {
+		return nil
+	}
+
+	return scope.SetColumn("ClusterUID", rnd.PPID('c'))
+}
+
+// NewFaceCluster returns a new, empty face cluster.
+func NewFaceCluster() *FaceCluster {
+	return &FaceCluster{}
+}
+
+// FindFaceCluster returns an existing face cluster or nil if not found.
+func FindFaceCluster(uid string) *FaceCluster {
+	if uid == "" {
+		return nil
+	}
+
+	result := FaceCluster{}
+
+	if err := Db().Where("cluster_uid = ?", uid).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// Create inserts a new row to the database.
+func (m *FaceCluster) Create() error {
+	return Db().Create(m).Error
+}
+
+// Save updates the existing or inserts a new row.
+func (m *FaceCluster) Save() error {
+	return Db().Save(m).Error
+}
+
+// Vector returns the mean embedding of all faces in the cluster.
+func (m *FaceCluster) Vector() face.Embedding {
+	return face.NewEmbedding(m.Embedding)
+}
+
+// AddFace adds a face to the cluster, it is linked to the person of the cluster if any.
+func (m *FaceCluster) AddFace(f *Face) error {
+	m.Embedding = m.Vector().Add(f.Vector(), m.FaceCount).JSON()
+	m.FaceCount++
+
+	if err := m.Save(); err != nil {
+		return err
+	}
+
+	f.ClusterUID = m.ClusterUID
+	f.PersonUID = m.PersonUID
+
+	return Db().Model(f).UpdateColumns(map[string]interface{}{"cluster_uid": f.ClusterUID, "person_uid": f.PersonUID}).Error
+}
+
+// SetPerson links the cluster and all its faces to a person, which is marked as subject.
+func (m *FaceCluster) SetPerson(person *Person) error {
+	personUID := ""
+
+	if person != nil {
+		personUID = person.PersonUID
+
+		if !person.IsSubject {
+			if err := Db().Model(person).UpdateColumn("is_subject", true).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	m.PersonUID = personUID
+
+	if err := Db().Model(m).UpdateColumn("person_uid", personUID).Error; err != nil {
+		return err
+	}
+
+	return Db().Model(&Face{}).Where("cluster_uid = ?", m.ClusterUID).UpdateColumn("person_uid", personUID).Error
+}
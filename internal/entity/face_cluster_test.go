@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFaceCluster_AddFace(t *testing.T) {
+	file := FileFixtures["exampleFileName.jpg"]
+
+	a := NewFace(&file, face.Face{Embedding: face.Embedding{1, 0}})
+	b := NewFace(&file, face.Face{Embedding: face.Embedding{0, 1}})
+
+	for _, f := range []*Face{a, b} {
+		if err := f.Create(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m := NewFaceCluster()
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.AddFace(a); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, face.Embedding{1, 0}, m.Vector())
+
+	if err := m.AddFace(b); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 2, m.FaceCount)
+	assert.Equal(t, m.ClusterUID, b.ClusterUID)
+	assert.InDelta(t, 0.7071, m.Vector()[0], 0.0001)
+
+	t.Run("set person", func(t *testing.T) {
+		person, err := FirstOrCreateSubject("Face Cluster Test")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := m.SetPerson(person); err != nil {
+			t.Fatal(err)
+		}
+
+		found := FindFaceCluster(m.ClusterUID)
+
+		if found == nil {
+			t.Fatal("cluster not found")
+		}
+
+		assert.Equal(t, person.PersonUID, found.PersonUID)
+
+		var faces Faces
+
+		Db().Where("cluster_uid = ?", m.ClusterUID).Find(&faces)
+
+		assert.Len(t, faces, 2)
+
+		for _, f := range faces {
+			assert.Equal(t, person.PersonUID, f.PersonUID)
+		}
+
+		// Existing subjects are found by name.
+		same, err := FirstOrCreateSubject("Face Cluster Test")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, person.PersonUID, same.PersonUID)
+	})
+}
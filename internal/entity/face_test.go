@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplaceFileFaces(t *testing.T) {
+	file := FileFixtures["exampleFileName.jpg"]
+
+	faces := face.Faces{
+		{Area: face.Area{X: 0.1, Y: 0.2, W: 0.1, H: 0.15}, Score: 0.9, Embedding: face.Embedding{0.6, 0.8}},
+		{Area: face.Area{X: 0.5, Y: 0.2, W: 0.1, H: 0.15}, Score: 0.8, Embedding: face.Embedding{0.8, 0.6}},
+	}
+
+	if err := ReplaceFileFaces(&file, faces); err != nil {
+		t.Fatal(err)
+	}
+
+	// Replacing again must not create duplicates.
+	if err := ReplaceFileFaces(&file, faces); err != nil {
+		t.Fatal(err)
+	}
+
+	var result Faces
+
+	if err := Db().Where("file_id = ?", file.ID).Order("id").Find(&result).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, result, 2)
+	assert.Equal(t, file.PhotoUID, result[0].PhotoUID)
+	assert.Equal(t, FaceSrcImage, result[0].FaceSrc)
+	assert.Equal(t, float32(0.1), result[0].FaceX)
+	assert.Equal(t, face.Embedding{0.6, 0.8}, result[0].Vector())
+}
+
+func TestReplaceFileRegions(t *testing.T) {
+	file := FileFixtures["exampleFileName.jpg"]
+
+	regions := meta.Regions{
+		{Name: "Regina Region", Type: meta.RegionTypeFace, X: 0.1, Y: 0.5, W: 0.1, H: 0.15},
+		{Name: "Regina Region", Type: meta.RegionTypeFace, X: 0.11, Y: 0.5, W: 0.1, H: 0.15},
+		{Name: "Berlin", Type: "Location", X: 0, Y: 0, W: 1, H: 1},
+		{Type: meta.RegionTypeFace, X: 0.7, Y: 0.5, W: 0.1, H: 0.15},
+	}
+
+	count, err := ReplaceFileRegions(&file, regions)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 1, count)
+
+	// Replacing again must not create duplicates.
+	if _, err := ReplaceFileRegions(&file, regions); err != nil {
+		t.Fatal(err)
+	}
+
+	var result Faces
+
+	if err := Db().Where("file_id = ? AND face_src = ?", file.ID, FaceSrcXmp).Find(&result).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, result, 1)
+	assert.Equal(t, "", result[0].Embedding)
+
+	person, err := FirstOrCreateSubject("Regina Region")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, person.PersonUID, result[0].PersonUID)
+}
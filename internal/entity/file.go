@@ -47,6 +47,8 @@ type File struct {
 	FileLuminance   string        `gorm:"type:varbinary(9);" json:"Luminance" yaml:"Luminance,omitempty"`
 	FileDiff        uint32        `json:"Diff" yaml:"Diff,omitempty"`
 	FileChroma      uint8         `json:"Chroma" yaml:"Chroma,omitempty"`
+	FileDHash       string        `gorm:"type:varbinary(16);index;" json:"DHash" yaml:"DHash,omitempty"`
+	FilePHash       string        `gorm:"type:varbinary(16);" json:"PHash" yaml:"PHash,omitempty"`
 	FileNotes       string        `gorm:"type:text" json:"Notes" yaml:"Notes,omitempty"`
 	FileError       string        `gorm:"type:varbinary(512)" json:"Error" yaml:"Error,omitempty"`
 	Share           []FileShare   `json:"-" yaml:"-"`
@@ -136,7 +138,7 @@ func (m *File) AllFilesMissing() bool {
 	count := 0
 
 	if err := Db().Model(&File{}).
-		Where("photo_id = ? AND file_missing = 0", m.PhotoID).
+		Where("photo_id = ? AND file_missing = FALSE", m.PhotoID).
 		Count(&count).Error; err != nil {
 		log.Errorf("file: %s", err.Error())
 	}
@@ -180,7 +182,7 @@ func (m *File) UpdateVideoInfos() error {
 		return err
 	}
 
-	return Db().Model(File{}).Where("photo_id = ? AND file_video = 1", m.PhotoID).Updates(values).Error
+	return Db().Model(File{}).Where("photo_id = ? AND file_video = TRUE", m.PhotoID).Updates(values).Error
 }
 
 // Updates a column in the database.
@@ -19,6 +19,7 @@ type FileSync struct {
 	FileID     uint   `gorm:"index;"`
 	RemoteDate time.Time
 	RemoteSize int64
+	RemoteETag string `gorm:"type:varbinary(255);"`
 	Status     string `gorm:"type:varbinary(16);"`
 	Error      string `gorm:"type:varbinary(512);"`
 	Errors     int
@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"time"
+)
+
+// HighlightDateFormat is the format of highlight dates.
+const HighlightDateFormat = "2006-01-02"
+
+// Highlight represents a photo picked as memory of the day.
+type Highlight struct {
+	HighlightDate string    `gorm:"type:varbinary(10);primary_key;auto_increment:false" json:"Date" yaml:"Date"`
+	PhotoUID      string    `gorm:"type:varbinary(42);primary_key;auto_increment:false" json:"PhotoUID" yaml:"PhotoUID"`
+	YearsAgo      int       `json:"YearsAgo" yaml:"YearsAgo"`
+	CreatedAt     time.Time `json:"CreatedAt" yaml:"-"`
+}
+
+// Highlights represents a list of highlights.
+type Highlights []Highlight
+
+// TableName returns the entity database table name.
+func (Highlight) TableName() string {
+	return "highlights"
+}
+
+// NewHighlight returns a new highlight for the given day.
+func NewHighlight(date time.Time, photoUID string, yearsAgo int) *Highlight {
+	result := &Highlight{
+		HighlightDate: date.Format(HighlightDateFormat),
+		PhotoUID:      photoUID,
+		YearsAgo:      yearsAgo,
+	}
+
+	return result
+}
+
+// Create inserts a new row to the database.
+func (m *Highlight) Create() error {
+	return Db().Create(m).Error
+}
+
+// FindHighlights returns the highlights of a day.
+func FindHighlights(date time.Time) (result Highlights, err error) {
+	err = Db().Where("highlight_date = ?", date.Format(HighlightDateFormat)).
+		Order("years_ago").Find(&result).Error
+
+	return result, err
+}
+
+// DeleteHighlights removes highlights of days before the given date.
+func DeleteHighlights(before time.Time) error {
+	return Db().Where("highlight_date < ?", before.Format(HighlightDateFormat)).Delete(&Highlight{}).Error
+}
@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHighlight(t *testing.T) {
+	m := NewHighlight(time.Date(2020, 10, 17, 8, 0, 0, 0, time.UTC), "pt9jtdre2lvl0yh7", 2)
+
+	assert.Equal(t, "2020-10-17", m.HighlightDate)
+	assert.Equal(t, "pt9jtdre2lvl0yh7", m.PhotoUID)
+	assert.Equal(t, 2, m.YearsAgo)
+}
+
+func TestFindHighlights(t *testing.T) {
+	day := time.Date(2020, 10, 17, 0, 0, 0, 0, time.UTC)
+
+	if err := NewHighlight(day, "pt9jtdre2lvl0y11", 4).Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewHighlight(day, "pt9jtdre2lvl0yh7", 1).Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := FindHighlights(day)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, result, 2)
+	assert.Equal(t, 1, result[0].YearsAgo)
+
+	if err := DeleteHighlights(day.AddDate(0, 0, 1)); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err = FindHighlights(day)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Empty(t, result)
+}
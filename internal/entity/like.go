@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"time"
+)
+
+// Like represents a person liking a photo or album, likes are listed in the activity feed.
+type Like struct {
+	SubjectUID string    `gorm:"type:varbinary(42);primary_key;auto_increment:false" json:"SubjectUID" yaml:"SubjectUID"`
+	PersonUID  string    `gorm:"type:varbinary(42);primary_key;auto_increment:false" json:"PersonUID" yaml:"PersonUID"`
+	PersonName string    `gorm:"type:varchar(255);" json:"PersonName" yaml:"PersonName,omitempty"`
+	CreatedAt  time.Time `gorm:"index;" json:"CreatedAt" yaml:"CreatedAt"`
+}
+
+// AddLike saves a like of a photo or album, existing likes are kept.
+func AddLike(subjectUID string, person Person) error {
+	m := Like{SubjectUID: subjectUID, PersonUID: person.PersonUID, PersonName: person.DisplayName}
+
+	return Db().Where(Like{SubjectUID: subjectUID, PersonUID: person.PersonUID}).FirstOrCreate(&m).Error
+}
+
+// RemoveLike deletes a like of a photo or album.
+func RemoveLike(subjectUID string, person Person) error {
+	return Db().Where("subject_uid = ? AND person_uid = ?", subjectUID, person.PersonUID).Delete(&Like{}).Error
+}
@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddLike(t *testing.T) {
+	if err := AddLike("at9lxuqxpogaaba7", Admin); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := AddLike("at9lxuqxpogaaba7", Admin); err != nil {
+		t.Fatal(err)
+	}
+
+	var count int
+
+	Db().Model(&Like{}).Where("subject_uid = ?", "at9lxuqxpogaaba7").Count(&count)
+	assert.Equal(t, 1, count)
+
+	if err := RemoveLike("at9lxuqxpogaaba7", Admin); err != nil {
+		t.Fatal(err)
+	}
+
+	Db().Model(&Like{}).Where("subject_uid = ?", "at9lxuqxpogaaba7").Count(&count)
+	assert.Equal(t, 0, count)
+}
@@ -25,6 +25,7 @@ type Link struct {
 	HasPassword bool      `json:"HasPassword" yaml:"HasPassword,omitempty"`
 	CanComment  bool      `json:"CanComment" yaml:"CanComment,omitempty"`
 	CanEdit     bool      `json:"CanEdit" yaml:"CanEdit,omitempty"`
+	CanUpload   bool      `json:"CanUpload" yaml:"CanUpload,omitempty"`
 	CreatedAt   time.Time `deepcopier:"skip" json:"CreatedAt" yaml:"CreatedAt"`
 	ModifiedAt  time.Time `deepcopier:"skip" yaml:"ModifiedAt"`
 }
@@ -65,19 +66,25 @@ func (m *Link) Redeem() {
 	}
 }
 
+// Expired returns true if the link can't be redeemed anymore.
 func (m *Link) Expired() bool {
-	if m.MaxViews > 0 && m.LinkViews >= m.MaxViews {
-		return true
-	}
+	return m.Exhausted() || m.Outdated()
+}
+
+// Exhausted returns true if the link has reached its maximum number of views.
+func (m *Link) Exhausted() bool {
+	return m.MaxViews > 0 && m.LinkViews >= m.MaxViews
+}
 
+// Outdated returns true if the link has expired after its lifetime, also for guests who already redeemed it.
+func (m *Link) Outdated() bool {
 	if m.LinkExpires <= 0 {
 		return false
 	}
 
-	now := Timestamp()
 	expires := m.ModifiedAt.Add(Seconds(m.LinkExpires))
 
-	return now.Before(expires)
+	return Timestamp().After(expires)
 }
 
 func (m *Link) SetSlug(s string) {
@@ -186,6 +193,21 @@ func FindValidLinks(token, share string) (result Links) {
 	return result
 }
 
+// FindActiveLinks returns a slice of links for a token that have not been outdated, regardless of their views.
+func FindActiveLinks(token string) (result Links) {
+	if token == "" {
+		return result
+	}
+
+	for _, link := range FindLinks(token, "") {
+		if !link.Outdated() {
+			result = append(result, link)
+		}
+	}
+
+	return result
+}
+
 // String returns an human readable identifier for logging.
 func (m *Link) String() string {
 	return m.LinkUID
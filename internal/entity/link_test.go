@@ -28,13 +28,14 @@ func TestLink_Expired(t *testing.T) {
 
 	link.LinkExpires = oneDay
 
-	assert.False(t, link.Expired())
+	assert.True(t, link.Expired())
+	assert.True(t, link.Outdated())
 
 	link.LinkExpires = oneDay * 8
 
-	assert.True(t, link.Expired())
+	assert.False(t, link.Expired())
+	assert.False(t, link.Outdated())
 
-	link.LinkExpires = oneDay
 	link.LinkViews = 9
 	link.MaxViews = 10
 
@@ -43,6 +44,8 @@ func TestLink_Expired(t *testing.T) {
 	link.Redeem()
 
 	assert.True(t, link.Expired())
+	assert.True(t, link.Exhausted())
+	assert.False(t, link.Outdated())
 }
 
 func TestLink_Redeem(t *testing.T) {
@@ -0,0 +1,218 @@
+package entity
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Migration represents a versioned, reversible database schema or data migration.
+// Versions are numbers like 2020101701 (date and sequence) and must be unique.
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(db *gorm.DB) error
+	Down    func(db *gorm.DB) error
+}
+
+// String returns the migration version and name for logging.
+func (m Migration) String() string {
+	return fmt.Sprintf("%d %s", m.Version, m.Name)
+}
+
+// MigrationVersion represents an applied migration in the schema version table.
+type MigrationVersion struct {
+	Version   int       `gorm:"primary_key;auto_increment:false" json:"Version" yaml:"Version"`
+	Name      string    `gorm:"type:varchar(255);" json:"Name" yaml:"Name"`
+	AppliedAt time.Time `json:"AppliedAt" yaml:"AppliedAt"`
+}
+
+// TableName returns the schema version table name.
+func (MigrationVersion) TableName() string {
+	return "migrations"
+}
+
+// MigrationStatus represents a migration and the time it was applied, if any.
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// Applied returns true if the migration was applied.
+func (m MigrationStatus) Applied() bool {
+	return m.AppliedAt != nil
+}
+
+// MigrationList represents a list of migrations.
+type MigrationList []Migration
+
+// Sorted returns the migrations sorted by version.
+func (list MigrationList) Sorted() MigrationList {
+	result := make(MigrationList, len(list))
+	copy(result, list)
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Version < result[j].Version
+	})
+
+	return result
+}
+
+// applied returns the applied versions from the schema version table.
+func (list MigrationList) applied() (map[int]MigrationVersion, error) {
+	result := make(map[int]MigrationVersion)
+
+	if !UnscopedDb().HasTable(&MigrationVersion{}) {
+		return result, nil
+	}
+
+	var versions []MigrationVersion
+
+	if err := UnscopedDb().Find(&versions).Error; err != nil {
+		return result, err
+	}
+
+	for _, v := range versions {
+		result[v.Version] = v
+	}
+
+	return result, nil
+}
+
+// Status returns all migrations sorted by version and the time they were applied.
+func (list MigrationList) Status() (result []MigrationStatus, err error) {
+	applied, err := list.applied()
+
+	if err != nil {
+		return result, err
+	}
+
+	for _, m := range list.Sorted() {
+		s := MigrationStatus{Migration: m}
+
+		if v, ok := applied[m.Version]; ok {
+			appliedAt := v.AppliedAt
+			s.AppliedAt = &appliedAt
+		}
+
+		result = append(result, s)
+	}
+
+	return result, nil
+}
+
+// Pending returns migrations that were not applied yet, sorted by version.
+func (list MigrationList) Pending() (result MigrationList, err error) {
+	status, err := list.Status()
+
+	if err != nil {
+		return result, err
+	}
+
+	for _, s := range status {
+		if !s.Applied() {
+			result = append(result, s.Migration)
+		}
+	}
+
+	return result, nil
+}
+
+// Up applies pending migrations up to and including the target version, or all if target is 0.
+// In dry-run mode, the migrations that would be applied are returned without changing the database.
+func (list MigrationList) Up(target int, dryRun bool) (result MigrationList, err error) {
+	pending, err := list.Pending()
+
+	if err != nil {
+		return result, err
+	}
+
+	for _, m := range pending {
+		if target > 0 && m.Version > target {
+			break
+		}
+
+		if !dryRun {
+			if err := m.run(m.Up, true); err != nil {
+				return result, fmt.Errorf("migration %s failed: %s", m, err)
+			}
+
+			log.Infof("migrate: applied %s", m)
+		}
+
+		result = append(result, m)
+	}
+
+	return result, nil
+}
+
+// Down reverts the given number of applied migrations, starting with the latest version.
+// In dry-run mode, the migrations that would be reverted are returned without changing the database.
+func (list MigrationList) Down(steps int, dryRun bool) (result MigrationList, err error) {
+	status, err := list.Status()
+
+	if err != nil {
+		return result, err
+	}
+
+	for i := len(status) - 1; i >= 0 && len(result) < steps; i-- {
+		if !status[i].Applied() {
+			continue
+		}
+
+		m := status[i].Migration
+
+		if m.Down == nil {
+			return result, fmt.Errorf("migration %s can't be reverted", m)
+		}
+
+		if !dryRun {
+			if err := m.run(m.Down, false); err != nil {
+				return result, fmt.Errorf("migration %s failed: %s", m, err)
+			}
+
+			log.Infof("migrate: reverted %s", m)
+		}
+
+		result = append(result, m)
+	}
+
+	return result, nil
+}
+
+// run executes a migration step in a transaction and updates the schema version table.
+// Note that MySQL implicitly commits schema changes, so they can't be rolled back.
+func (m Migration) run(step func(db *gorm.DB) error, up bool) error {
+	if step == nil {
+		return fmt.Errorf("missing step")
+	}
+
+	if err := UnscopedDb().AutoMigrate(&MigrationVersion{}).Error; err != nil {
+		return err
+	}
+
+	tx := UnscopedDb().Begin()
+
+	if err := tx.Error; err != nil {
+		return err
+	}
+
+	if err := step(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if up {
+		if err := tx.Create(&MigrationVersion{Version: m.Version, Name: m.Name, AppliedAt: Timestamp()}).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	} else if err := tx.Where("version = ?", m.Version).Delete(&MigrationVersion{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
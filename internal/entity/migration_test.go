@@ -0,0 +1,160 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrationList(t *testing.T) {
+	count := 0
+
+	list := MigrationList{
+		{
+			Version: 1900010102,
+			Name:    "second",
+			Up:      func(db *gorm.DB) error { count += 10; return nil },
+			Down:    func(db *gorm.DB) error { count -= 10; return nil },
+		},
+		{
+			Version: 1900010101,
+			Name:    "first",
+			Up:      func(db *gorm.DB) error { count++; return nil },
+			Down:    func(db *gorm.DB) error { count--; return nil },
+		},
+	}
+
+	t.Run("sorted", func(t *testing.T) {
+		sorted := list.Sorted()
+
+		assert.Equal(t, 1900010101, sorted[0].Version)
+		assert.Equal(t, 1900010102, sorted[1].Version)
+		assert.Equal(t, 1900010102, list[0].Version)
+	})
+	t.Run("dry run", func(t *testing.T) {
+		result, err := list.Up(0, true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 2)
+		assert.Equal(t, 0, count)
+
+		pending, err := list.Pending()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, pending, 2)
+	})
+	t.Run("up to version", func(t *testing.T) {
+		result, err := list.Up(1900010101, false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 1)
+		assert.Equal(t, 1, count)
+
+		status, err := list.Status()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "first", status[0].Name)
+		assert.True(t, status[0].Applied())
+		assert.False(t, status[1].Applied())
+	})
+	t.Run("up", func(t *testing.T) {
+		result, err := list.Up(0, false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 1)
+		assert.Equal(t, "second", result[0].Name)
+		assert.Equal(t, 11, count)
+	})
+	t.Run("down", func(t *testing.T) {
+		result, err := list.Down(1, false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 1)
+		assert.Equal(t, "second", result[0].Name)
+		assert.Equal(t, 1, count)
+	})
+	t.Run("down all", func(t *testing.T) {
+		result, err := list.Down(10, false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 1)
+		assert.Equal(t, 0, count)
+
+		pending, err := list.Pending()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, pending, 2)
+	})
+	t.Run("failed", func(t *testing.T) {
+		failing := MigrationList{{
+			Version: 1900010103,
+			Name:    "failing",
+			Up:      func(db *gorm.DB) error { return errors.New("failed") },
+		}}
+
+		_, err := failing.Up(0, false)
+		assert.Error(t, err)
+
+		pending, err := failing.Pending()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, pending, 1)
+	})
+	t.Run("irreversible", func(t *testing.T) {
+		irreversible := MigrationList{{
+			Version: 1900010104,
+			Name:    "irreversible",
+			Up:      func(db *gorm.DB) error { return nil },
+		}}
+
+		if _, err := irreversible.Up(0, false); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := irreversible.Down(1, false)
+		assert.Error(t, err)
+
+		UnscopedDb().Where("version = ?", 1900010104).Delete(&MigrationVersion{})
+	})
+}
+
+func TestMigrations(t *testing.T) {
+	versions := make(map[int]bool)
+
+	for _, m := range Migrations {
+		assert.NotEmpty(t, m.Name)
+		assert.NotNil(t, m.Up)
+		assert.False(t, versions[m.Version], "duplicate version %d", m.Version)
+
+		versions[m.Version] = true
+	}
+}
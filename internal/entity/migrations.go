@@ -0,0 +1,109 @@
+package entity
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrations contains all versioned database migrations. They are applied in order of their
+// version after the tables were created with AutoMigrate, so steps must not fail if the
+// schema is already up to date.
+var Migrations = MigrationList{
+	{
+		Version: 2020101701,
+		Name:    "add photo quality index",
+		Up: func(db *gorm.DB) error {
+			if db.Dialect().HasIndex("photos", "idx_photos_quality") {
+				return nil
+			}
+
+			return db.Model(&Photo{}).AddIndex("idx_photos_quality", "photo_quality").Error
+		},
+		Down: func(db *gorm.DB) error {
+			if !db.Dialect().HasIndex("photos", "idx_photos_quality") {
+				return nil
+			}
+
+			return db.Model(&Photo{}).RemoveIndex("idx_photos_quality").Error
+		},
+	},
+	{
+		Version: 2020101702,
+		Name:    "update photo quality scores",
+		Up:      updatePhotoQuality,
+		Down: func(db *gorm.DB) error {
+			// Scores are derived from other values and don't need to be restored.
+			return nil
+		},
+	},
+	{
+		Version: 2020101703,
+		Name:    "assign photos and albums without owner to admin",
+		Up:      assignOwnerToAdmin,
+		Down: func(db *gorm.DB) error {
+			// Previously unowned entities can't be told apart anymore.
+			return nil
+		},
+	},
+}
+
+// assignOwnerToAdmin sets the owner of photos and albums created before users were
+// introduced, so that they remain visible only to admins.
+func assignOwnerToAdmin(db *gorm.DB) error {
+	var admin Person
+
+	if err := db.Where("role_admin = TRUE AND person_uid <> ''").Order("id").First(&admin).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	if err := db.Unscoped().Model(&Photo{}).
+		Where("owner_uid = '' OR owner_uid IS NULL").
+		UpdateColumn("owner_uid", admin.PersonUID).Error; err != nil {
+		return err
+	}
+
+	return db.Unscoped().Model(&Album{}).
+		Where("owner_uid = '' OR owner_uid IS NULL").
+		UpdateColumn("owner_uid", admin.PersonUID).Error
+}
+
+// updatePhotoQuality recomputes the quality score of all photos that are not hidden.
+func updatePhotoQuality(db *gorm.DB) error {
+	limit := 1000
+	offset := 0
+
+	for {
+		var photos Photos
+
+		if err := db.Unscoped().Preload("Details").
+			Where("photo_quality >= 0").
+			Order("id").Limit(limit).Offset(offset).
+			Find(&photos).Error; err != nil {
+			return err
+		}
+
+		for _, p := range photos {
+			// Don't create missing details within the migration.
+			if p.Details == nil {
+				p.Details = &Details{PhotoID: p.ID}
+			}
+
+			if score := p.QualityScore(); score != p.PhotoQuality {
+				if err := db.Unscoped().Model(&Photo{}).
+					Where("id = ?", p.ID).
+					UpdateColumn("photo_quality", score).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		if len(photos) < limit {
+			return nil
+		}
+
+		offset += limit
+	}
+}
@@ -2,12 +2,16 @@ package entity
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jinzhu/gorm"
 	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/auth"
+	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/pkg/rnd"
 	"github.com/photoprism/photoprism/pkg/txt"
+	"github.com/ulule/deepcopier"
 )
 
 type People []Person
@@ -41,6 +45,8 @@ type Person struct {
 	BirthYear     int        `json:"BirthYear" yaml:"BirthYear,omitempty"`
 	BirthMonth    int        `json:"BirthMonth" yaml:"BirthMonth,omitempty"`
 	BirthDay      int        `json:"BirthDay" yaml:"BirthDay,omitempty"`
+	AuthProvider  string     `gorm:"type:varbinary(255);index;" json:"AuthProvider" yaml:"AuthProvider,omitempty"`
+	AuthID        string     `gorm:"type:varbinary(255);index;" json:"-" yaml:"AuthID,omitempty"`
 	LoginAttempts int        `json:"-" yaml:"-,omitempty"`
 	LoginAt       *time.Time `json:"-" yaml:"-"`
 	CreatedAt     time.Time  `json:"CreatedAt" yaml:"-"`
@@ -116,6 +122,126 @@ func (m *Person) BeforeCreate(scope *gorm.Scope) error {
 	return scope.SetColumn("PersonUID", rnd.PPID('u'))
 }
 
+// CreatePerson creates a new user account from form data and sets the initial password if provided.
+func CreatePerson(f form.User) (m *Person, err error) {
+	userName := strings.ToLower(strings.TrimSpace(f.UserName))
+
+	if userName == "" || len(userName) > 32 {
+		return nil, fmt.Errorf("user name must be between 1 and 32 characters")
+	}
+
+	existing := Person{}
+
+	if err := Db().Where("user_name = ?", userName).First(&existing).Error; err == nil {
+		return nil, fmt.Errorf("user %s already exists", txt.Quote(userName))
+	}
+
+	m = &Person{UserActive: true}
+
+	if err := deepcopier.Copy(m).From(f); err != nil {
+		return nil, err
+	}
+
+	m.UserName = userName
+
+	// Check the password first, so that no user is created if it is rejected.
+	if f.Password != "" {
+		if err := ValidatePassword(userName, f.Password); err != nil {
+			return nil, err
+		}
+	}
+
+	err = Db().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(m).Error; err != nil {
+			return err
+		} else if f.Password == "" {
+			return nil
+		}
+
+		pw := NewPassword(m.PersonUID, f.Password)
+
+		return tx.Save(&pw).Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// SaveForm updates the user account including roles and capabilities using form data.
+func (m *Person) SaveForm(f form.User) error {
+	userName := m.UserName
+
+	if err := deepcopier.Copy(m).From(f); err != nil {
+		return err
+	}
+
+	// User names can't be changed, they are used for login and WebDAV.
+	m.UserName = userName
+
+	if f.Password != "" {
+		if err := m.SetPassword(f.Password); err != nil {
+			return err
+		}
+	}
+
+	return m.Save()
+}
+
+// SaveProfile updates the user profile using form data, roles and capabilities remain unchanged.
+func (m *Person) SaveProfile(f form.User) error {
+	m.FirstName = txt.Clip(f.FirstName, 32)
+	m.LastName = txt.Clip(f.LastName, 32)
+	m.DisplayName = txt.Clip(f.DisplayName, 64)
+	m.UserEmail = txt.Clip(f.UserEmail, 255)
+	m.UserInfo = f.UserInfo
+
+	return m.Save()
+}
+
+// Delete marks the user account as deleted, the default admin can't be deleted.
+func (m *Person) Delete() error {
+	if m.ID == Admin.ID || m.PersonUID == Admin.PersonUID {
+		return fmt.Errorf("default admin %s can't be deleted", txt.Quote(m.UserName))
+	}
+
+	if !m.Registered() {
+		return fmt.Errorf("only registered users can be deleted")
+	}
+
+	return Db().Delete(m).Error
+}
+
+// SetRole replaces the current role flags with the given role.
+func (m *Person) SetRole(role acl.Role) error {
+	m.RoleAdmin = false
+	m.RoleFamily = false
+	m.RoleFriend = false
+	m.RoleChild = false
+	m.RoleGuest = false
+
+	switch role {
+	case acl.RoleAdmin:
+		m.RoleAdmin = true
+	case acl.RoleFamily:
+		m.RoleFamily = true
+	case acl.RoleFriend:
+		m.RoleFriend = true
+	case acl.RoleChild:
+		m.RoleChild = true
+	case acl.RoleGuest:
+		m.RoleGuest = true
+	case acl.RoleDefault, "":
+		return nil
+	default:
+		return fmt.Errorf("unknown role %s", txt.Quote(string(role)))
+	}
+
+	return nil
+}
+
 // FirstOrCreatePerson returns an existing row, inserts a new row or nil in case of errors.
 func FirstOrCreatePerson(m *Person) *Person {
 	result := Person{}
@@ -130,6 +256,30 @@ func FirstOrCreatePerson(m *Person) *Person {
 	return m
 }
 
+// FirstOrCreateSubject returns the person shown in photos with the given name, a new person is
+// created if none exists.
+func FirstOrCreateSubject(name string) (*Person, error) {
+	name = txt.Clip(strings.TrimSpace(name), 64)
+
+	if name == "" {
+		return nil, fmt.Errorf("name must not be empty")
+	}
+
+	result := Person{}
+
+	if err := Db().Where("is_subject = TRUE AND display_name = ?", name).First(&result).Error; err == nil {
+		return &result, nil
+	}
+
+	m := &Person{DisplayName: name, IsSubject: true}
+
+	if err := m.Create(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
 // FindPersonByUserName returns an existing user or nil if not found.
 func FindPersonByUserName(userName string) *Person {
 	if userName == "" {
@@ -180,6 +330,11 @@ func (m *Person) Registered() bool {
 	return m.UserName != "" && rnd.IsPPID(m.PersonUID, 'u')
 }
 
+// Disabled returns true if the user account has been deactivated and may not log in.
+func (m *Person) Disabled() bool {
+	return m.Registered() && !m.UserActive
+}
+
 // Admin returns true if the person is an admin with user name.
 func (m *Person) Admin() bool {
 	return m.Registered() && m.RoleAdmin
@@ -201,8 +356,8 @@ func (m *Person) SetPassword(password string) error {
 		return fmt.Errorf("only registered users can change their password")
 	}
 
-	if len(password) < 6 {
-		return fmt.Errorf("new password for %s must be at least 6 characters", txt.Quote(m.UserName))
+	if err := ValidatePassword(m.UserName, password); err != nil {
+		return err
 	}
 
 	pw := NewPassword(m.PersonUID, password)
@@ -210,6 +365,15 @@ func (m *Person) SetPassword(password string) error {
 	return pw.Save()
 }
 
+// ValidatePassword returns an error if the password of a user is too short.
+func ValidatePassword(userName, password string) error {
+	if len(password) < 6 {
+		return fmt.Errorf("new password for %s must be at least 6 characters", txt.Quote(userName))
+	}
+
+	return nil
+}
+
 // InitPassword sets the initial user password stored as hash.
 func (m *Person) InitPassword(password string) {
 	if !m.Registered() {
@@ -254,6 +418,50 @@ func (m *Person) InvalidPassword(password string) bool {
 	return pw.InvalidPassword(password)
 }
 
+// LoginWait returns how long the user must wait before the next login attempt.
+func (m *Person) LoginWait() time.Duration {
+	if m.LoginAt == nil {
+		return 0
+	}
+
+	return auth.Wait(m.LoginAttempts, auth.AccountAttempts, *m.LoginAt)
+}
+
+// LoginFailed increments the number of failed login attempts and returns it.
+func (m *Person) LoginFailed() int {
+	now := Timestamp()
+
+	m.LoginAttempts++
+	m.LoginAt = &now
+
+	if err := m.updateLogin(); err != nil {
+		log.Errorf("person: %s", err)
+	}
+
+	return m.LoginAttempts
+}
+
+// LoginSucceeded resets the number of failed login attempts.
+func (m *Person) LoginSucceeded() {
+	now := Timestamp()
+
+	m.LoginAttempts = 0
+	m.LoginAt = &now
+
+	if err := m.updateLogin(); err != nil {
+		log.Errorf("person: %s", err)
+	}
+}
+
+// updateLogin saves the login attempts and time without changing other columns.
+func (m *Person) updateLogin() error {
+	if !m.Registered() {
+		return nil
+	}
+
+	return Db().Model(m).UpdateColumns(map[string]interface{}{"login_attempts": m.LoginAttempts, "login_at": m.LoginAt}).Error
+}
+
 // Role returns the user role for ACL permission checks.
 func (m *Person) Role() acl.Role {
 	if m.RoleAdmin {
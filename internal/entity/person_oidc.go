@@ -0,0 +1,143 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/oidc"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// OIDCPerson returns the user account for verified single sign-on claims. Existing accounts are
+// found by issuer and subject or linked by verified email, otherwise a new account is created.
+// The role is updated on every login so that group changes at the identity provider take effect.
+func OIDCPerson(claims *oidc.Claims, role acl.Role) (*Person, error) {
+	if claims == nil || claims.Issuer == "" || claims.Subject == "" {
+		return nil, fmt.Errorf("oidc: missing issuer or subject")
+	}
+
+	if role == "" || role == acl.RoleDefault {
+		return nil, fmt.Errorf("oidc: no role for %s", txt.Quote(claims.UserName()))
+	}
+
+	m := FindPersonByAuthID(claims.Issuer, claims.Subject)
+
+	if m == nil && claims.EmailVerified && claims.Email != "" {
+		if m = FindPersonByEmail(claims.Email); m != nil {
+			if m.AuthID != "" {
+				return nil, fmt.Errorf("oidc: %s is already linked to another account", txt.Quote(m.UserName))
+			}
+
+			log.Infof("oidc: linking existing user %s", txt.Quote(m.UserName))
+
+			m.AuthProvider = claims.Issuer
+			m.AuthID = claims.Subject
+		}
+	}
+
+	if m == nil {
+		f := form.User{
+			UserName:    uniqueUserName(claims.UserName()),
+			FirstName:   txt.Clip(claims.GivenName, 32),
+			LastName:    txt.Clip(claims.FamilyName, 32),
+			DisplayName: txt.Clip(claims.Name, 64),
+			UserActive:  true,
+		}
+
+		if claims.EmailVerified {
+			f.UserEmail = txt.Clip(claims.Email, 255)
+		}
+
+		created, err := CreatePerson(f)
+
+		if err != nil {
+			return nil, err
+		}
+
+		log.Infof("oidc: created user %s", txt.Quote(created.UserName))
+
+		m = created
+		m.AuthProvider = claims.Issuer
+		m.AuthID = claims.Subject
+	}
+
+	if !m.UserActive {
+		return nil, fmt.Errorf("oidc: user %s is disabled", txt.Quote(m.UserName))
+	}
+
+	// Never change the role of the default admin, so that it can't be locked out.
+	if m.ID != Admin.ID {
+		if err := m.SetRole(role); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := m.Save(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// FindPersonByAuthID returns the user linked to an external account or nil if not found.
+func FindPersonByAuthID(provider, id string) *Person {
+	if provider == "" || id == "" {
+		return nil
+	}
+
+	result := Person{}
+
+	if err := Db().Where("auth_provider = ? AND auth_id = ?", provider, id).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// FindPersonByEmail returns the registered user with the email address or nil if not found.
+func FindPersonByEmail(email string) *Person {
+	if email == "" {
+		return nil
+	}
+
+	result := Person{}
+
+	if err := Db().Where("user_email = ? AND user_name <> ''", email).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// uniqueUserName returns a valid user name that is not in use yet.
+func uniqueUserName(s string) string {
+	var b strings.Builder
+
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '.' || r == '_' || r == '-' {
+			b.WriteRune(r)
+		}
+	}
+
+	base := txt.Clip(b.String(), 28)
+
+	if base == "" {
+		base = "user"
+	}
+
+	userName := base
+
+	for i := 2; i < 1000; i++ {
+		existing := Person{}
+
+		if err := Db().Unscoped().Where("user_name = ?", userName).First(&existing).Error; err != nil {
+			return userName
+		}
+
+		userName = fmt.Sprintf("%s-%d", base, i)
+	}
+
+	return userName
+}
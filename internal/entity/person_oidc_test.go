@@ -0,0 +1,95 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/oidc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOIDCPerson(t *testing.T) {
+	t.Run("create", func(t *testing.T) {
+		claims := &oidc.Claims{
+			Issuer:            "https://id.example.com",
+			Subject:           "oidc-create",
+			PreferredUsername: "Oidc Create",
+			Email:             "oidc-create@example.com",
+			EmailVerified:     true,
+			Name:              "OIDC Create",
+		}
+
+		m, err := OIDCPerson(claims, acl.RoleFamily)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "oidccreate", m.UserName)
+		assert.Equal(t, "oidc-create@example.com", m.UserEmail)
+		assert.Equal(t, "OIDC Create", m.DisplayName)
+		assert.True(t, m.RoleFamily)
+
+		// Roles follow the group claims on every login.
+		again, err := OIDCPerson(claims, acl.RoleFriend)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, m.PersonUID, again.PersonUID)
+		assert.False(t, again.RoleFamily)
+		assert.True(t, again.RoleFriend)
+	})
+	t.Run("unique user name", func(t *testing.T) {
+		m, err := OIDCPerson(&oidc.Claims{Issuer: "https://id.example.com", Subject: "oidc-admin", PreferredUsername: "admin"}, acl.RoleGuest)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "admin-2", m.UserName)
+		assert.True(t, m.RoleGuest)
+		assert.False(t, m.RoleAdmin)
+	})
+	t.Run("link by verified email", func(t *testing.T) {
+		existing, err := CreatePerson(form.User{UserName: "oidc-link", UserEmail: "oidc-link@example.com"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		m, err := OIDCPerson(&oidc.Claims{Issuer: "https://id.example.com", Subject: "oidc-link", Email: "oidc-link@example.com", EmailVerified: true}, acl.RoleChild)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, existing.PersonUID, m.PersonUID)
+		assert.Equal(t, "https://id.example.com", m.AuthProvider)
+		assert.Equal(t, "oidc-link", m.AuthID)
+		assert.True(t, m.RoleChild)
+	})
+	t.Run("unverified email", func(t *testing.T) {
+		existing, err := CreatePerson(form.User{UserName: "oidc-unverified", UserEmail: "oidc-unverified@example.com"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		m, err := OIDCPerson(&oidc.Claims{Issuer: "https://id.example.com", Subject: "oidc-unverified", Email: "oidc-unverified@example.com"}, acl.RoleChild)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEqual(t, existing.PersonUID, m.PersonUID)
+		assert.Equal(t, "", m.UserEmail)
+	})
+	t.Run("no role", func(t *testing.T) {
+		_, err := OIDCPerson(&oidc.Claims{Issuer: "https://id.example.com", Subject: "oidc-norole"}, "")
+
+		assert.Error(t, err)
+	})
+}
@@ -2,7 +2,11 @@ package entity
 
 import (
 	"testing"
+	"time"
 
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/auth"
+	"github.com/photoprism/photoprism/internal/form"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -42,3 +46,153 @@ func TestPerson_InvalidPassword(t *testing.T) {
 		assert.False(t, m.InvalidPassword("photoprism"))
 	})
 }
+
+func TestCreatePerson(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		m, err := CreatePerson(form.User{UserName: "Create-Test", DisplayName: "Create Test", RoleFamily: true, Password: "photoprism"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "create-test", m.UserName)
+		assert.True(t, m.Registered())
+		assert.True(t, m.UserActive)
+		assert.Equal(t, acl.RoleFamily, m.Role())
+		assert.False(t, m.InvalidPassword("photoprism"))
+	})
+	t.Run("already exists", func(t *testing.T) {
+		_, err := CreatePerson(form.User{UserName: "admin"})
+
+		assert.Error(t, err)
+	})
+	t.Run("empty user name", func(t *testing.T) {
+		_, err := CreatePerson(form.User{UserName: " "})
+
+		assert.Error(t, err)
+	})
+	t.Run("password too short", func(t *testing.T) {
+		m, err := CreatePerson(form.User{UserName: "short-password", Password: "abc"})
+
+		assert.Error(t, err)
+		assert.Nil(t, m)
+		assert.Nil(t, FindPersonByUserName("short-password"))
+	})
+}
+
+func TestPerson_SaveForm(t *testing.T) {
+	m, err := CreatePerson(form.User{UserName: "save-form", RoleFriend: true})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := form.NewUser(m)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f.UserName = "changed"
+	f.DisplayName = "Saved"
+	f.RoleFriend = false
+	f.RoleChild = true
+
+	if err := m.SaveForm(f); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "save-form", m.UserName)
+	assert.Equal(t, "Saved", m.DisplayName)
+	assert.Equal(t, acl.RoleChild, m.Role())
+}
+
+func TestPerson_SaveProfile(t *testing.T) {
+	m, err := CreatePerson(form.User{UserName: "save-profile", RoleGuest: true})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.SaveProfile(form.User{DisplayName: "Profile", RoleAdmin: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "Profile", m.DisplayName)
+	assert.False(t, m.RoleAdmin)
+	assert.Equal(t, acl.RoleGuest, m.Role())
+}
+
+func TestPerson_Delete(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		m, err := CreatePerson(form.User{UserName: "delete-me"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := m.Delete(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Nil(t, FindPersonByUID(m.PersonUID))
+	})
+	t.Run("admin", func(t *testing.T) {
+		assert.Error(t, Admin.Delete())
+	})
+}
+
+func TestPerson_SetRole(t *testing.T) {
+	m := Person{RoleAdmin: true}
+
+	if err := m.SetRole(acl.RoleFriend); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.False(t, m.RoleAdmin)
+	assert.Equal(t, acl.RoleFriend, m.Role())
+	assert.Error(t, m.SetRole("superuser"))
+}
+
+func TestPerson_LoginFailed(t *testing.T) {
+	m, err := CreatePerson(form.User{UserName: "login-failed", Password: "photoprism"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, time.Duration(0), m.LoginWait())
+
+	for i := 1; i <= auth.AccountAttempts; i++ {
+		assert.Equal(t, i, m.LoginFailed())
+	}
+
+	assert.True(t, m.LoginWait() > 0)
+
+	if found := FindPersonByUserName("login-failed"); found == nil {
+		t.Fatal("user should exist")
+	} else {
+		assert.Equal(t, auth.AccountAttempts, found.LoginAttempts)
+		assert.True(t, found.LoginWait() > 0)
+	}
+
+	m.LoginSucceeded()
+
+	assert.Equal(t, 0, m.LoginAttempts)
+	assert.Equal(t, time.Duration(0), m.LoginWait())
+}
+
+func TestPerson_Disabled(t *testing.T) {
+	t.Run("active", func(t *testing.T) {
+		p := Person{PersonUID: "uqxetse3cy5eo9z2", UserName: "active", UserActive: true}
+		assert.False(t, p.Disabled())
+	})
+	t.Run("inactive", func(t *testing.T) {
+		p := Person{PersonUID: "uqxetse3cy5eo9z2", UserName: "inactive", UserActive: false}
+		assert.True(t, p.Disabled())
+	})
+	t.Run("guest", func(t *testing.T) {
+		assert.False(t, Guest.Disabled())
+		assert.False(t, UnknownPerson.Disabled())
+	})
+}
@@ -38,6 +38,8 @@ type Photo struct {
 	TakenAtLocal     time.Time    `gorm:"type:datetime;" yaml:"-"`
 	TakenSrc         string       `gorm:"type:varbinary(8);" json:"TakenSrc" yaml:"TakenSrc,omitempty"`
 	PhotoUID         string       `gorm:"type:varbinary(42);unique_index;index:idx_photos_taken_uid;" json:"UID" yaml:"UID"`
+	OwnerUID         string       `gorm:"type:varbinary(42);index;" json:"OwnerUID" yaml:"OwnerUID,omitempty"`
+	LinkUID          string       `gorm:"type:varbinary(42);index;" json:"-" yaml:"LinkUID,omitempty"`
 	PhotoType        string       `gorm:"type:varbinary(8);default:'image';" json:"Type" yaml:"Type"`
 	PhotoTitle       string       `gorm:"type:varchar(255);" json:"Title" yaml:"Title"`
 	TitleSrc         string       `gorm:"type:varbinary(8);" json:"TitleSrc" yaml:"TitleSrc,omitempty"`
@@ -191,6 +193,8 @@ func (m *Photo) Create() error {
 		return err
 	}
 
+	m.QueueFullText()
+
 	return nil
 }
 
@@ -210,6 +214,8 @@ func (m *Photo) Save() error {
 		return err
 	}
 
+	m.QueueFullText()
+
 	return nil
 }
 
@@ -870,7 +876,7 @@ func (m *Photo) AllFilesMissing() bool {
 	count := 0
 
 	if err := Db().Model(&File{}).
-		Where("photo_id = ? AND b.file_missing = 0", m.ID).
+		Where("photo_id = ? AND b.file_missing = FALSE", m.ID).
 		Count(&count).Error; err != nil {
 		log.Error(err)
 	}
@@ -896,6 +902,10 @@ func (m *Photo) DeletePermanently() error {
 	Db().Unscoped().Delete(PhotoLabel{}, "photo_id = ?", m.ID)
 	Db().Unscoped().Delete(PhotoAlbum{}, "photo_uid = ?", m.PhotoUID)
 
+	if err := m.RemoveFullText(); err != nil {
+		log.Errorf("photo: %s (remove %s from search index)", err, m.PhotoUID)
+	}
+
 	return Db().Unscoped().Delete(m).Error
 }
 
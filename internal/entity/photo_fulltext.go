@@ -0,0 +1,383 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/maps"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// FullTextTable is the name of the full-text search index table.
+const FullTextTable = "photos_fulltext"
+
+// FullTextDelay is the time photo changes are collected before the search index is updated in a batch.
+var FullTextDelay = 3 * time.Second
+
+var fullTextEnabled = false
+var fullTextLang = "en"
+var fullTextQueue = make(map[uint]bool)
+var fullTextMutex = sync.Mutex{}
+
+// FullTextEnabled returns true if the full-text search index is available.
+func FullTextEnabled() bool {
+	return fullTextEnabled
+}
+
+// FullTextLang returns the language used for stemming indexed words and search terms.
+func FullTextLang() string {
+	return fullTextLang
+}
+
+// SetFullTextLang changes the stemming language, see txt.StemLang for supported languages.
+// The index must be rebuilt if the language was changed after photos have been indexed.
+func SetFullTextLang(lang string) (changed bool) {
+	lang = txt.StemLang(lang)
+
+	if lang == fullTextLang {
+		return false
+	}
+
+	fullTextLang = lang
+
+	return true
+}
+
+// MigrateFullText creates the full-text search index table if it does not exist yet.
+// SQLite requires the FTS5 extension, which can be enabled with the sqlite_fts5 build tag.
+// PostgreSQL uses a GIN index with the "simple" configuration, as words are already stemmed.
+func MigrateFullText() error {
+	var created bool
+
+	switch DbDialect() {
+	case MySQL:
+		created = !Db().HasTable(FullTextTable)
+
+		if err := Db().Exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (photo_id INT UNSIGNED NOT NULL PRIMARY KEY, "+
+			"search_text TEXT NOT NULL, FULLTEXT INDEX idx_photos_fulltext (search_text)) "+
+			"ENGINE=InnoDB DEFAULT CHARSET=utf8mb4", FullTextTable)).Error; err != nil {
+			fullTextEnabled = false
+			return err
+		}
+	case SQLite:
+		created = !Db().HasTable(FullTextTable)
+
+		if err := Db().Exec(fmt.Sprintf("CREATE VIRTUAL TABLE IF NOT EXISTS %s "+
+			"USING fts5(search_text, tokenize = 'unicode61 remove_diacritics 2')", FullTextTable)).Error; err != nil {
+			fullTextEnabled = false
+			return err
+		}
+	case Postgres:
+		created = !Db().HasTable(FullTextTable)
+
+		if err := Db().Exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (photo_id BIGINT NOT NULL PRIMARY KEY, "+
+			"search_text TEXT NOT NULL)", FullTextTable)).Error; err != nil {
+			fullTextEnabled = false
+			return err
+		}
+
+		if err := Db().Exec(fmt.Sprintf("CREATE INDEX IF NOT EXISTS idx_photos_fulltext ON %s "+
+			"USING GIN (to_tsvector('simple', search_text))", FullTextTable)).Error; err != nil {
+			fullTextEnabled = false
+			return err
+		}
+	default:
+		fullTextEnabled = false
+		return fmt.Errorf("full-text search not supported for %s", DbDialect())
+	}
+
+	fullTextEnabled = true
+
+	if created {
+		if count, err := RebuildFullText(); err != nil {
+			return err
+		} else if count > 0 {
+			log.Infof("fulltext: indexed %d photos", count)
+		}
+	}
+
+	return nil
+}
+
+// RebuildFullText re-creates the full-text search index for all photos, including archived photos.
+func RebuildFullText() (count int, err error) {
+	if !fullTextEnabled {
+		return 0, fmt.Errorf("fulltext: index not available")
+	}
+
+	if err := Db().Exec(fmt.Sprintf("DELETE FROM %s", FullTextTable)).Error; err != nil {
+		return 0, err
+	}
+
+	limit := 1000
+	offset := 0
+
+	for {
+		var photos Photos
+
+		if err := UnscopedDb().Preload("Details").Order("id").Limit(limit).Offset(offset).Find(&photos).Error; err != nil {
+			return count, err
+		}
+
+		n, err := indexFullText(photos)
+		count += n
+
+		if err != nil {
+			return count, err
+		}
+
+		if len(photos) < limit {
+			break
+		}
+
+		offset += limit
+	}
+
+	return count, nil
+}
+
+// QueueFullText schedules an update of the full-text search index for this photo. Changes are
+// collected for FullTextDelay, so that labels and places are looked up once per batch while indexing.
+func (m *Photo) QueueFullText() {
+	if !fullTextEnabled || !m.HasID() {
+		return
+	}
+
+	fullTextMutex.Lock()
+	defer fullTextMutex.Unlock()
+
+	if len(fullTextQueue) == 0 {
+		time.AfterFunc(FullTextDelay, func() {
+			if _, err := FlushFullText(); err != nil {
+				log.Errorf("fulltext: %s (update index)", err)
+			}
+		})
+	}
+
+	fullTextQueue[m.ID] = true
+}
+
+// FlushFullText updates the full-text search index for all queued photos.
+func FlushFullText() (count int, err error) {
+	fullTextMutex.Lock()
+	queue := fullTextQueue
+	fullTextQueue = make(map[uint]bool)
+	fullTextMutex.Unlock()
+
+	if len(queue) == 0 || !fullTextEnabled {
+		return 0, nil
+	}
+
+	ids := make([]uint, 0, len(queue))
+
+	for id := range queue {
+		ids = append(ids, id)
+	}
+
+	limit := 1000
+
+	for offset := 0; offset < len(ids); offset += limit {
+		end := offset + limit
+
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		var photos Photos
+
+		if err := UnscopedDb().Preload("Details").Where("id IN (?)", ids[offset:end]).Find(&photos).Error; err != nil {
+			return count, err
+		}
+
+		n, err := indexFullText(photos)
+		count += n
+
+		if err != nil {
+			return count, err
+		}
+	}
+
+	return count, nil
+}
+
+// indexFullText updates the full-text search index for a batch of photos. Labels and places
+// are looked up with one query each.
+func indexFullText(photos Photos) (count int, err error) {
+	if len(photos) == 0 {
+		return 0, nil
+	}
+
+	ids := make([]uint, len(photos))
+	placeIDs := make([]string, 0, len(photos))
+
+	for i, p := range photos {
+		ids[i] = p.ID
+
+		if p.PlaceID != "" && p.PlaceID != UnknownPlace.ID {
+			placeIDs = append(placeIDs, p.PlaceID)
+		}
+	}
+
+	var labels []struct {
+		PhotoID   uint
+		LabelName string
+	}
+
+	if err := Db().Table("labels").Select("pl.photo_id, labels.label_name").
+		Joins("JOIN photos_labels pl ON pl.label_id = labels.id").
+		Where("pl.photo_id IN (?) AND pl.uncertainty < 100 AND labels.deleted_at IS NULL", ids).
+		Scan(&labels).Error; err != nil {
+		return 0, err
+	}
+
+	labelNames := make(map[uint][]string, len(photos))
+
+	for _, l := range labels {
+		labelNames[l.PhotoID] = append(labelNames[l.PhotoID], l.LabelName)
+	}
+
+	places := make(map[string]*Place, len(placeIDs))
+
+	if len(placeIDs) > 0 {
+		var results []Place
+
+		if err := Db().Where("id IN (?)", placeIDs).Find(&results).Error; err != nil {
+			return 0, err
+		}
+
+		for i := range results {
+			places[results[i].ID] = &results[i]
+		}
+	}
+
+	for _, p := range photos {
+		if err := p.saveFullText(p.fullText(labelNames[p.ID], places[p.PlaceID])); err != nil {
+			log.Errorf("fulltext: %s (index %s)", err, p.PhotoUID)
+			continue
+		}
+
+		count++
+	}
+
+	return count, nil
+}
+
+// FullText returns the stemmed words of titles, descriptions, details, labels
+// and place names for indexing.
+func (m *Photo) FullText() string {
+	var labels []string
+
+	if err := Db().Table("labels").Joins("JOIN photos_labels pl ON pl.label_id = labels.id").
+		Where("pl.photo_id = ? AND pl.uncertainty < 100 AND labels.deleted_at IS NULL", m.ID).
+		Pluck("labels.label_name", &labels).Error; err != nil {
+		log.Errorf("fulltext: %s (find labels of %s)", err, m.PhotoUID)
+	}
+
+	var place *Place
+
+	if m.PlaceID != "" && m.PlaceID != UnknownPlace.ID {
+		result := Place{}
+
+		if err := Db().Where("id = ?", m.PlaceID).First(&result).Error; err == nil {
+			place = &result
+		}
+	}
+
+	return m.fullText(labels, place)
+}
+
+// fullText returns the stemmed words for indexing based on the given label names and place.
+func (m *Photo) fullText(labels []string, place *Place) string {
+	var words []string
+
+	lang := fullTextLang
+	details := m.GetDetails()
+
+	words = append(words, txt.StemKeywords(m.PhotoTitle, lang)...)
+	words = append(words, txt.StemKeywords(m.PhotoDescription, lang)...)
+	words = append(words, txt.StemKeywords(details.Keywords, lang)...)
+	words = append(words, txt.StemKeywords(details.Subject, lang)...)
+	words = append(words, txt.StemKeywords(details.Artist, lang)...)
+	words = append(words, txt.StemKeywords(details.Notes, lang)...)
+
+	for _, l := range labels {
+		words = append(words, txt.StemKeywords(l, lang)...)
+	}
+
+	if place != nil {
+		words = append(words, txt.StemKeywords(place.LocLabel, lang)...)
+		words = append(words, txt.StemKeywords(place.LocCity, lang)...)
+		words = append(words, txt.StemKeywords(place.LocState, lang)...)
+	}
+
+	if name, ok := maps.CountryNames[m.PhotoCountry]; ok && m.PhotoCountry != UnknownCountry.ID {
+		words = append(words, txt.StemKeywords(name, lang)...)
+	}
+
+	return strings.Join(txt.UniqueWords(words), " ")
+}
+
+// IndexFullText updates the full-text search index for this photo.
+func (m *Photo) IndexFullText() error {
+	if !fullTextEnabled || !m.HasID() {
+		return nil
+	}
+
+	return m.saveFullText(m.FullText())
+}
+
+// saveFullText writes the indexed text of this photo.
+func (m *Photo) saveFullText(text string) error {
+	switch DbDialect() {
+	case MySQL:
+		return Db().Exec(fmt.Sprintf("REPLACE INTO %s (photo_id, search_text) VALUES (?, ?)", FullTextTable), m.ID, text).Error
+	case SQLite:
+		if err := m.RemoveFullText(); err != nil {
+			return err
+		}
+
+		return Db().Exec(fmt.Sprintf("INSERT INTO %s (rowid, search_text) VALUES (?, ?)", FullTextTable), m.ID, text).Error
+	case Postgres:
+		return Db().Exec(fmt.Sprintf("INSERT INTO %s (photo_id, search_text) VALUES (?, ?) "+
+			"ON CONFLICT (photo_id) DO UPDATE SET search_text = EXCLUDED.search_text", FullTextTable), m.ID, text).Error
+	}
+
+	return nil
+}
+
+// RemoveFullText removes this photo from the full-text search index.
+func (m *Photo) RemoveFullText() error {
+	if !fullTextEnabled || !m.HasID() {
+		return nil
+	}
+
+	switch DbDialect() {
+	case MySQL, Postgres:
+		return Db().Exec(fmt.Sprintf("DELETE FROM %s WHERE photo_id = ?", FullTextTable), m.ID).Error
+	case SQLite:
+		return Db().Exec(fmt.Sprintf("DELETE FROM %s WHERE rowid = ?", FullTextTable), m.ID).Error
+	}
+
+	return nil
+}
+
+// IndexLabelFullText updates the full-text search index for all photos with a label, e.g. after it was renamed.
+func IndexLabelFullText(labelID uint) error {
+	if !fullTextEnabled {
+		return nil
+	}
+
+	var photos Photos
+
+	if err := UnscopedDb().Preload("Details").
+		Where("id IN (SELECT photo_id FROM photos_labels WHERE label_id = ? AND uncertainty < 100)", labelID).
+		Find(&photos).Error; err != nil {
+		return err
+	}
+
+	_, err := indexFullText(photos)
+
+	return err
+}
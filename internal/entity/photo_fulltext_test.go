@@ -0,0 +1,88 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhoto_FullText(t *testing.T) {
+	t.Run("details", func(t *testing.T) {
+		m := PhotoFixtures.Get("19800101_000002_D640C559")
+		text := m.FullText()
+
+		assert.Contains(t, text, "lake")
+		assert.Contains(t, text, "frog")
+		assert.Contains(t, text, "natur")
+		assert.Contains(t, text, "han")
+	})
+}
+
+func TestPhoto_IndexFullText(t *testing.T) {
+	if !FullTextEnabled() {
+		t.Skip("full-text search index not available")
+	}
+
+	m := PhotoFixtures.Get("19800101_000002_D640C559")
+
+	if err := m.IndexFullText(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.RemoveFullText(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.IndexFullText(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRebuildFullText(t *testing.T) {
+	if !FullTextEnabled() {
+		t.Skip("full-text search index not available")
+	}
+
+	count, err := RebuildFullText()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.GreaterOrEqual(t, count, len(PhotoFixtures))
+}
+
+func TestSetFullTextLang(t *testing.T) {
+	defer SetFullTextLang("en")
+
+	assert.False(t, SetFullTextLang("en"))
+	assert.True(t, SetFullTextLang("de_DE"))
+	assert.Equal(t, "de", FullTextLang())
+	assert.True(t, SetFullTextLang("fr"))
+	assert.Equal(t, "", FullTextLang())
+}
+
+func TestFlushFullText(t *testing.T) {
+	if !FullTextEnabled() {
+		t.Skip("full-text search index not available")
+	}
+
+	m := PhotoFixtures.Get("19800101_000002_D640C559")
+	m.QueueFullText()
+	m.QueueFullText()
+
+	count, err := FlushFullText()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Queued photos are only indexed once.
+	assert.LessOrEqual(t, count, 1)
+
+	if count, err = FlushFullText(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 0, count)
+}
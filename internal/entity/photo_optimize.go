@@ -61,6 +61,8 @@ func (m *Photo) EstimatePlace() {
 		dateExpr = "ABS(DATEDIFF(taken_at, ?)) ASC"
 	case SQLite:
 		dateExpr = "ABS(JulianDay(taken_at) - JulianDay(?)) ASC"
+	case Postgres:
+		dateExpr = "ABS(EXTRACT(EPOCH FROM (taken_at - ?))) ASC"
 	default:
 		log.Errorf("photo: unknown sql dialect %s", DbDialect())
 		return
@@ -65,5 +65,10 @@ func (m *Photo) QualityScore() (score int) {
 		score = 3
 	}
 
+	// Photos uploaded by guests remain in review until they are approved.
+	if score >= 3 && m.EditedAt == nil && m.LinkUID != "" {
+		score = 2
+	}
+
 	return score
 }
@@ -21,4 +21,14 @@ func TestPhoto_QualityScore(t *testing.T) {
 	t.Run("PhotoFixturePhoto15 - description with blacklist", func(t *testing.T) {
 		assert.Equal(t, 2, PhotoFixtures.Pointer("Photo15").QualityScore())
 	})
+	t.Run("uploaded by guest", func(t *testing.T) {
+		photo := *PhotoFixtures.Pointer("Photo06")
+		photo.LinkUID = "s000000000000001"
+		photo.EditedAt = nil
+		assert.Equal(t, 2, photo.QualityScore())
+
+		edited := Timestamp()
+		photo.EditedAt = &edited
+		assert.Equal(t, 4, photo.QualityScore())
+	})
 }
@@ -0,0 +1,126 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+type Sessions []Session
+
+// Session represents a persistent user session, the secret session id is only stored as hash.
+type Session struct {
+	ID         string    `gorm:"type:varbinary(64);primary_key;auto_increment:false;" json:"-" yaml:"-"`
+	SessionUID string    `gorm:"type:varbinary(42);unique_index;" json:"UID" yaml:"UID"`
+	UserUID    string    `gorm:"type:varbinary(42);index;" json:"UserUID" yaml:"UserUID"`
+	UserAgent  string    `gorm:"type:varchar(512);" json:"UserAgent" yaml:"UserAgent,omitempty"`
+	ClientIP   string    `gorm:"type:varbinary(64);" json:"ClientIP" yaml:"ClientIP,omitempty"`
+	Tokens     string    `gorm:"type:text;" json:"-" yaml:"-"`
+	Shares     string    `gorm:"type:text;" json:"-" yaml:"-"`
+	CreatedAt  time.Time `json:"CreatedAt" yaml:"CreatedAt"`
+	ActiveAt   time.Time `json:"ActiveAt" yaml:"ActiveAt"`
+	ExpiresAt  time.Time `gorm:"index;" json:"ExpiresAt" yaml:"ExpiresAt"`
+	Current    bool      `gorm:"-" json:"Current" yaml:"-"`
+}
+
+// SessionHash returns the hash under which a secret session id is stored.
+func SessionHash(id string) string {
+	h := sha256.Sum256([]byte(id))
+
+	return hex.EncodeToString(h[:])
+}
+
+// NewSession creates a new session entity for the secret session id.
+func NewSession(id, userUID string, expires time.Duration) Session {
+	now := Timestamp()
+
+	return Session{
+		ID:         SessionHash(id),
+		SessionUID: rnd.PPID('x'),
+		UserUID:    userUID,
+		CreatedAt:  now,
+		ActiveAt:   now,
+		ExpiresAt:  now.Add(expires),
+	}
+}
+
+// BeforeCreate creates a random UID if needed before inserting a new row to the database.
+func (m *Session) BeforeCreate(scope *gorm.Scope) error {
+	if rnd.IsUID(m.SessionUID, 'x') {
+		return nil
+	}
+
+	return scope.SetColumn("SessionUID", rnd.PPID('x'))
+}
+
+// Create inserts a new row to the database.
+func (m *Session) Create() error {
+	return Db().Create(m).Error
+}
+
+// Save updates the existing row in the database.
+func (m *Session) Save() error {
+	return Db().Save(m).Error
+}
+
+// Delete removes the session from the database.
+func (m *Session) Delete() error {
+	return Db().Delete(m).Error
+}
+
+// UpdateActivity sets the last activity time and extends the expiration date.
+func (m *Session) UpdateActivity(expires time.Duration) error {
+	now := Timestamp()
+
+	m.ActiveAt = now
+	m.ExpiresAt = now.Add(expires)
+
+	return Db().Model(m).UpdateColumns(map[string]interface{}{"active_at": m.ActiveAt, "expires_at": m.ExpiresAt}).Error
+}
+
+// Expired returns true if the session has expired.
+func (m *Session) Expired() bool {
+	return !m.ExpiresAt.IsZero() && m.ExpiresAt.Before(Timestamp())
+}
+
+// FindSession returns a session by secret session id or nil if not found.
+func FindSession(id string) *Session {
+	if id == "" {
+		return nil
+	}
+
+	result := Session{}
+
+	if err := Db().Where("id = ?", SessionHash(id)).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// FindUserSessions returns all sessions of a user that have not expired yet.
+func FindUserSessions(userUID string) (result Sessions) {
+	if err := Db().Where("user_uid = ? AND expires_at > ?", userUID, Timestamp()).
+		Order("active_at DESC").Find(&result).Error; err != nil {
+		log.Errorf("session: %s", err)
+	}
+
+	return result
+}
+
+// DeleteUserSessions removes all sessions of a user and returns the number of deleted sessions.
+func DeleteUserSessions(userUID string) int {
+	if userUID == "" {
+		return 0
+	}
+
+	return int(Db().Where("user_uid = ?", userUID).Delete(&Session{}).RowsAffected)
+}
+
+// DeleteExpiredSessions removes all expired sessions from the database.
+func DeleteExpiredSessions() int {
+	return int(Db().Where("expires_at < ?", Timestamp()).Delete(&Session{}).RowsAffected)
+}
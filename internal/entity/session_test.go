@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionHash(t *testing.T) {
+	assert.Equal(t, 64, len(SessionHash("abc")))
+	assert.Equal(t, SessionHash("abc"), SessionHash("abc"))
+	assert.NotEqual(t, SessionHash("abc"), SessionHash("abd"))
+}
+
+func TestNewSession(t *testing.T) {
+	m := NewSession("secret", Admin.PersonUID, time.Hour)
+
+	assert.Equal(t, SessionHash("secret"), m.ID)
+	assert.Equal(t, Admin.PersonUID, m.UserUID)
+	assert.Equal(t, 16, len(m.SessionUID))
+	assert.False(t, m.Expired())
+}
+
+func TestFindSession(t *testing.T) {
+	m := NewSession("find-session", Admin.PersonUID, time.Hour)
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("found", func(t *testing.T) {
+		result := FindSession("find-session")
+
+		if result == nil {
+			t.Fatal("result should not be nil")
+		}
+
+		assert.Equal(t, m.SessionUID, result.SessionUID)
+	})
+	t.Run("not found", func(t *testing.T) {
+		assert.Nil(t, FindSession("xxx"))
+		assert.Nil(t, FindSession(""))
+	})
+}
+
+func TestSession_Expired(t *testing.T) {
+	m := NewSession("expired", Admin.PersonUID, -time.Hour)
+
+	assert.True(t, m.Expired())
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.LessOrEqual(t, 1, DeleteExpiredSessions())
+	assert.Nil(t, FindSession("expired"))
+}
+
+func TestUserSessions(t *testing.T) {
+	userUID := "u000000000000099"
+
+	for _, id := range []string{"user-session-1", "user-session-2"} {
+		m := NewSession(id, userUID, time.Hour)
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	assert.Len(t, FindUserSessions(userUID), 2)
+	assert.Equal(t, 2, DeleteUserSessions(userUID))
+	assert.Empty(t, FindUserSessions(userUID))
+}
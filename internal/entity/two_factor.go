@@ -0,0 +1,174 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/totp"
+)
+
+// Number of recovery codes created when two-factor authentication is enabled.
+const RecoveryCodeCount = 10
+
+// TwoFactor represents the TOTP two-factor authentication settings of a user.
+type TwoFactor struct {
+	UserUID       string    `gorm:"type:varbinary(42);primary_key;auto_increment:false;" json:"UserUID"`
+	Secret        string    `gorm:"type:varbinary(64);" json:"-"`
+	Enabled       bool      `json:"Enabled"`
+	RecoveryCodes string    `gorm:"type:text;" json:"-"`
+	LastCounter   int64     `json:"-"`
+	CreatedAt     time.Time `json:"CreatedAt"`
+	UpdatedAt     time.Time `json:"UpdatedAt"`
+}
+
+// NewTwoFactor creates a new, not yet enabled two-factor secret for a user.
+func NewTwoFactor(userUID string) (*TwoFactor, error) {
+	if userUID == "" {
+		return nil, fmt.Errorf("two-factor authentication requires a user")
+	}
+
+	secret, err := totp.NewSecret()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &TwoFactor{UserUID: userUID, Secret: secret}, nil
+}
+
+// FindTwoFactor returns the two-factor settings of a user or nil if not found.
+func FindTwoFactor(userUID string) *TwoFactor {
+	if userUID == "" {
+		return nil
+	}
+
+	result := TwoFactor{}
+
+	if err := Db().Where("user_uid = ?", userUID).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// Save updates the existing or inserts a new row.
+func (m *TwoFactor) Save() error {
+	return Db().Save(m).Error
+}
+
+// Delete removes the two-factor settings, which disables two-factor authentication.
+func (m *TwoFactor) Delete() error {
+	return Db().Delete(m).Error
+}
+
+// URL returns the key URI for authenticator apps.
+func (m *TwoFactor) URL(account string) string {
+	return totp.URL("PhotoPrism", account, m.Secret)
+}
+
+// Enable enables two-factor authentication if the code is valid and returns new recovery codes.
+func (m *TwoFactor) Enable(code string) (codes []string, err error) {
+	if !m.ValidCode(code) {
+		return nil, fmt.Errorf("invalid verification code")
+	}
+
+	m.Enabled = true
+
+	codes = m.NewRecoveryCodes()
+
+	return codes, m.Save()
+}
+
+// ValidCode returns true if the TOTP code is valid and has not been used before.
+func (m *TwoFactor) ValidCode(code string) bool {
+	counter, ok := totp.Validate(code, m.Secret, time.Now())
+
+	if !ok || counter <= m.LastCounter {
+		return false
+	}
+
+	m.LastCounter = counter
+
+	if m.Enabled {
+		if err := Db().Model(m).UpdateColumn("last_counter", counter).Error; err != nil {
+			log.Errorf("two-factor: %s", err)
+		}
+	}
+
+	return true
+}
+
+// NewRecoveryCodes replaces the recovery codes and returns them, only hashes are stored.
+func (m *TwoFactor) NewRecoveryCodes() (codes []string) {
+	hashes := make([]string, 0, RecoveryCodeCount)
+
+	for i := 0; i < RecoveryCodeCount; i++ {
+		code := rnd.Token(5) + "-" + rnd.Token(5)
+		codes = append(codes, code)
+		hashes = append(hashes, recoveryCodeHash(code))
+	}
+
+	m.RecoveryCodes = strings.Join(hashes, ",")
+
+	return codes
+}
+
+// UseRecoveryCode returns true if the recovery code is valid and removes it so that it can't be used again.
+func (m *TwoFactor) UseRecoveryCode(code string) bool {
+	code = strings.ToLower(strings.TrimSpace(code))
+
+	if code == "" || m.RecoveryCodes == "" {
+		return false
+	}
+
+	hash := recoveryCodeHash(code)
+	hashes := strings.Split(m.RecoveryCodes, ",")
+
+	for i, h := range hashes {
+		if h != hash {
+			continue
+		}
+
+		m.RecoveryCodes = strings.Join(append(hashes[:i], hashes[i+1:]...), ",")
+
+		if err := Db().Model(m).UpdateColumn("recovery_codes", m.RecoveryCodes).Error; err != nil {
+			log.Errorf("two-factor: %s", err)
+			return false
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// Verify returns true if the code is a valid TOTP or recovery code.
+func (m *TwoFactor) Verify(code string) bool {
+	if !m.Enabled {
+		return false
+	}
+
+	return m.ValidCode(code) || m.UseRecoveryCode(code)
+}
+
+// recoveryCodeHash returns the hash under which a recovery code is stored.
+func recoveryCodeHash(code string) string {
+	h := sha256.Sum256([]byte(code))
+
+	return hex.EncodeToString(h[:])
+}
+
+// TwoFactorEnabled returns true if the user has enabled two-factor authentication.
+func (m *Person) TwoFactorEnabled() bool {
+	if !m.Registered() {
+		return false
+	}
+
+	tf := FindTwoFactor(m.PersonUID)
+
+	return tf != nil && tf.Enabled
+}
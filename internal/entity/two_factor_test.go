@@ -0,0 +1,78 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/totp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTwoFactor_Enable(t *testing.T) {
+	user, err := CreatePerson(form.User{UserName: "two-factor", Password: "photoprism"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := NewTwoFactor(user.PersonUID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.False(t, user.TwoFactorEnabled())
+
+	t.Run("invalid code", func(t *testing.T) {
+		_, err := m.Enable("000000x")
+		assert.Error(t, err)
+		assert.False(t, m.Enabled)
+	})
+
+	t.Run("valid code", func(t *testing.T) {
+		code, err := totp.Code(m.Secret, time.Now())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		codes, err := m.Enable(code)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, codes, RecoveryCodeCount)
+		assert.True(t, user.TwoFactorEnabled())
+
+		// Codes can't be used twice.
+		assert.False(t, m.Verify(code))
+	})
+
+	t.Run("recovery code", func(t *testing.T) {
+		codes := m.NewRecoveryCodes()
+
+		if err := m.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, m.Verify(codes[0]))
+		assert.False(t, m.Verify(codes[0]))
+		assert.True(t, FindTwoFactor(user.PersonUID).UseRecoveryCode(codes[1]))
+		assert.False(t, m.Verify("xxxxx-xxxxx"))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		if err := m.Delete(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, user.TwoFactorEnabled())
+		assert.Nil(t, FindTwoFactor(user.PersonUID))
+	})
+}
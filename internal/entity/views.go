@@ -8,7 +8,7 @@ func CreateViews() {
 		            JOIN photos ph ON pl.photo_id = ph.id
 					WHERE pl.uncertainty < 100
 					AND ph.photo_quality >= 0
-					AND ph.photo_private = 0
+					AND ph.photo_private = FALSE
 					AND ph.deleted_at IS NULL GROUP BY l.id)
 		UNION ALL
 		(SELECT l.id AS label_id, COUNT(*) AS photo_count FROM labels l
@@ -17,7 +17,7 @@ func CreateViews() {
 		            JOIN photos ph ON pl.photo_id = ph.id
 					WHERE pl.uncertainty < 100
 					AND ph.photo_quality >= 0
-					AND ph.photo_private = 0
+					AND ph.photo_private = FALSE
 					AND ph.deleted_at IS NULL GROUP BY l.id)) counts GROUP BY label_id`
 
 	if err := UnscopedDb().Exec(labelCounts).Error; err != nil {
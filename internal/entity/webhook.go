@@ -0,0 +1,199 @@
+package entity
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+type Webhooks []Webhook
+
+// Webhook represents an outgoing webhook that receives library events matching its topic patterns.
+type Webhook struct {
+	WebhookUID     string    `gorm:"type:varbinary(42);primary_key;" json:"UID" yaml:"UID"`
+	WebhookName    string    `gorm:"type:varchar(160);" json:"Name" yaml:"Name"`
+	WebhookURL     string    `gorm:"type:varbinary(512);" json:"URL" yaml:"URL"`
+	WebhookEvents  string    `gorm:"type:varbinary(512);" json:"Events" yaml:"Events"`
+	WebhookSecret  string    `gorm:"type:varbinary(255);" json:"-" yaml:"-"`
+	WebhookEnabled bool      `json:"Enabled" yaml:"Enabled"`
+	RetryLimit     int       `json:"RetryLimit" yaml:"RetryLimit"`
+	CreatedAt      time.Time `json:"CreatedAt" yaml:"CreatedAt"`
+	UpdatedAt      time.Time `json:"UpdatedAt" yaml:"UpdatedAt"`
+}
+
+// BeforeCreate creates a random UID if needed before inserting a new row to the database.
+func (m *Webhook) BeforeCreate(scope *gorm.Scope) error {
+	if rnd.IsUID(m.WebhookUID, 'w') {
+		return nil
+	}
+
+	return scope.SetColumn("WebhookUID", rnd.PPID('w'))
+}
+
+// MatchTopic returns true if an event topic matches a pattern like "photos.*".
+func MatchTopic(pattern, topic string) bool {
+	matched, err := path.Match(pattern, topic)
+
+	return err == nil && matched
+}
+
+// CreateWebhook creates a new webhook and returns it together with the signing secret,
+// which is generated if none was provided. The retry limit defaults to 3, negative values disable retries.
+func CreateWebhook(f form.Webhook) (m *Webhook, secret string, err error) {
+	m = &Webhook{}
+
+	if f.RetryLimit == 0 {
+		f.RetryLimit = 3
+	}
+
+	if f.Secret == "" {
+		b := make([]byte, 24)
+
+		if _, err := rand.Read(b); err != nil {
+			return nil, "", err
+		}
+
+		f.Secret = hex.EncodeToString(b)
+	}
+
+	if err := m.SaveForm(f); err != nil {
+		return nil, "", err
+	}
+
+	return m, m.WebhookSecret, nil
+}
+
+// SaveForm validates the form values and saves the webhook, the secret is only changed if not empty.
+func (m *Webhook) SaveForm(f form.Webhook) error {
+	name := txt.Clip(strings.TrimSpace(f.Name), 160)
+
+	if name == "" {
+		return fmt.Errorf("webhook name must not be empty")
+	}
+
+	u, err := url.Parse(strings.TrimSpace(f.URL))
+
+	if err != nil || u.Host == "" || u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid webhook url %s", txt.Quote(f.URL))
+	}
+
+	var patterns []string
+
+	for _, p := range strings.Split(f.Events, ",") {
+		if p = strings.ToLower(strings.TrimSpace(p)); p == "" {
+			continue
+		} else if _, err := path.Match(p, ""); err != nil {
+			return fmt.Errorf("invalid event pattern %s", txt.Quote(p))
+		}
+
+		patterns = append(patterns, p)
+	}
+
+	if len(patterns) == 0 {
+		return fmt.Errorf("webhook events must not be empty")
+	}
+
+	m.WebhookName = name
+	m.WebhookURL = u.String()
+	m.WebhookEvents = strings.Join(patterns, ",")
+	m.WebhookEnabled = f.Enabled
+
+	if f.RetryLimit < 0 {
+		m.RetryLimit = 0
+	} else {
+		m.RetryLimit = f.RetryLimit
+	}
+
+	if f.Secret != "" {
+		m.WebhookSecret = f.Secret
+	}
+
+	return Db().Save(m).Error
+}
+
+// Form returns a form initialized with the webhook values, except for the secret.
+func (m *Webhook) Form() form.Webhook {
+	return form.Webhook{
+		Name:       m.WebhookName,
+		URL:        m.WebhookURL,
+		Events:     m.WebhookEvents,
+		Enabled:    m.WebhookEnabled,
+		RetryLimit: m.RetryLimit,
+	}
+}
+
+// Patterns returns the event topic patterns the webhook subscribes to.
+func (m *Webhook) Patterns() []string {
+	if m.WebhookEvents == "" {
+		return []string{}
+	}
+
+	return strings.Split(m.WebhookEvents, ",")
+}
+
+// Match returns true if the webhook subscribes to an event topic.
+func (m *Webhook) Match(topic string) bool {
+	for _, p := range m.Patterns() {
+		if MatchTopic(p, topic) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Delete removes the webhook and its delivery log from the database.
+func (m *Webhook) Delete() error {
+	if err := Db().Where("webhook_uid = ?", m.WebhookUID).Delete(&WebhookDelivery{}).Error; err != nil {
+		return err
+	}
+
+	return Db().Delete(m).Error
+}
+
+// FindWebhook returns a webhook by UID or nil if not found.
+func FindWebhook(uid string) *Webhook {
+	result := Webhook{}
+
+	if err := Db().Where("webhook_uid = ?", uid).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// FindWebhooks returns all webhooks sorted by name.
+func FindWebhooks() (result Webhooks) {
+	if err := Db().Order("webhook_name, webhook_uid").Find(&result).Error; err != nil {
+		log.Errorf("webhook: %s", err)
+	}
+
+	return result
+}
+
+// MatchingWebhooks returns all enabled webhooks that subscribe to an event topic.
+func MatchingWebhooks(topic string) (result Webhooks) {
+	var hooks Webhooks
+
+	if err := Db().Where("webhook_enabled = ?", true).Find(&hooks).Error; err != nil {
+		log.Errorf("webhook: %s", err)
+		return result
+	}
+
+	for _, m := range hooks {
+		if m.Match(topic) {
+			result = append(result, m)
+		}
+	}
+
+	return result
+}
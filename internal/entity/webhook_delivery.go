@@ -0,0 +1,89 @@
+package entity
+
+import (
+	"time"
+)
+
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusDelivered = "delivered"
+	DeliveryStatusFailed    = "failed"
+)
+
+type WebhookDeliveries []WebhookDelivery
+
+// WebhookDelivery represents a delivery attempt of an event to a webhook, rows form the delivery log.
+type WebhookDelivery struct {
+	ID             uint       `gorm:"primary_key" json:"ID" yaml:"ID"`
+	WebhookUID     string     `gorm:"type:varbinary(42);index;" json:"WebhookUID" yaml:"WebhookUID"`
+	EventName      string     `gorm:"type:varbinary(128);" json:"Event" yaml:"Event"`
+	Payload        string     `gorm:"type:mediumtext;" json:"Payload" yaml:"-"`
+	DeliveryStatus string     `gorm:"type:varbinary(16);index;" json:"Status" yaml:"Status"`
+	StatusCode     int        `json:"StatusCode" yaml:"StatusCode,omitempty"`
+	DeliveryError  string     `gorm:"type:varbinary(512);" json:"Error" yaml:"Error,omitempty"`
+	Attempts       int        `json:"Attempts" yaml:"Attempts"`
+	RetryAt        *time.Time `json:"RetryAt" yaml:"RetryAt,omitempty"`
+	DeliveredAt    *time.Time `json:"DeliveredAt" yaml:"DeliveredAt,omitempty"`
+	CreatedAt      time.Time  `sql:"index" json:"CreatedAt" yaml:"CreatedAt"`
+}
+
+// NewWebhookDelivery creates a pending delivery of an event payload to a webhook.
+func NewWebhookDelivery(webhookUID, eventName, payload string) (*WebhookDelivery, error) {
+	now := Timestamp()
+
+	// Retried later in case the first attempt gets interrupted.
+	retryAt := now.Add(5 * time.Minute)
+
+	m := &WebhookDelivery{
+		WebhookUID:     webhookUID,
+		EventName:      eventName,
+		Payload:        payload,
+		DeliveryStatus: DeliveryStatusPending,
+		RetryAt:        &retryAt,
+		CreatedAt:      now,
+	}
+
+	if err := Db().Create(m).Error; err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Save updates the delivery in the database.
+func (m *WebhookDelivery) Save() error {
+	return Db().Save(m).Error
+}
+
+// Pending returns true if the event still needs to be delivered.
+func (m *WebhookDelivery) Pending() bool {
+	return m.DeliveryStatus == DeliveryStatusPending
+}
+
+// FindWebhookDeliveries returns the most recent deliveries of a webhook.
+func FindWebhookDeliveries(webhookUID string, limit, offset int) (result WebhookDeliveries) {
+	if err := Db().Where("webhook_uid = ?", webhookUID).
+		Order("created_at DESC, id DESC").Limit(limit).Offset(offset).
+		Find(&result).Error; err != nil {
+		log.Errorf("webhook: %s", err)
+	}
+
+	return result
+}
+
+// DueWebhookDeliveries returns pending deliveries that should be retried now.
+func DueWebhookDeliveries(limit int) (result WebhookDeliveries) {
+	if err := Db().Where("delivery_status = ? AND retry_at <= ?", DeliveryStatusPending, Timestamp()).
+		Order("retry_at").Limit(limit).
+		Find(&result).Error; err != nil {
+		log.Errorf("webhook: %s", err)
+	}
+
+	return result
+}
+
+// PurgeWebhookDeliveries removes deliveries that were created before the given time and aren't pending.
+func PurgeWebhookDeliveries(before time.Time) error {
+	return Db().Where("created_at < ? AND delivery_status <> ?", before, DeliveryStatusPending).
+		Delete(&WebhookDelivery{}).Error
+}
@@ -0,0 +1,153 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchTopic(t *testing.T) {
+	assert.True(t, MatchTopic("*", "photos.created"))
+	assert.True(t, MatchTopic("photos.*", "photos.archived"))
+	assert.True(t, MatchTopic("import.completed", "import.completed"))
+	assert.False(t, MatchTopic("photos.*", "albums.created"))
+	assert.False(t, MatchTopic("photos.created", "photos.updated"))
+	assert.False(t, MatchTopic("[", "photos.created"))
+}
+
+func TestCreateWebhook(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		m, secret, err := CreateWebhook(form.Webhook{Name: " Home ", URL: "https://example.com/hook", Events: "Photos.created, import.*,,", Enabled: true})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer m.Delete()
+
+		assert.Equal(t, 48, len(secret))
+		assert.Equal(t, secret, m.WebhookSecret)
+		assert.Equal(t, "Home", m.WebhookName)
+		assert.Equal(t, "photos.created,import.*", m.WebhookEvents)
+		assert.Equal(t, 3, m.RetryLimit)
+		assert.True(t, m.Match("import.completed"))
+		assert.False(t, m.Match("photos.updated"))
+
+		found := FindWebhook(m.WebhookUID)
+
+		if found == nil {
+			t.Fatal("webhook should be found")
+		}
+
+		assert.Equal(t, m.WebhookURL, found.WebhookURL)
+	})
+	t.Run("custom secret", func(t *testing.T) {
+		m, secret, err := CreateWebhook(form.Webhook{Name: "Chat", URL: "http://localhost:8080/", Events: "*", Secret: "foo"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer m.Delete()
+
+		assert.Equal(t, "foo", secret)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, _, err := CreateWebhook(form.Webhook{Name: "", URL: "https://example.com/", Events: "*"})
+		assert.Error(t, err)
+
+		_, _, err = CreateWebhook(form.Webhook{Name: "Invalid", URL: "ftp://example.com/", Events: "*"})
+		assert.Error(t, err)
+
+		_, _, err = CreateWebhook(form.Webhook{Name: "Invalid", URL: "https://example.com/", Events: " , "})
+		assert.Error(t, err)
+
+		_, _, err = CreateWebhook(form.Webhook{Name: "Invalid", URL: "https://example.com/", Events: "photos.["})
+		assert.Error(t, err)
+	})
+}
+
+func TestWebhook_SaveForm(t *testing.T) {
+	m, secret, err := CreateWebhook(form.Webhook{Name: "Update", URL: "https://example.com/", Events: "*"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer m.Delete()
+
+	f := m.Form()
+	f.Events = "albums.shared"
+	f.Enabled = true
+
+	if err := m.SaveForm(f); err != nil {
+		t.Fatal(err)
+	}
+
+	found := FindWebhook(m.WebhookUID)
+
+	assert.Equal(t, "albums.shared", found.WebhookEvents)
+	assert.Equal(t, secret, found.WebhookSecret)
+	assert.True(t, found.WebhookEnabled)
+}
+
+func TestMatchingWebhooks(t *testing.T) {
+	enabled, _, err := CreateWebhook(form.Webhook{Name: "Enabled", URL: "https://example.com/", Events: "labels.*", Enabled: true})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer enabled.Delete()
+
+	disabled, _, err := CreateWebhook(form.Webhook{Name: "Disabled", URL: "https://example.com/", Events: "labels.*"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer disabled.Delete()
+
+	result := MatchingWebhooks("labels.created")
+
+	assert.Len(t, result, 1)
+	assert.Equal(t, enabled.WebhookUID, result[0].WebhookUID)
+	assert.Empty(t, MatchingWebhooks("albums.created"))
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	hook, _, err := CreateWebhook(form.Webhook{Name: "Log", URL: "https://example.com/", Events: "*"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := NewWebhookDelivery(hook.WebhookUID, "photos.created", "{}")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, m.Pending())
+	assert.NotNil(t, m.RetryAt)
+	assert.Len(t, FindWebhookDeliveries(hook.WebhookUID, 10, 0), 1)
+
+	m.DeliveryStatus = DeliveryStatusDelivered
+
+	if err := m.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NoError(t, PurgeWebhookDeliveries(Timestamp().Add(time.Hour)))
+	assert.Empty(t, FindWebhookDeliveries(hook.WebhookUID, 10, 0))
+
+	if _, err := NewWebhookDelivery(hook.WebhookUID, "photos.updated", "{}"); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NoError(t, hook.Delete())
+	assert.Empty(t, FindWebhookDeliveries(hook.WebhookUID, 10, 0))
+	assert.Nil(t, FindWebhook(hook.WebhookUID))
+}
@@ -0,0 +1,103 @@
+package event
+
+import (
+	"sync"
+	"time"
+)
+
+// HistorySize is the number of recent events kept for replaying them to clients.
+var HistorySize = 1000
+
+var sharedHistory = NewHistory(HistorySize)
+
+// Entry represents a published event with a sequential id, so that clients can resume after reconnecting.
+type Entry struct {
+	ID      uint64
+	Time    time.Time
+	Message Message
+}
+
+// History represents a ring buffer of recently published events.
+type History struct {
+	mutex   sync.RWMutex
+	size    int
+	entries []Entry
+	lastID  uint64
+	notify  chan struct{}
+}
+
+// NewHistory returns a new event history that keeps up to size entries.
+func NewHistory(size int) *History {
+	if size < 1 {
+		size = 1
+	}
+
+	return &History{
+		size:    size,
+		entries: make([]Entry, 0, size),
+		notify:  make(chan struct{}),
+	}
+}
+
+// SharedHistory returns the history of events published with Publish or PublishEntities.
+func SharedHistory() *History {
+	return sharedHistory
+}
+
+// Add appends a message to the history and wakes up waiting clients.
+func (h *History) Add(msg Message) Entry {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.lastID++
+
+	entry := Entry{ID: h.lastID, Time: time.Now().UTC(), Message: msg}
+
+	if len(h.entries) >= h.size {
+		copy(h.entries, h.entries[1:])
+		h.entries[len(h.entries)-1] = entry
+	} else {
+		h.entries = append(h.entries, entry)
+	}
+
+	close(h.notify)
+	h.notify = make(chan struct{})
+
+	return entry
+}
+
+// LastID returns the id of the most recent event.
+func (h *History) LastID() uint64 {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	return h.lastID
+}
+
+// Since returns all events after the given id. The result is incomplete if older
+// events have already been removed from the history or the id is unknown.
+func (h *History) Since(id uint64) (result []Entry, complete bool) {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	if len(h.entries) == 0 || id == h.lastID {
+		return result, id <= h.lastID
+	}
+
+	first := h.entries[0].ID
+
+	// Ids of unknown events were not issued by this history, e.g. before a restart.
+	if id+1 < first || id > h.lastID {
+		return append(result, h.entries...), false
+	}
+
+	return append(result, h.entries[id+1-first:]...), true
+}
+
+// Wait returns a channel that is closed once a new event was added.
+func (h *History) Wait() <-chan struct{} {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	return h.notify
+}
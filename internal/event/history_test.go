@@ -0,0 +1,81 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistory(t *testing.T) {
+	h := NewHistory(3)
+
+	assert.Equal(t, uint64(0), h.LastID())
+
+	result, complete := h.Since(0)
+
+	assert.Empty(t, result)
+	assert.True(t, complete)
+
+	wait := h.Wait()
+
+	h.Add(Message{Name: "photos.created"})
+
+	select {
+	case <-wait:
+	default:
+		t.Fatal("wait channel should be closed")
+	}
+
+	h.Add(Message{Name: "photos.updated"})
+	h.Add(Message{Name: "albums.created"})
+
+	result, complete = h.Since(1)
+
+	assert.True(t, complete)
+	assert.Len(t, result, 2)
+	assert.Equal(t, uint64(2), result[0].ID)
+	assert.Equal(t, "albums.created", result[1].Message.Name)
+
+	h.Add(Message{Name: "albums.updated"})
+	h.Add(Message{Name: "albums.deleted"})
+
+	assert.Equal(t, uint64(5), h.LastID())
+
+	result, complete = h.Since(1)
+
+	assert.False(t, complete)
+	assert.Len(t, result, 3)
+	assert.Equal(t, uint64(3), result[0].ID)
+
+	result, complete = h.Since(2)
+
+	assert.True(t, complete)
+	assert.Len(t, result, 3)
+
+	result, complete = h.Since(5)
+
+	assert.True(t, complete)
+	assert.Empty(t, result)
+
+	result, complete = h.Since(10)
+
+	assert.False(t, complete)
+	assert.Len(t, result, 3)
+
+	result, complete = NewHistory(3).Since(10)
+
+	assert.False(t, complete)
+	assert.Empty(t, result)
+}
+
+func TestSharedHistory(t *testing.T) {
+	last := SharedHistory().LastID()
+
+	Publish("foo.history", Data{"id": 1})
+
+	result, complete := SharedHistory().Since(last)
+
+	assert.True(t, complete)
+	assert.NotEmpty(t, result)
+	assert.Equal(t, "foo.history", result[len(result)-1].Message.Name)
+}
@@ -57,12 +57,18 @@ func WarningMsg(id i18n.Message, params ...interface{}) {
 }
 
 func Publish(event string, data Data) {
-	SharedHub().Publish(Message{
+	publish(Message{
 		Name:   event,
 		Fields: data,
 	})
 }
 
+// publish sends a message to subscribers and adds it to the shared history.
+func publish(msg Message) {
+	SharedHistory().Add(msg)
+	SharedHub().Publish(msg)
+}
+
 func Subscribe(topics ...string) hub.Subscription {
 	return SharedHub().Subscribe(channelCap, topics...)
 }
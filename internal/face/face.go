@@ -0,0 +1,155 @@
+/*
+
+Package face provides face detection, face embeddings and clustering of similar faces.
+
+Copyright (c) 2018 - 2020 Michael Mayer <hello@photoprism.org>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism™ is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.org if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.org/developer-guide/
+
+*/
+package face
+
+import (
+	"encoding/json"
+	"math"
+
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+var log = event.Log
+
+const (
+	ScoreThreshold = 0.7  // Minimum detection confidence.
+	MinSize        = 40   // Minimum face size in pixels.
+	ClusterDist    = 0.85 // Maximum embedding distance of faces in the same cluster.
+	MatchDist      = 0.7  // Maximum embedding distance for recognizing a known person.
+)
+
+// Area represents the relative position and size of a face, values are between 0 and 1.
+type Area struct {
+	X float32 `json:"x"`
+	Y float32 `json:"y"`
+	W float32 `json:"w"`
+	H float32 `json:"h"`
+}
+
+// Face represents a detected face with its embedding.
+type Face struct {
+	Area      Area
+	Score     float32
+	Embedding Embedding
+}
+
+// Faces represents a list of detected faces.
+type Faces []Face
+
+// Embedding represents a face embedding vector, similar faces have a small distance.
+type Embedding []float32
+
+// NewEmbedding decodes a JSON encoded embedding.
+func NewEmbedding(s string) (result Embedding) {
+	if s == "" {
+		return result
+	}
+
+	if err := json.Unmarshal([]byte(s), &result); err != nil {
+		log.Errorf("face: %s", err)
+	}
+
+	return result
+}
+
+// JSON returns the embedding as JSON encoded string.
+func (e Embedding) JSON() string {
+	if len(e) == 0 {
+		return ""
+	}
+
+	b, err := json.Marshal(e)
+
+	if err != nil {
+		log.Errorf("face: %s", err)
+		return ""
+	}
+
+	return string(b)
+}
+
+// Dist returns the euclidean distance between two embeddings.
+func (e Embedding) Dist(other Embedding) float64 {
+	if len(e) == 0 || len(e) != len(other) {
+		return math.MaxFloat64
+	}
+
+	var sum float64
+
+	for i := range e {
+		d := float64(e[i] - other[i])
+		sum += d * d
+	}
+
+	return math.Sqrt(sum)
+}
+
+// Normalize scales the embedding to unit length.
+func (e Embedding) Normalize() Embedding {
+	var sum float64
+
+	for _, v := range e {
+		sum += float64(v * v)
+	}
+
+	if sum == 0 {
+		return e
+	}
+
+	norm := float32(math.Sqrt(sum))
+	result := make(Embedding, len(e))
+
+	for i, v := range e {
+		result[i] = v / norm
+	}
+
+	return result
+}
+
+// Add returns the mean of an embedding that represents n faces and another one.
+func (e Embedding) Add(other Embedding, n int) Embedding {
+	if len(e) == 0 || n < 1 {
+		return other
+	}
+
+	if len(e) != len(other) {
+		return e
+	}
+
+	result := make(Embedding, len(e))
+
+	for i := range e {
+		result[i] = (e[i]*float32(n) + other[i]) / float32(n+1)
+	}
+
+	return result.Normalize()
+}
@@ -0,0 +1,59 @@
+package face
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmbedding_Dist(t *testing.T) {
+	t.Run("same", func(t *testing.T) {
+		e := Embedding{0.6, 0.8}
+		assert.Equal(t, float64(0), e.Dist(e))
+	})
+	t.Run("orthogonal", func(t *testing.T) {
+		assert.InDelta(t, math.Sqrt2, Embedding{1, 0}.Dist(Embedding{0, 1}), 0.0001)
+	})
+	t.Run("different length", func(t *testing.T) {
+		assert.Equal(t, math.MaxFloat64, Embedding{1, 0}.Dist(Embedding{1}))
+	})
+}
+
+func TestEmbedding_Normalize(t *testing.T) {
+	assert.Equal(t, Embedding{0.6, 0.8}, Embedding{3, 4}.Normalize())
+	assert.Equal(t, Embedding{0, 0}, Embedding{0, 0}.Normalize())
+}
+
+func TestEmbedding_Add(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		assert.Equal(t, Embedding{1, 0}, Embedding{}.Add(Embedding{1, 0}, 0))
+	})
+	t.Run("mean", func(t *testing.T) {
+		result := Embedding{1, 0}.Add(Embedding{0, 1}, 1)
+		assert.InDelta(t, 0.7071, result[0], 0.0001)
+		assert.InDelta(t, 0.7071, result[1], 0.0001)
+	})
+}
+
+func TestEmbedding_JSON(t *testing.T) {
+	e := Embedding{0.5, -0.25}
+
+	assert.Equal(t, "[0.5,-0.25]", e.JSON())
+	assert.Equal(t, e, NewEmbedding(e.JSON()))
+	assert.Equal(t, "", Embedding{}.JSON())
+	assert.Len(t, NewEmbedding(""), 0)
+}
+
+func TestNet_File(t *testing.T) {
+	t.Run("models missing", func(t *testing.T) {
+		net := New("testdata/missing", false)
+
+		assert.True(t, net.Disabled())
+
+		faces, err := net.File("testdata/missing.jpg")
+
+		assert.NoError(t, err)
+		assert.Len(t, faces, 0)
+	})
+}
@@ -0,0 +1,288 @@
+package face
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"math"
+	"path/filepath"
+	"sync"
+
+	"github.com/disintegration/imaging"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+	tf "github.com/tensorflow/tensorflow/tensorflow/go"
+)
+
+// Size of the face images expected by the embedding model.
+const CropSize = 160
+
+// Net uses TensorFlow to detect faces and compute their embeddings.
+type Net struct {
+	detectModel *tf.SavedModel
+	embedModel  *tf.SavedModel
+	modelPath   string
+	modelTags   []string
+	disabled    bool
+	mutex       sync.Mutex
+}
+
+// New returns a new face net instance, models are loaded from the "detect" and "embed"
+// subdirectories of the model path on first use.
+func New(modelPath string, disabled bool) *Net {
+	return &Net{modelPath: modelPath, modelTags: []string{"serve"}, disabled: disabled}
+}
+
+// Disabled returns true if face detection is disabled or the models are missing.
+func (t *Net) Disabled() bool {
+	return t.disabled || !fs.PathExists(filepath.Join(t.modelPath, "detect")) || !fs.PathExists(filepath.Join(t.modelPath, "embed"))
+}
+
+// File returns the faces found in a jpeg media file.
+func (t *Net) File(fileName string) (result Faces, err error) {
+	if t.Disabled() {
+		return result, nil
+	}
+
+	if fs.MimeType(fileName) != "image/jpeg" {
+		return result, fmt.Errorf("face: %s is not a jpeg file", txt.Quote(filepath.Base(fileName)))
+	}
+
+	img, err := imaging.Open(fileName)
+
+	if err != nil {
+		return result, err
+	}
+
+	return t.Detect(img)
+}
+
+// Detect returns the faces found in an image.
+func (t *Net) Detect(img image.Image) (result Faces, err error) {
+	if err := t.loadModels(); err != nil {
+		return result, err
+	}
+
+	tensor, err := imageTensor(img)
+
+	if err != nil {
+		return result, err
+	}
+
+	// Operations are nil if the model doesn't contain them.
+	inputOp := t.detectModel.Graph.Operation("image_tensor")
+	boxesOp := t.detectModel.Graph.Operation("detection_boxes")
+	scoresOp := t.detectModel.Graph.Operation("detection_scores")
+
+	if inputOp == nil || boxesOp == nil || scoresOp == nil {
+		return result, errors.New("face: detection model has unexpected operations")
+	}
+
+	output, err := t.detectModel.Session.Run(
+		map[tf.Output]*tf.Tensor{
+			inputOp.Output(0): tensor,
+		},
+		[]tf.Output{
+			boxesOp.Output(0),
+			scoresOp.Output(0),
+		},
+		nil)
+
+	if err != nil {
+		log.Error(err)
+		return result, errors.New("face: could not run detection")
+	}
+
+	if len(output) < 2 {
+		return result, errors.New("face: detection result is empty")
+	}
+
+	boxes := output[0].Value().([][][]float32)[0]
+	scores := output[1].Value().([][]float32)[0]
+
+	bounds := img.Bounds()
+	width, height := float32(bounds.Dx()), float32(bounds.Dy())
+
+	for i, score := range scores {
+		if score < ScoreThreshold || i >= len(boxes) {
+			continue
+		}
+
+		// Boxes are returned as ymin, xmin, ymax, xmax.
+		box := boxes[i]
+		area := Area{X: box[1], Y: box[0], W: box[3] - box[1], H: box[2] - box[0]}
+
+		if area.W*width < MinSize || area.H*height < MinSize {
+			continue
+		}
+
+		embedding, err := t.embedding(img, area)
+
+		if err != nil {
+			log.Warnf("face: %s", err)
+			continue
+		}
+
+		result = append(result, Face{Area: area, Score: score, Embedding: embedding})
+	}
+
+	log.Debugf("face: found %d faces", len(result))
+
+	return result, nil
+}
+
+// embedding returns the normalized embedding of a face area.
+func (t *Net) embedding(img image.Image, area Area) (Embedding, error) {
+	bounds := img.Bounds()
+	w, h := float32(bounds.Dx()), float32(bounds.Dy())
+
+	// Add a small margin around the detected face.
+	mx, my := area.W*w*0.1, area.H*h*0.1
+
+	rect := image.Rect(
+		bounds.Min.X+int(area.X*w-mx),
+		bounds.Min.Y+int(area.Y*h-my),
+		bounds.Min.X+int((area.X+area.W)*w+mx),
+		bounds.Min.Y+int((area.Y+area.H)*h+my),
+	).Intersect(bounds)
+
+	if rect.Empty() {
+		return nil, errors.New("empty face area")
+	}
+
+	crop := imaging.Fill(imaging.Crop(img, rect), CropSize, CropSize, imaging.Center, imaging.Lanczos)
+
+	input := t.embedModel.Graph.Operation("input")
+	phase := t.embedModel.Graph.Operation("phase_train")
+	embeddings := t.embedModel.Graph.Operation("embeddings")
+
+	if input == nil || phase == nil || embeddings == nil {
+		return nil, errors.New("embedding model has unexpected operations")
+	}
+
+	output, err := t.embedModel.Session.Run(
+		map[tf.Output]*tf.Tensor{
+			input.Output(0): faceTensor(crop),
+			phase.Output(0): boolTensor(false),
+		},
+		[]tf.Output{
+			embeddings.Output(0),
+		},
+		nil)
+
+	if err != nil {
+		log.Error(err)
+		return nil, errors.New("could not compute embedding")
+	}
+
+	if len(output) < 1 {
+		return nil, errors.New("embedding is empty")
+	}
+
+	return Embedding(output[0].Value().([][]float32)[0]).Normalize(), nil
+}
+
+func (t *Net) loadModels() error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.detectModel != nil && t.embedModel != nil {
+		// Already loaded
+		return nil
+	}
+
+	log.Infof("tensorflow: loading face models from %s", txt.Quote(filepath.Base(t.modelPath)))
+
+	detect, err := tf.LoadSavedModel(filepath.Join(t.modelPath, "detect"), t.modelTags, nil)
+
+	if err != nil {
+		return err
+	}
+
+	embed, err := tf.LoadSavedModel(filepath.Join(t.modelPath, "embed"), t.modelTags, nil)
+
+	if err != nil {
+		return err
+	}
+
+	t.detectModel = detect
+	t.embedModel = embed
+
+	return nil
+}
+
+// imageTensor returns an uint8 tensor with shape [1, height, width, 3].
+func imageTensor(img image.Image) (*tf.Tensor, error) {
+	bounds := img.Bounds()
+	result := make([][][][]uint8, 1)
+	result[0] = make([][][]uint8, bounds.Dy())
+
+	for y := 0; y < bounds.Dy(); y++ {
+		result[0][y] = make([][]uint8, bounds.Dx())
+
+		for x := 0; x < bounds.Dx(); x++ {
+			r, g, b, _ := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			result[0][y][x] = []uint8{uint8(r >> 8), uint8(g >> 8), uint8(b >> 8)}
+		}
+	}
+
+	return tf.NewTensor(result)
+}
+
+// faceTensor returns a prewhitened float tensor with shape [1, CropSize, CropSize, 3].
+func faceTensor(img image.Image) *tf.Tensor {
+	var sum, sumSq float64
+
+	pixels := make([]float32, 0, CropSize*CropSize*3)
+
+	for y := 0; y < CropSize; y++ {
+		for x := 0; x < CropSize; x++ {
+			r, g, b, _ := img.At(x, y).RGBA()
+
+			for _, v := range []float32{float32(r >> 8), float32(g >> 8), float32(b >> 8)} {
+				pixels = append(pixels, v)
+				sum += float64(v)
+				sumSq += float64(v * v)
+			}
+		}
+	}
+
+	n := float64(len(pixels))
+	mean := sum / n
+	std := math.Max(math.Sqrt(sumSq/n-mean*mean), 1/math.Sqrt(n))
+
+	result := make([][][][]float32, 1)
+	result[0] = make([][][]float32, CropSize)
+
+	for y := 0; y < CropSize; y++ {
+		result[0][y] = make([][]float32, CropSize)
+
+		for x := 0; x < CropSize; x++ {
+			i := (y*CropSize + x) * 3
+			result[0][y][x] = []float32{
+				float32((float64(pixels[i]) - mean) / std),
+				float32((float64(pixels[i+1]) - mean) / std),
+				float32((float64(pixels[i+2]) - mean) / std),
+			}
+		}
+	}
+
+	tensor, err := tf.NewTensor(result)
+
+	if err != nil {
+		log.Error(err)
+	}
+
+	return tensor
+}
+
+// boolTensor returns a scalar bool tensor.
+func boolTensor(b bool) *tf.Tensor {
+	tensor, err := tf.NewTensor(b)
+
+	if err != nil {
+		log.Error(err)
+	}
+
+	return tensor
+}
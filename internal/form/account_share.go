@@ -2,5 +2,6 @@ package form
 
 type AccountShare struct {
 	Photos      []string `json:"photos"`
+	Albums      []string `json:"albums"`
 	Destination string   `json:"destination"`
 }
@@ -1,6 +1,10 @@
 package form
 
-import "github.com/ulule/deepcopier"
+import (
+	"fmt"
+
+	"github.com/ulule/deepcopier"
+)
 
 // Album represents an album edit form.
 type Album struct {
@@ -27,3 +31,33 @@ func NewAlbum(m interface{}) (f Album, err error) {
 
 	return f, err
 }
+
+// SmartAlbumFilter validates a photo search filter for smart albums and returns it in normalized form.
+func SmartAlbumFilter(s string) (string, error) {
+	if IsQueryExpr(s) {
+		expr, err := ParseQueryExpr(s)
+
+		if err != nil {
+			return "", err
+		}
+
+		return expr.String(), nil
+	}
+
+	f := PhotoSearch{}
+
+	if err := Unserialize(&f, s); err != nil {
+		return "", err
+	}
+
+	// Smart albums must not depend on other albums or individual photos.
+	f.Album = ""
+	f.Filter = ""
+	f.ID = ""
+
+	if result := f.Serialize(); result != "" {
+		return result, nil
+	}
+
+	return "", fmt.Errorf("smart album filter must not be empty")
+}
@@ -17,6 +17,8 @@ type AlbumSearch struct {
 	Count    int    `form:"count" binding:"required" serialize:"-"`
 	Offset   int    `form:"offset" serialize:"-"`
 	Order    string `form:"order" serialize:"-"`
+	Owner    string `form:"-"` // Restricts results to albums owned by this user UID.
+	Shared   string `form:"-"` // Album UIDs shared with the owner, comma separated.
 }
 
 func (f *AlbumSearch) GetQuery() string {
@@ -37,3 +37,45 @@ func TestNewAlbum(t *testing.T) {
 		assert.Equal(t, true, r.AlbumFavorite)
 	})
 }
+
+func TestSmartAlbumFilter(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		r, err := SmartAlbumFilter("label:cat favorite:true")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, r, "label:cat")
+		assert.Contains(t, r, "favorite:true")
+	})
+	t.Run("album removed", func(t *testing.T) {
+		r, err := SmartAlbumFilter("album:at9lxuqxpogaaba8 label:cat")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "label:cat", r)
+	})
+	t.Run("expression", func(t *testing.T) {
+		r, err := SmartAlbumFilter("(label:cat OR label:dog)  AND iso:100-400")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "(label:cat OR label:dog) iso:100..400", r)
+	})
+	t.Run("invalid expression", func(t *testing.T) {
+		_, err := SmartAlbumFilter("label:cat OR")
+
+		assert.Error(t, err)
+	})
+	t.Run("empty", func(t *testing.T) {
+		r, err := SmartAlbumFilter("")
+
+		assert.Error(t, err)
+		assert.Equal(t, "", r)
+	})
+}
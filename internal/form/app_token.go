@@ -0,0 +1,8 @@
+package form
+
+// AppToken represents a form for creating app tokens.
+type AppToken struct {
+	Name    string `json:"Name"`
+	Scope   string `json:"Scope"`
+	Expires int    `json:"Expires"`
+}
@@ -0,0 +1,12 @@
+package form
+
+// Comment represents a comment form, guests may optionally provide a name.
+type Comment struct {
+	Text string `json:"Text"`
+	Name string `json:"Name"`
+}
+
+// ActivitySearch represents search form fields for "/api/v1/activity".
+type ActivitySearch struct {
+	Count int `form:"count" binding:"required"`
+}
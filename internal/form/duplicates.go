@@ -0,0 +1,33 @@
+package form
+
+// DuplicateSearch represents search form fields for "/api/v1/duplicates".
+type DuplicateSearch struct {
+	Dist   *int `form:"dist"`
+	Count  int  `form:"count" binding:"required"`
+	Offset int  `form:"offset"`
+}
+
+// Distance returns the maximum perceptual hash distance, or -1 for the default if none was specified.
+func (f DuplicateSearch) Distance() int {
+	if f.Dist == nil {
+		return -1
+	}
+
+	return *f.Dist
+}
+
+// DuplicateKeep represents a group of near-duplicates of which only one photo is kept, the others are archived.
+type DuplicateKeep struct {
+	Keep    string   `json:"keep"`
+	Archive []string `json:"archive"`
+	Dist    *int     `json:"dist"`
+}
+
+// Distance returns the maximum perceptual hash distance of the group, or -1 for the default if none was specified.
+func (f DuplicateKeep) Distance() int {
+	if f.Dist == nil {
+		return -1
+	}
+
+	return *f.Dist
+}
@@ -0,0 +1,21 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDuplicateSearch_Distance(t *testing.T) {
+	dist := 0
+
+	assert.Equal(t, -1, DuplicateSearch{}.Distance())
+	assert.Equal(t, 0, DuplicateSearch{Dist: &dist}.Distance())
+}
+
+func TestDuplicateKeep_Distance(t *testing.T) {
+	dist := 4
+
+	assert.Equal(t, -1, DuplicateKeep{}.Distance())
+	assert.Equal(t, 4, DuplicateKeep{Dist: &dist}.Distance())
+}
@@ -0,0 +1,13 @@
+package form
+
+// FaceSearch represents search form fields for "/api/v1/faces".
+type FaceSearch struct {
+	Min    int `form:"min"`
+	Count  int `form:"count" binding:"required"`
+	Offset int `form:"offset"`
+}
+
+// FaceCluster represents a face cluster edit form, the cluster is linked to the person with this name.
+type FaceCluster struct {
+	PersonName string `json:"Name"`
+}
@@ -31,6 +31,8 @@ type GeoSearch struct {
 	Color    string    `form:"color"`
 	Camera   int       `form:"camera"`
 	Lens     int       `form:"lens"`
+	Owner    string    `form:"-"` // Restricts results to photos owned by this user UID.
+	Shared   string    `form:"-"` // Album UIDs shared with the owner, comma separated.
 }
 
 // GetQuery returns the query parameter as string.
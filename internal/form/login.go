@@ -1,10 +1,13 @@
 package form
 
 type Login struct {
-	Email    string `json:"email"`
-	UserName string `json:"username"`
-	Password string `json:"password"`
-	Token    string `json:"token"`
+	Email     string `json:"email"`
+	UserName  string `json:"username"`
+	Password  string `json:"password"`
+	Token     string `json:"token"`
+	Code      string `json:"code"`
+	Challenge string `json:"challenge"`
+	State     string `json:"state"`
 }
 
 func (f Login) HasToken() bool {
@@ -19,6 +22,18 @@ func (f Login) HasPassword() bool {
 	return f.Password != "" && len(f.Password) <= 255
 }
 
+func (f Login) HasCode() bool {
+	return f.Code != "" && len(f.Code) <= 32
+}
+
+func (f Login) HasChallenge() bool {
+	return f.Challenge != "" && f.HasCode()
+}
+
+func (f Login) HasState() bool {
+	return f.State != "" && f.Code != "" && len(f.Code) <= 2048
+}
+
 func (f Login) HasCredentials() bool {
 	return f.HasUserName() && f.HasPassword()
 }
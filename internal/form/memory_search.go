@@ -0,0 +1,14 @@
+package form
+
+// MemorySearch represents search form fields for "/api/v1/memories".
+type MemorySearch struct {
+	Year     int    `form:"year"`
+	Month    int    `form:"month"`
+	Day      int    `form:"day"`
+	Quality  int    `form:"quality"`
+	Favorite bool   `form:"favorite"`
+	Dist     int    `form:"dist"`
+	Count    int    `form:"count"`
+	Owner    string `form:"-"` // Restricts results to photos owned by this user UID.
+	Shared   string `form:"-"` // Album UIDs shared with the owner, comma separated.
+}
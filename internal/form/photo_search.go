@@ -41,6 +41,7 @@ type PhotoSearch struct {
 	Location  bool      `form:"location"`
 	Album     string    `form:"album"`
 	Label     string    `form:"label"`
+	Person    string    `form:"person"`   // Person UIDs or names, comma separated
 	Category  string    `form:"category"` // Moments
 	Country   string    `form:"country"`  // Moments
 	State     string    `form:"state"`    // Moments
@@ -58,6 +59,8 @@ type PhotoSearch struct {
 	Offset    int       `form:"offset" serialize:"-"`
 	Order     string    `form:"order" serialize:"-"`
 	Merged    bool      `form:"merged" serialize:"-"`
+	Owner     string    `form:"-"` // Restricts results to photos owned by this user UID.
+	Shared    string    `form:"-"` // Album UIDs shared with the owner, comma separated.
 }
 
 func (f *PhotoSearch) GetQuery() string {
@@ -166,6 +166,18 @@ func TestParseQueryString(t *testing.T) {
 
 		assert.Equal(t, "Could not find format for \"cat\"", err.Error())
 	})
+	t.Run("query for owner is not allowed", func(t *testing.T) {
+		form := &PhotoSearch{Query: "owner:u000000000000099"}
+
+		err := form.ParseQueryString()
+
+		if err == nil {
+			t.Fatal("error expected")
+		}
+
+		assert.Equal(t, "unknown filter: Owner", err.Error())
+		assert.Equal(t, "", form.Owner)
+	})
 }
 
 func TestNewPhotoSearch(t *testing.T) {
@@ -191,4 +203,8 @@ func TestPhotoSearch_Serialize(t *testing.T) {
 	t.Logf("SERIALIZED: %s", result)
 
 	assert.IsType(t, "string", result)
+
+	form.Owner = "u000000000000099"
+
+	assert.Equal(t, result, form.Serialize())
 }
@@ -0,0 +1,538 @@
+package form
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Query expression operators.
+const (
+	QueryOpEqual        = "="
+	QueryOpLess         = "<"
+	QueryOpLessEqual    = "<="
+	QueryOpGreater      = ">"
+	QueryOpGreaterEqual = ">="
+	QueryOpRange        = ".."
+)
+
+// Query expression field types.
+const (
+	QueryText   = "text"
+	QueryNumber = "number"
+	QueryDate   = "date"
+	QueryBool   = "bool"
+)
+
+// QueryFields maps the field names supported in search expressions to their type.
+var QueryFields = map[string]string{
+	"label":       QueryText,
+	"keyword":     QueryText,
+	"title":       QueryText,
+	"description": QueryText,
+	"name":        QueryText,
+	"original":    QueryText,
+	"path":        QueryText,
+	"folder":      QueryText,
+	"camera":      QueryText,
+	"lens":        QueryText,
+	"country":     QueryText,
+	"state":       QueryText,
+	"city":        QueryText,
+	"category":    QueryText,
+	"color":       QueryText,
+	"type":        QueryText,
+	"person":      QueryText,
+	"album":       QueryText,
+	"iso":         QueryNumber,
+	"f":           QueryNumber,
+	"mm":          QueryNumber,
+	"year":        QueryNumber,
+	"month":       QueryNumber,
+	"day":         QueryNumber,
+	"quality":     QueryNumber,
+	"chroma":      QueryNumber,
+	"lat":         QueryNumber,
+	"lng":         QueryNumber,
+	"taken":       QueryDate,
+	"added":       QueryDate,
+	"favorite":    QueryBool,
+	"private":     QueryBool,
+	"public":      QueryBool,
+	"scan":        QueryBool,
+	"video":       QueryBool,
+	"portrait":    QueryBool,
+	"mono":        QueryBool,
+}
+
+var queryNumberRange = regexp.MustCompile(`^(\d+(?:\.\d+)?)-(\d+(?:\.\d+)?)$`)
+var queryDateLayouts = []string{"2006-01-02", "2006-01", "2006"}
+
+// QueryExpr represents a node of a parsed search expression.
+type QueryExpr interface {
+	String() string
+}
+
+// QueryAnd matches if all expressions match.
+type QueryAnd []QueryExpr
+
+// QueryOr matches if at least one expression matches.
+type QueryOr []QueryExpr
+
+// QueryNot matches if the expression does not match.
+type QueryNot struct {
+	Expr QueryExpr
+}
+
+// QueryTerm matches a single field value, or keywords and labels if the key is empty.
+type QueryTerm struct {
+	Key   string
+	Op    string
+	Value string
+	Max   string
+}
+
+// String returns the expression in normalized form.
+func (q QueryAnd) String() string {
+	s := make([]string, len(q))
+
+	for i, e := range q {
+		if _, ok := e.(QueryOr); ok {
+			s[i] = "(" + e.String() + ")"
+		} else {
+			s[i] = e.String()
+		}
+	}
+
+	return strings.Join(s, " ")
+}
+
+// String returns the expression in normalized form.
+func (q QueryOr) String() string {
+	s := make([]string, len(q))
+
+	for i, e := range q {
+		s[i] = e.String()
+	}
+
+	return strings.Join(s, " OR ")
+}
+
+// String returns the expression in normalized form.
+func (q QueryNot) String() string {
+	if _, ok := q.Expr.(QueryTerm); ok {
+		return "-" + q.Expr.String()
+	}
+
+	return "-(" + q.Expr.String() + ")"
+}
+
+// String returns the term in normalized form.
+func (q QueryTerm) String() string {
+	value := q.Value
+
+	switch q.Op {
+	case QueryOpRange:
+		value = q.Value + QueryOpRange + q.Max
+	case QueryOpEqual:
+		if strings.ContainsAny(value, " ():") {
+			value = fmt.Sprintf("\"%s\"", value)
+		}
+	default:
+		value = q.Op + value
+	}
+
+	if q.Key == "" {
+		return value
+	}
+
+	return q.Key + ":" + value
+}
+
+// QueryDateRange returns the start and end of the period specified as year, month or day.
+func QueryDateRange(s string) (start, end time.Time, err error) {
+	for i, layout := range queryDateLayouts {
+		if len(s) != len(layout) {
+			continue
+		}
+
+		if start, err = time.Parse(layout, s); err != nil {
+			return start, end, err
+		}
+
+		switch i {
+		case 0:
+			end = start.AddDate(0, 0, 1)
+		case 1:
+			end = start.AddDate(0, 1, 0)
+		default:
+			end = start.AddDate(1, 0, 0)
+		}
+
+		return start, end, nil
+	}
+
+	return start, end, fmt.Errorf("invalid date: %s", s)
+}
+
+// queryToken represents a word, a quoted phrase or a parenthesis in a search query.
+type queryToken struct {
+	Text  string
+	Paren bool
+}
+
+// lexQuery splits a search query into tokens.
+func lexQuery(q string) (tokens []queryToken, err error) {
+	var word []rune
+	var quoted bool
+
+	flush := func() {
+		if len(word) > 0 {
+			tokens = append(tokens, queryToken{Text: string(word)})
+			word = word[:0]
+		}
+	}
+
+	for _, char := range q {
+		switch {
+		case char == '"':
+			quoted = !quoted
+			word = append(word, char)
+		case quoted:
+			word = append(word, char)
+		case char == '(' || char == ')':
+			flush()
+			tokens = append(tokens, queryToken{Text: string(char), Paren: true})
+		case char == ' ' || char == '\t' || char == '\n' || char == '\r':
+			flush()
+		default:
+			word = append(word, char)
+		}
+	}
+
+	if quoted {
+		return tokens, fmt.Errorf("missing closing quote")
+	}
+
+	flush()
+
+	return tokens, nil
+}
+
+// splitTerm returns the lowercase key, value and quote status of a term.
+func splitTerm(s string) (key, value string, quoted bool) {
+	value = s
+
+	if i := strings.IndexRune(s, ':'); i > 0 && !strings.ContainsRune(s[:i], '"') {
+		key = strings.ToLower(s[:i])
+		value = s[i+1:]
+	}
+
+	quoted = strings.ContainsRune(value, '"')
+	value = strings.TrimSpace(strings.ReplaceAll(value, "\"", ""))
+
+	return key, value, quoted
+}
+
+// queryParser parses a list of tokens into an expression tree.
+type queryParser struct {
+	tokens []queryToken
+	pos    int
+}
+
+func (p *queryParser) peek() (queryToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return queryToken{}, false
+	}
+
+	return p.tokens[p.pos], true
+}
+
+func (p *queryParser) next() (queryToken, bool) {
+	t, ok := p.peek()
+
+	if ok {
+		p.pos++
+	}
+
+	return t, ok
+}
+
+// parseOr parses expressions separated by OR.
+func (p *queryParser) parseOr() (QueryExpr, error) {
+	var result QueryOr
+
+	for {
+		e, err := p.parseAnd()
+
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, e)
+
+		if t, ok := p.peek(); !ok || t.Paren || t.Text != "OR" {
+			break
+		}
+
+		p.pos++
+	}
+
+	if len(result) == 1 {
+		return result[0], nil
+	}
+
+	return result, nil
+}
+
+// parseAnd parses expressions separated by AND or whitespace.
+func (p *queryParser) parseAnd() (QueryExpr, error) {
+	var result QueryAnd
+
+	for {
+		t, ok := p.peek()
+
+		if !ok || t.Paren && t.Text == ")" || !t.Paren && t.Text == "OR" {
+			break
+		} else if !t.Paren && t.Text == "AND" {
+			if len(result) == 0 {
+				return nil, fmt.Errorf("unexpected AND")
+			}
+
+			p.pos++
+
+			if t, ok := p.peek(); !ok || t.Paren && t.Text == ")" || !t.Paren && t.Text == "OR" {
+				return nil, fmt.Errorf("missing expression after AND")
+			}
+
+			continue
+		}
+
+		e, err := p.parseNot()
+
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, e)
+	}
+
+	switch len(result) {
+	case 0:
+		if t, ok := p.peek(); ok {
+			return nil, fmt.Errorf("unexpected %s", t.Text)
+		}
+
+		return nil, fmt.Errorf("unexpected end of query")
+	case 1:
+		return result[0], nil
+	}
+
+	return result, nil
+}
+
+// parseNot parses negated expressions, groups and terms.
+func (p *queryParser) parseNot() (QueryExpr, error) {
+	t, ok := p.next()
+
+	if !ok {
+		return nil, fmt.Errorf("unexpected end of query")
+	}
+
+	switch {
+	case t.Paren && t.Text == "(":
+		e, err := p.parseOr()
+
+		if err != nil {
+			return nil, err
+		}
+
+		if t, ok := p.next(); !ok || t.Text != ")" {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+
+		return e, nil
+	case t.Paren:
+		return nil, fmt.Errorf("unexpected %s", t.Text)
+	case t.Text == "NOT" || t.Text == "-":
+		e, err := p.parseNot()
+
+		if err != nil {
+			return nil, err
+		}
+
+		return QueryNot{Expr: e}, nil
+	case strings.HasPrefix(t.Text, "-"):
+		e, err := parseTerm(t.Text[1:])
+
+		if err != nil {
+			return nil, err
+		}
+
+		return QueryNot{Expr: e}, nil
+	}
+
+	return parseTerm(t.Text)
+}
+
+// parseTerm parses a single search term like label:cat, iso:100-400 or taken:>=2019-06.
+func parseTerm(s string) (QueryExpr, error) {
+	key, value, quoted := splitTerm(s)
+
+	if value == "" {
+		return nil, fmt.Errorf("missing value for %s", key)
+	}
+
+	result := QueryTerm{Key: key, Op: QueryOpEqual, Value: value}
+
+	if key == "" {
+		return result, nil
+	}
+
+	fieldType, ok := QueryFields[key]
+
+	if !ok {
+		return nil, fmt.Errorf("unknown filter: %s", key)
+	} else if quoted {
+		return result, nil
+	}
+
+	for _, op := range []string{QueryOpGreaterEqual, QueryOpLessEqual, QueryOpGreater, QueryOpLess, QueryOpEqual} {
+		if strings.HasPrefix(value, op) {
+			result.Op = op
+			result.Value = strings.TrimSpace(value[len(op):])
+			break
+		}
+	}
+
+	if result.Op == QueryOpEqual {
+		if i := strings.Index(value, QueryOpRange); i >= 0 {
+			result.Op = QueryOpRange
+			result.Value = value[:i]
+			result.Max = value[i+len(QueryOpRange):]
+		} else if m := queryNumberRange.FindStringSubmatch(value); fieldType == QueryNumber && m != nil {
+			result.Op = QueryOpRange
+			result.Value = m[1]
+			result.Max = m[2]
+		}
+	}
+
+	// Open ranges are converted to comparisons.
+	if result.Op == QueryOpRange {
+		if result.Value == "" && result.Max == "" {
+			return nil, fmt.Errorf("invalid range for %s", key)
+		} else if result.Value == "" {
+			result.Op = QueryOpLessEqual
+			result.Value = result.Max
+			result.Max = ""
+		} else if result.Max == "" {
+			result.Op = QueryOpGreaterEqual
+		}
+	}
+
+	if result.Op != QueryOpEqual && fieldType != QueryNumber && fieldType != QueryDate {
+		return nil, fmt.Errorf("operator %s not supported for %s", result.Op, key)
+	}
+
+	// Validate numbers and dates.
+	for _, v := range []string{result.Value, result.Max} {
+		if v == "" {
+			continue
+		}
+
+		switch fieldType {
+		case QueryNumber:
+			if _, err := strconv.ParseFloat(v, 64); err != nil {
+				return nil, fmt.Errorf("invalid number for %s: %s", key, v)
+			}
+		case QueryDate:
+			if _, _, err := QueryDateRange(v); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// ParseQueryExpr parses a search query with boolean operators, parentheses, quoted phrases,
+// negation, ranges and comparison operators.
+func ParseQueryExpr(q string) (QueryExpr, error) {
+	tokens, err := lexQuery(q)
+
+	if err != nil {
+		return nil, err
+	} else if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty query")
+	}
+
+	p := queryParser{tokens: tokens}
+
+	result, err := p.parseOr()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if t, ok := p.peek(); ok {
+		return nil, fmt.Errorf("unexpected %s", t.Text)
+	}
+
+	return result, nil
+}
+
+// photoSearchFields maps the filter names supported by PhotoSearch without expressions to their kind.
+var photoSearchFields = func() map[string]reflect.Kind {
+	result := make(map[string]reflect.Kind)
+	t := reflect.TypeOf(PhotoSearch{})
+
+	for i := 0; i < t.NumField(); i++ {
+		if name := t.Field(i).Tag.Get("form"); name != "" && name != "-" {
+			result[name] = t.Field(i).Type.Kind()
+		}
+	}
+
+	return result
+}()
+
+// IsQueryExpr returns true if a search query requires the expression parser, e.g. because
+// it contains boolean operators, parentheses, negation, ranges or comparison operators.
+func IsQueryExpr(q string) bool {
+	tokens, err := lexQuery(q)
+
+	if err != nil {
+		return false
+	}
+
+	for _, t := range tokens {
+		if t.Paren || t.Text == "AND" || t.Text == "OR" || t.Text == "NOT" || strings.HasPrefix(t.Text, "-") {
+			return true
+		}
+
+		key, value, quoted := splitTerm(t.Text)
+
+		if key == "" || quoted || value == "" {
+			continue
+		}
+
+		fieldType, ok := QueryFields[key]
+		kind, legacy := photoSearchFields[key]
+
+		if !ok {
+			continue
+		} else if !legacy {
+			return true
+		} else if _, err := strconv.Atoi(value); err != nil && kind == reflect.Int {
+			return true
+		} else if strings.ContainsAny(value[:1], "<>=") || strings.Contains(value, QueryOpRange) {
+			return true
+		} else if fieldType == QueryNumber && queryNumberRange.MatchString(value) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,324 @@
+package form
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseQueryExpr(t *testing.T) {
+	t.Run("single word", func(t *testing.T) {
+		r, err := ParseQueryExpr("cat")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, QueryTerm{Op: QueryOpEqual, Value: "cat"}, r)
+	})
+	t.Run("implicit and", func(t *testing.T) {
+		r, err := ParseQueryExpr("label:cat favorite:true")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, QueryAnd{
+			QueryTerm{Key: "label", Op: QueryOpEqual, Value: "cat"},
+			QueryTerm{Key: "favorite", Op: QueryOpEqual, Value: "true"},
+		}, r)
+	})
+	t.Run("explicit and", func(t *testing.T) {
+		r, err := ParseQueryExpr("label:cat AND label:dog")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "label:cat label:dog", r.String())
+	})
+	t.Run("or", func(t *testing.T) {
+		r, err := ParseQueryExpr("label:cat OR label:dog")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, QueryOr{
+			QueryTerm{Key: "label", Op: QueryOpEqual, Value: "cat"},
+			QueryTerm{Key: "label", Op: QueryOpEqual, Value: "dog"},
+		}, r)
+	})
+	t.Run("and binds stronger than or", func(t *testing.T) {
+		r, err := ParseQueryExpr("label:cat favorite:true OR label:dog")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, QueryOr{
+			QueryAnd{
+				QueryTerm{Key: "label", Op: QueryOpEqual, Value: "cat"},
+				QueryTerm{Key: "favorite", Op: QueryOpEqual, Value: "true"},
+			},
+			QueryTerm{Key: "label", Op: QueryOpEqual, Value: "dog"},
+		}, r)
+	})
+	t.Run("parentheses", func(t *testing.T) {
+		r, err := ParseQueryExpr("(label:cat OR label:dog) country:de")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, QueryAnd{
+			QueryOr{
+				QueryTerm{Key: "label", Op: QueryOpEqual, Value: "cat"},
+				QueryTerm{Key: "label", Op: QueryOpEqual, Value: "dog"},
+			},
+			QueryTerm{Key: "country", Op: QueryOpEqual, Value: "de"},
+		}, r)
+		assert.Equal(t, "(label:cat OR label:dog) country:de", r.String())
+	})
+	t.Run("nested parentheses", func(t *testing.T) {
+		r, err := ParseQueryExpr("((cat))")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, QueryTerm{Op: QueryOpEqual, Value: "cat"}, r)
+	})
+	t.Run("not", func(t *testing.T) {
+		r, err := ParseQueryExpr("NOT label:cat")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, QueryNot{Expr: QueryTerm{Key: "label", Op: QueryOpEqual, Value: "cat"}}, r)
+		assert.Equal(t, "-label:cat", r.String())
+	})
+	t.Run("negation", func(t *testing.T) {
+		r, err := ParseQueryExpr("label:dog -label:cat")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, QueryAnd{
+			QueryTerm{Key: "label", Op: QueryOpEqual, Value: "dog"},
+			QueryNot{Expr: QueryTerm{Key: "label", Op: QueryOpEqual, Value: "cat"}},
+		}, r)
+	})
+	t.Run("negated group", func(t *testing.T) {
+		r, err := ParseQueryExpr("-(label:cat OR label:dog)")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, QueryNot{Expr: QueryOr{
+			QueryTerm{Key: "label", Op: QueryOpEqual, Value: "cat"},
+			QueryTerm{Key: "label", Op: QueryOpEqual, Value: "dog"},
+		}}, r)
+		assert.Equal(t, "-(label:cat OR label:dog)", r.String())
+	})
+	t.Run("quoted phrase", func(t *testing.T) {
+		r, err := ParseQueryExpr(`"black cat" OR title:"Cat (Black)"`)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, QueryOr{
+			QueryTerm{Op: QueryOpEqual, Value: "black cat"},
+			QueryTerm{Key: "title", Op: QueryOpEqual, Value: "Cat (Black)"},
+		}, r)
+		assert.Equal(t, `"black cat" OR title:"Cat (Black)"`, r.String())
+	})
+	t.Run("quoted operator", func(t *testing.T) {
+		r, err := ParseQueryExpr(`"OR" title:">10"`)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, QueryAnd{
+			QueryTerm{Op: QueryOpEqual, Value: "OR"},
+			QueryTerm{Key: "title", Op: QueryOpEqual, Value: ">10"},
+		}, r)
+	})
+	t.Run("number range", func(t *testing.T) {
+		r, err := ParseQueryExpr("iso:100-400")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, QueryTerm{Key: "iso", Op: QueryOpRange, Value: "100", Max: "400"}, r)
+		assert.Equal(t, "iso:100..400", r.String())
+	})
+	t.Run("decimal range", func(t *testing.T) {
+		r, err := ParseQueryExpr("f:1.8..2.8")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, QueryTerm{Key: "f", Op: QueryOpRange, Value: "1.8", Max: "2.8"}, r)
+	})
+	t.Run("date range", func(t *testing.T) {
+		r, err := ParseQueryExpr("taken:2019-06..2019-08")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, QueryTerm{Key: "taken", Op: QueryOpRange, Value: "2019-06", Max: "2019-08"}, r)
+	})
+	t.Run("open range", func(t *testing.T) {
+		r, err := ParseQueryExpr("iso:800.. taken:..2019")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, QueryAnd{
+			QueryTerm{Key: "iso", Op: QueryOpGreaterEqual, Value: "800"},
+			QueryTerm{Key: "taken", Op: QueryOpLessEqual, Value: "2019"},
+		}, r)
+	})
+	t.Run("comparison", func(t *testing.T) {
+		r, err := ParseQueryExpr("iso:>400 iso:<=1600 mm:<50 quality:>=3 f:=2.8 taken:>2020-01-01")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, QueryAnd{
+			QueryTerm{Key: "iso", Op: QueryOpGreater, Value: "400"},
+			QueryTerm{Key: "iso", Op: QueryOpLessEqual, Value: "1600"},
+			QueryTerm{Key: "mm", Op: QueryOpLess, Value: "50"},
+			QueryTerm{Key: "quality", Op: QueryOpGreaterEqual, Value: "3"},
+			QueryTerm{Key: "f", Op: QueryOpEqual, Value: "2.8"},
+			QueryTerm{Key: "taken", Op: QueryOpGreater, Value: "2020-01-01"},
+		}, r)
+		assert.Equal(t, "iso:>400 iso:<=1600 mm:<50 quality:>=3 f:2.8 taken:>2020-01-01", r.String())
+	})
+	t.Run("date is not a number range", func(t *testing.T) {
+		r, err := ParseQueryExpr("taken:2019-06")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, QueryTerm{Key: "taken", Op: QueryOpEqual, Value: "2019-06"}, r)
+	})
+	t.Run("key is case insensitive", func(t *testing.T) {
+		r, err := ParseQueryExpr("Label:Cat")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, QueryTerm{Key: "label", Op: QueryOpEqual, Value: "Cat"}, r)
+	})
+	t.Run("lowercase or is a word", func(t *testing.T) {
+		r, err := ParseQueryExpr("cat or dog")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, r, 3)
+	})
+	t.Run("errors", func(t *testing.T) {
+		for _, q := range []string{
+			"",
+			"   ",
+			"OR cat",
+			"cat OR",
+			"cat AND",
+			"AND cat",
+			"NOT",
+			"(cat",
+			"cat)",
+			"()",
+			`"cat`,
+			"foo:bar",
+			"label:",
+			"iso:abc",
+			"iso:..",
+			"label:>cat",
+			"favorite:1..2",
+			"taken:2019-13",
+			"taken:yesterday",
+		} {
+			_, err := ParseQueryExpr(q)
+			assert.Error(t, err, q)
+		}
+	})
+}
+
+func TestIsQueryExpr(t *testing.T) {
+	t.Run("simple", func(t *testing.T) {
+		assert.False(t, IsQueryExpr(""))
+		assert.False(t, IsQueryExpr("cat dog"))
+		assert.False(t, IsQueryExpr("label:cat favorite:true"))
+		assert.False(t, IsQueryExpr("year:2019 camera:1000003"))
+		assert.False(t, IsQueryExpr("sea-side"))
+		assert.False(t, IsQueryExpr(`title:"a OR b"`))
+		assert.False(t, IsQueryExpr(`"cat`))
+	})
+	t.Run("expressions", func(t *testing.T) {
+		assert.True(t, IsQueryExpr("label:cat OR label:dog"))
+		assert.True(t, IsQueryExpr("cat AND dog"))
+		assert.True(t, IsQueryExpr("NOT cat"))
+		assert.True(t, IsQueryExpr("-label:cat"))
+		assert.True(t, IsQueryExpr("(cat)"))
+		assert.True(t, IsQueryExpr("iso:100-400"))
+		assert.True(t, IsQueryExpr("year:>2015"))
+		assert.True(t, IsQueryExpr("taken:2019-06..2019-08"))
+		assert.True(t, IsQueryExpr("keyword:sunset"))
+		assert.True(t, IsQueryExpr("camera:canon"))
+	})
+}
+
+func TestQueryDateRange(t *testing.T) {
+	t.Run("year", func(t *testing.T) {
+		start, end, err := QueryDateRange("2019")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), start)
+		assert.Equal(t, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), end)
+	})
+	t.Run("month", func(t *testing.T) {
+		start, end, err := QueryDateRange("2019-12")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, time.Date(2019, 12, 1, 0, 0, 0, 0, time.UTC), start)
+		assert.Equal(t, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), end)
+	})
+	t.Run("day", func(t *testing.T) {
+		start, end, err := QueryDateRange("2019-06-30")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, time.Date(2019, 6, 30, 0, 0, 0, 0, time.UTC), start)
+		assert.Equal(t, time.Date(2019, 7, 1, 0, 0, 0, 0, time.UTC), end)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, _, err := QueryDateRange("June")
+		assert.Error(t, err)
+	})
+}
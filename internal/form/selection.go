@@ -8,6 +8,8 @@ type Selection struct {
 	Albums []string `json:"albums"`
 	Labels []string `json:"labels"`
 	Places []string `json:"places"`
+	Owner  string   `json:"-"` // Restricts results to photos owned by this user UID.
+	Shared string   `json:"-"` // Album UIDs shared with the owner, comma separated.
 }
 
 func (f Selection) Empty() bool {
@@ -33,7 +33,7 @@ func Serialize(f interface{}, all bool) string {
 		fieldInfo := v.Type().Field(i).Tag.Get("serialize")
 
 		// Serialize field values as string.
-		if fieldName != "" && (fieldInfo != "-" || all) {
+		if fieldName != "" && fieldName != "-" && (fieldInfo != "-" || all) {
 			switch t := fieldValue.Interface().(type) {
 			case time.Time:
 				if val := fieldValue.Interface().(time.Time); !val.IsZero() {
@@ -95,7 +95,10 @@ func Unserialize(f SearchForm, q string) (result error) {
 				field := formValues.FieldByName(fieldName)
 				stringValue := string(value)
 
-				if field.CanSet() {
+				// Fields without a form name can't be set from a query string.
+				if sf, ok := formValues.Type().FieldByName(fieldName); ok && sf.Tag.Get("form") == "-" {
+					result = fmt.Errorf("unknown filter: %s", fieldName)
+				} else if field.CanSet() {
 					switch field.Interface().(type) {
 					case time.Time:
 						if timeValue, err := dateparse.ParseAny(stringValue); err != nil {
@@ -0,0 +1,7 @@
+package form
+
+// TwoFactor represents a form for enabling and disabling two-factor authentication.
+type TwoFactor struct {
+	Code     string `json:"code"`
+	Password string `json:"password"`
+}
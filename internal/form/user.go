@@ -0,0 +1,31 @@
+package form
+
+import "github.com/ulule/deepcopier"
+
+// User represents a user account form.
+type User struct {
+	UserName    string `json:"UserName"`
+	FirstName   string `json:"FirstName"`
+	LastName    string `json:"LastName"`
+	DisplayName string `json:"DisplayName"`
+	UserEmail   string `json:"Email"`
+	UserInfo    string `json:"Info"`
+	UserActive  bool   `json:"Active"`
+	RoleAdmin   bool   `json:"Admin"`
+	RoleGuest   bool   `json:"Guest"`
+	RoleChild   bool   `json:"Child"`
+	RoleFamily  bool   `json:"Family"`
+	RoleFriend  bool   `json:"Friend"`
+	CanEdit     bool   `json:"CanEdit"`
+	CanComment  bool   `json:"CanComment"`
+	CanUpload   bool   `json:"CanUpload"`
+	CanDownload bool   `json:"CanDownload"`
+	WebDAV      bool   `json:"WebDAV"`
+	Password    string `json:"Password,omitempty"`
+}
+
+func NewUser(m interface{}) (f User, err error) {
+	err = deepcopier.Copy(m).To(&f)
+
+	return f, err
+}
@@ -0,0 +1,27 @@
+package form
+
+// UserSearch represents search form fields for "/api/v1/users".
+type UserSearch struct {
+	Query  string `form:"q"`
+	Role   string `form:"role"`
+	Active bool   `form:"active"`
+	Count  int    `form:"count" binding:"required" serialize:"-"`
+	Offset int    `form:"offset" serialize:"-"`
+	Order  string `form:"order" serialize:"-"`
+}
+
+func (f *UserSearch) GetQuery() string {
+	return f.Query
+}
+
+func (f *UserSearch) SetQuery(q string) {
+	f.Query = q
+}
+
+func (f *UserSearch) ParseQueryString() error {
+	return ParseQueryString(f)
+}
+
+func NewUserSearch(query string) UserSearch {
+	return UserSearch{Query: query}
+}
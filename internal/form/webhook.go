@@ -0,0 +1,11 @@
+package form
+
+// Webhook represents a form for creating and updating outgoing webhooks.
+type Webhook struct {
+	Name       string `json:"Name"`
+	URL        string `json:"URL"`
+	Events     string `json:"Events"`
+	Secret     string `json:"Secret"`
+	Enabled    bool   `json:"Enabled"`
+	RetryLimit int    `json:"RetryLimit"`
+}
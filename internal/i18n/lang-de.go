@@ -11,6 +11,7 @@ var MsgGerman = MessageMap{
 	ErrAlbumNotFound:      "Album nicht gefunden - gelöscht?",
 	ErrReadOnly:           "Funktion im 'read-only' Modus nicht verfügbar",
 	ErrUnauthorized:       "Anmeldung erforderlich",
+	ErrForbidden:          "Zugriff verweigert",
 	ErrOffensiveUpload:    "Inhalt könnte anstößig sein und wurde abgelehnt",
 	ErrNoItemsSelected:    "Auswahl ist leer, bitte erneut versuchen",
 	ErrCreateFile:         "Datei konnte nicht angelegt werden",
@@ -29,6 +30,9 @@ var MsgGerman = MessageMap{
 	ErrNoAlbumsSelected:   "Keine Alben ausgewählt",
 	ErrNoFilesForDownload: "Nicht zum Download verfügbar",
 	ErrZipFailed:          "Zip-Datei konnte nicht erstellt werden",
+	ErrTooManyRequests:    "Zu viele Fehlversuche, bitte später erneut versuchen",
+	ErrInvalidCode:        "Ungültiger Bestätigungscode, bitte erneut versuchen",
+	ErrBusy:               "Beschäftigt, bitte später erneut versuchen",
 
 	// Info and confirmation messages:
 	MsgChangesSaved:          "Änderungen erfolgreich gespeichert",
@@ -64,4 +68,8 @@ var MsgGerman = MessageMap{
 	MsgSelectionProtected:    "Auswahl als privat markiert",
 	MsgAlbumsDeleted:         "Alben gelöscht",
 	MsgZipCreatedIn:          "Zip-Datei erstellt in %d s",
+	MsgUserCreated:           "Nutzer angelegt",
+	MsgUserSaved:             "Nutzer gespeichert",
+	MsgUserDeleted:           "Nutzer %s gelöscht",
+	MsgFacesSaved:            "Gesichter gespeichert",
 }
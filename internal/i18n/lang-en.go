@@ -17,6 +17,7 @@ const (
 	ErrPublic
 	ErrReadOnly
 	ErrUnauthorized
+	ErrForbidden
 	ErrOffensiveUpload
 	ErrNoItemsSelected
 	ErrCreateFile
@@ -28,6 +29,9 @@ const (
 	ErrNoAlbumsSelected
 	ErrNoFilesForDownload
 	ErrZipFailed
+	ErrTooManyRequests
+	ErrInvalidCode
+	ErrBusy
 
 	MsgChangesSaved
 	MsgAlbumCreated
@@ -62,6 +66,10 @@ const (
 	MsgSelectionProtected
 	MsgAlbumsDeleted
 	MsgZipCreatedIn
+	MsgUserCreated
+	MsgUserSaved
+	MsgUserDeleted
+	MsgFacesSaved
 )
 
 var MsgEnglish = MessageMap{
@@ -82,6 +90,7 @@ var MsgEnglish = MessageMap{
 	ErrPublic:             "Not available in public mode",
 	ErrReadOnly:           "not available in read-only mode",
 	ErrUnauthorized:       "Please log in and try again",
+	ErrForbidden:          "Permission denied",
 	ErrOffensiveUpload:    "Upload might be offensive",
 	ErrNoItemsSelected:    "No items selected",
 	ErrCreateFile:         "Failed creating file, please check permissions",
@@ -93,6 +102,9 @@ var MsgEnglish = MessageMap{
 	ErrNoAlbumsSelected:   "No albums selected",
 	ErrNoFilesForDownload: "No files available for download",
 	ErrZipFailed:          "Failed to create zip file",
+	ErrTooManyRequests:    "Too many failed attempts, please try again later",
+	ErrInvalidCode:        "Invalid verification code, please try again",
+	ErrBusy:               "Busy, please try again later",
 
 	// Info and confirmation messages:
 	MsgChangesSaved:          "Changes successfully saved",
@@ -128,4 +140,8 @@ var MsgEnglish = MessageMap{
 	MsgSelectionProtected:    "Selection marked as private",
 	MsgAlbumsDeleted:         "Albums deleted",
 	MsgZipCreatedIn:          "Zip created in %d s",
+	MsgUserCreated:           "User created",
+	MsgUserSaved:             "User saved",
+	MsgUserDeleted:           "User %s deleted",
+	MsgFacesSaved:            "Faces saved",
 }
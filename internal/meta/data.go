@@ -43,6 +43,7 @@ type Data struct {
 	Height       int           `meta:"PixelYDimension,ImageHeight,ImageLength,ExifImageHeight,SourceImageHeight"`
 	Orientation  int           `meta:"-"`
 	Rotation     int           `meta:"Rotation"`
+	Regions      Regions       `meta:"-"`
 	Error        error         `meta:"-"`
 	All          map[string]string
 }
@@ -0,0 +1,87 @@
+package meta
+
+import (
+	"strings"
+)
+
+// Region types.
+const (
+	RegionTypeFace = "Face"
+)
+
+// Minimum intersection over union for two regions to be considered the same.
+const RegionOverlap = 0.5
+
+type Regions []Region
+
+// Region represents a named image region like a face, coordinates are relative
+// to the image size with the origin in the top left corner.
+type Region struct {
+	Name string
+	Type string
+	X    float32
+	Y    float32
+	W    float32
+	H    float32
+}
+
+// Valid returns true if the region is within the image bounds and has a size.
+func (r Region) Valid() bool {
+	return r.W > 0 && r.H > 0 && r.X >= 0 && r.Y >= 0 && r.X+r.W <= 1.01 && r.Y+r.H <= 1.01
+}
+
+// Face returns true if it's a face region.
+func (r Region) Face() bool {
+	return r.Type == "" || strings.EqualFold(r.Type, RegionTypeFace)
+}
+
+// Overlap returns the intersection over union of both regions.
+func (r Region) Overlap(other Region) float32 {
+	x1, y1 := max32(r.X, other.X), max32(r.Y, other.Y)
+	x2, y2 := min32(r.X+r.W, other.X+other.W), min32(r.Y+r.H, other.Y+other.H)
+
+	if x2 <= x1 || y2 <= y1 {
+		return 0
+	}
+
+	intersection := (x2 - x1) * (y2 - y1)
+	union := r.W*r.H + other.W*other.H - intersection
+
+	if union <= 0 {
+		return 0
+	}
+
+	return intersection / union
+}
+
+// Overlaps returns true if the region is considered to be the same as the other.
+func (r Region) Overlaps(other Region) bool {
+	return r.Overlap(other) >= RegionOverlap
+}
+
+// Faces returns named face regions.
+func (r Regions) Faces() (result Regions) {
+	for _, region := range r {
+		if region.Name != "" && region.Face() && region.Valid() {
+			result = append(result, region)
+		}
+	}
+
+	return result
+}
+
+func min32(a, b float32) float32 {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+func max32(a, b float32) float32 {
+	if a > b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,47 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegion_Overlap(t *testing.T) {
+	t.Run("same", func(t *testing.T) {
+		r := Region{X: 0.1, Y: 0.1, W: 0.2, H: 0.2}
+
+		assert.InEpsilon(t, 1, r.Overlap(r), 0.001)
+		assert.True(t, r.Overlaps(r))
+	})
+
+	t.Run("partial", func(t *testing.T) {
+		a := Region{X: 0.1, Y: 0.1, W: 0.2, H: 0.2}
+		b := Region{X: 0.2, Y: 0.1, W: 0.2, H: 0.2}
+
+		assert.InEpsilon(t, 1.0/3.0, a.Overlap(b), 0.001)
+		assert.False(t, a.Overlaps(b))
+	})
+
+	t.Run("none", func(t *testing.T) {
+		a := Region{X: 0.1, Y: 0.1, W: 0.2, H: 0.2}
+		b := Region{X: 0.5, Y: 0.5, W: 0.2, H: 0.2}
+
+		assert.Equal(t, float32(0), a.Overlap(b))
+	})
+}
+
+func TestRegions_Faces(t *testing.T) {
+	regions := Regions{
+		{Name: "Jane", Type: RegionTypeFace, X: 0.1, Y: 0.1, W: 0.2, H: 0.2},
+		{Name: "John", X: 0.5, Y: 0.1, W: 0.2, H: 0.2},
+		{Name: "", Type: RegionTypeFace, X: 0.1, Y: 0.5, W: 0.2, H: 0.2},
+		{Name: "Berlin", Type: "Location", X: 0, Y: 0, W: 1, H: 1},
+		{Name: "Invalid", Type: RegionTypeFace, X: 0.9, Y: 0.9, W: 0.5, H: 0.5},
+	}
+
+	faces := regions.Faces()
+
+	assert.Len(t, faces, 2)
+	assert.Equal(t, "Jane", faces[0].Name)
+	assert.Equal(t, "John", faces[1].Name)
+}
@@ -56,5 +56,9 @@ func (data *Data) XMP(fileName string) (err error) {
 		data.LensModel = doc.LensModel()
 	}
 
+	if regions := doc.Regions(); len(regions) > 0 {
+		data.Regions = regions
+	}
+
 	return nil
 }
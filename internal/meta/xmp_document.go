@@ -188,6 +188,8 @@ type XmpDocument struct {
 					Li   string `xml:"li"` // Gopher
 				} `xml:"Bag" json:"bag,omitempty"`
 			} `xml:"PersonInImage" json:"personinimage,omitempty"`
+			Regions    XmpRegions    `xml:"Regions" json:"regions,omitempty"`
+			RegionInfo XmpRegionInfo `xml:"RegionInfo" json:"regioninfo,omitempty"`
 		} `xml:"Description" json:"description,omitempty"`
 	} `xml:"RDF" json:"rdf,omitempty"`
 }
@@ -0,0 +1,181 @@
+package meta
+
+import (
+	"strconv"
+	"strings"
+)
+
+// XmpRegions represents image regions as specified by the Metadata Working Group (mwg-rs),
+// used by Lightroom, digiKam and Picasa.
+type XmpRegions struct {
+	AppliedToDimensions struct {
+		W    string `xml:"w,attr" json:"w,omitempty"`
+		H    string `xml:"h,attr" json:"h,omitempty"`
+		Unit string `xml:"unit,attr" json:"unit,omitempty"`
+	} `xml:"AppliedToDimensions" json:"appliedtodimensions,omitempty"`
+	RegionList struct {
+		Bag struct {
+			Li []XmpRegion `xml:"li" json:"li,omitempty"`
+		} `xml:"Bag" json:"bag,omitempty"`
+	} `xml:"RegionList" json:"regionlist,omitempty"`
+}
+
+// XmpRegion represents a single mwg-rs region, properties may be stored as
+// attributes, elements or in a nested rdf:Description.
+type XmpRegion struct {
+	XmpRegionProps
+	Description XmpRegionProps `xml:"Description" json:"description,omitempty"`
+}
+
+// XmpRegionProps represents the properties of a mwg-rs region.
+type XmpRegionProps struct {
+	NameAttr string        `xml:"Name,attr" json:"nameattr,omitempty"`
+	TypeAttr string        `xml:"Type,attr" json:"typeattr,omitempty"`
+	Name     string        `xml:"Name" json:"name,omitempty"`
+	Type     string        `xml:"Type" json:"type,omitempty"`
+	Area     XmpRegionArea `xml:"Area" json:"area,omitempty"`
+}
+
+// XmpRegionArea represents a mwg-rs region area, x and y are the center of the area.
+type XmpRegionArea struct {
+	X    string `xml:"x,attr" json:"x,omitempty"`
+	Y    string `xml:"y,attr" json:"y,omitempty"`
+	W    string `xml:"w,attr" json:"w,omitempty"`
+	H    string `xml:"h,attr" json:"h,omitempty"`
+	Unit string `xml:"unit,attr" json:"unit,omitempty"`
+}
+
+// XmpRegionInfo represents Microsoft Photo regions (MP:RegionInfo), used by Windows Photo Gallery.
+type XmpRegionInfo struct {
+	Regions     XmpMPRegions `xml:"Regions" json:"regions,omitempty"`
+	Description struct {
+		Regions XmpMPRegions `xml:"Regions" json:"regions,omitempty"`
+	} `xml:"Description" json:"description,omitempty"`
+}
+
+// XmpMPRegions represents a list of Microsoft Photo regions.
+type XmpMPRegions struct {
+	Bag struct {
+		Li []XmpMPRegion `xml:"li" json:"li,omitempty"`
+	} `xml:"Bag" json:"bag,omitempty"`
+}
+
+// XmpMPRegion represents a single Microsoft Photo region, the rectangle is "x, y, w, h"
+// with x and y being the top left corner.
+type XmpMPRegion struct {
+	RectangleAttr         string `xml:"Rectangle,attr" json:"rectangleattr,omitempty"`
+	PersonDisplayNameAttr string `xml:"PersonDisplayName,attr" json:"persondisplaynameattr,omitempty"`
+	Rectangle             string `xml:"Rectangle" json:"rectangle,omitempty"`
+	PersonDisplayName     string `xml:"PersonDisplayName" json:"persondisplayname,omitempty"`
+}
+
+// Regions returns the image regions found in the document.
+func (doc *XmpDocument) Regions() Regions {
+	return append(doc.MwgRegions(), doc.MPRegions()...)
+}
+
+// MwgRegions returns the mwg-rs image regions found in the document.
+func (doc *XmpDocument) MwgRegions() (result Regions) {
+	regions := doc.RDF.Description.Regions
+
+	width := parseRegionFloat(regions.AppliedToDimensions.W)
+	height := parseRegionFloat(regions.AppliedToDimensions.H)
+
+	for _, li := range regions.RegionList.Bag.Li {
+		props := li.XmpRegionProps
+
+		if li.Description.Area.W != "" {
+			props = li.Description
+		}
+
+		if r, ok := props.Region(width, height); ok {
+			result = append(result, r)
+		}
+	}
+
+	return result
+}
+
+// MPRegions returns the Microsoft Photo regions found in the document.
+func (doc *XmpDocument) MPRegions() (result Regions) {
+	info := doc.RDF.Description.RegionInfo
+	list := info.Regions.Bag.Li
+
+	if len(list) == 0 {
+		list = info.Description.Regions.Bag.Li
+	}
+
+	for _, li := range list {
+		if r, ok := li.Region(); ok {
+			result = append(result, r)
+		}
+	}
+
+	return result
+}
+
+// Region returns the mwg-rs region with relative top left coordinates.
+func (p XmpRegionProps) Region(width, height float32) (r Region, ok bool) {
+	r.Name = SanitizeString(firstNonEmpty(p.NameAttr, p.Name))
+	r.Type = SanitizeString(firstNonEmpty(p.TypeAttr, p.Type))
+
+	w := parseRegionFloat(p.Area.W)
+	h := parseRegionFloat(p.Area.H)
+	x := parseRegionFloat(p.Area.X)
+	y := parseRegionFloat(p.Area.Y)
+
+	// Areas should be normalized, but some tools use pixels.
+	if strings.EqualFold(p.Area.Unit, "pixel") {
+		if width <= 0 || height <= 0 {
+			return r, false
+		}
+
+		w, h, x, y = w/width, h/height, x/width, y/height
+	}
+
+	r.W, r.H = w, h
+	r.X, r.Y = x-w/2, y-h/2
+
+	return r, r.Valid()
+}
+
+// Region returns the Microsoft Photo region with relative top left coordinates.
+func (p XmpMPRegion) Region() (r Region, ok bool) {
+	r.Name = SanitizeString(firstNonEmpty(p.PersonDisplayNameAttr, p.PersonDisplayName))
+	r.Type = RegionTypeFace
+
+	values := strings.Split(firstNonEmpty(p.RectangleAttr, p.Rectangle), ",")
+
+	if len(values) != 4 {
+		return r, false
+	}
+
+	r.X = parseRegionFloat(values[0])
+	r.Y = parseRegionFloat(values[1])
+	r.W = parseRegionFloat(values[2])
+	r.H = parseRegionFloat(values[3])
+
+	return r, r.Valid()
+}
+
+// parseRegionFloat parses a region coordinate, invalid values are returned as 0.
+func parseRegionFloat(s string) float32 {
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 32)
+
+	if err != nil {
+		return 0
+	}
+
+	return float32(f)
+}
+
+// firstNonEmpty returns the first value that isn't empty.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
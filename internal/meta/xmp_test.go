@@ -55,4 +55,47 @@ func TestXMP(t *testing.T) {
 		assert.Equal(t, "iPhone 7 back camera 3.99mm f/1.8", data.LensModel)
 	})
 
+	t.Run("regions-lightroom", func(t *testing.T) {
+		data, err := XMP("testdata/regions-lightroom.xmp")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, data.Regions, 3)
+		assert.Equal(t, "Jane Doe", data.Regions[0].Name)
+		assert.Equal(t, RegionTypeFace, data.Regions[0].Type)
+		assert.InEpsilon(t, 0.25, data.Regions[0].X, 0.001)
+		assert.InEpsilon(t, 0.3, data.Regions[0].Y, 0.001)
+		assert.InEpsilon(t, 0.1, data.Regions[0].W, 0.001)
+		assert.InEpsilon(t, 0.2, data.Regions[0].H, 0.001)
+		assert.Equal(t, "John Doe", data.Regions[1].Name)
+		assert.InEpsilon(t, 0.65, data.Regions[1].X, 0.001)
+		assert.InEpsilon(t, 0.3, data.Regions[1].Y, 0.001)
+
+		faces := data.Regions.Faces()
+
+		assert.Len(t, faces, 2)
+	})
+
+	t.Run("regions-digikam", func(t *testing.T) {
+		data, err := XMP("testdata/regions-digikam.xmp")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, data.Regions, 4)
+
+		faces := data.Regions.Faces()
+
+		assert.Len(t, faces, 3)
+		assert.Equal(t, "Gopher", faces[0].Name)
+		assert.InEpsilon(t, 0.2, faces[0].X, 0.001)
+		assert.Equal(t, "Gopher", faces[1].Name)
+		assert.InEpsilon(t, 0.2, faces[1].X, 0.001)
+		assert.Equal(t, "Ferris", faces[2].Name)
+		assert.InEpsilon(t, 0.6, faces[2].X, 0.001)
+		assert.InEpsilon(t, 0.3, faces[2].H, 0.001)
+	})
 }
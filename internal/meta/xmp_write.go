@@ -0,0 +1,164 @@
+package meta
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Matches the mwg-rs regions of an existing XMP document.
+var xmpRegionsRegexp = regexp.MustCompile(`(?s)[ \t]*<mwg-rs:Regions[\s>].*?</mwg-rs:Regions>[ \t]*\r?\n?`)
+
+// Matches rdf:Description elements without child elements, e.g. after the regions were removed.
+var xmpEmptyDescriptionRegexp = regexp.MustCompile(`[ \t]*<rdf:Description\b([^>]*?)(?:/>|>\s*</rdf:Description>)[ \t]*\r?\n?`)
+
+// Matches XML attribute names.
+var xmpAttrNameRegexp = regexp.MustCompile(`([\w:.-]+)\s*=`)
+
+// Empty XMP document, used if the sidecar file doesn't exist yet.
+const xmpEmpty = `<?xpacket begin="` + "\uFEFF" + `" id="W5M0MpCehiHzreSzNTczkc9d"?>
+<x:xmpmeta xmlns:x="adobe:ns:meta/" x:xmptk="PhotoPrism">
+ <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+ </rdf:RDF>
+</x:xmpmeta>
+<?xpacket end="w"?>
+`
+
+// WriteXmpRegions writes named face regions to an XMP sidecar file, a new file is created if needed.
+// Existing regions of other tools are preserved unless they overlap with one of the new regions.
+// Regions without a name are not written, but remove overlapping regions, e.g. after a person was unlinked.
+func WriteXmpRegions(fileName string, regions Regions, width, height int) error {
+	doc := xmpEmpty
+
+	var existing Regions
+
+	if fs.FileExists(fileName) {
+		data, err := ioutil.ReadFile(fileName)
+
+		if err != nil {
+			return err
+		}
+
+		xmp := XmpDocument{}
+
+		if err := xml.Unmarshal(data, &xmp); err != nil {
+			return fmt.Errorf("metadata: can't parse %s (xmp)", txt.Quote(filepath.Base(fileName)))
+		}
+
+		existing = xmp.MwgRegions()
+		doc = removeEmptyDescriptions(xmpRegionsRegexp.ReplaceAllString(string(data), ""))
+	}
+
+	end := strings.LastIndex(doc, "</rdf:RDF>")
+
+	if end < 0 {
+		return fmt.Errorf("metadata: %s is not a valid xmp file", txt.Quote(filepath.Base(fileName)))
+	}
+
+	var result Regions
+
+	for _, r := range regions {
+		if r.Name != "" {
+			result = append(result, r)
+		}
+	}
+
+	for _, r := range existing {
+		keep := true
+
+		for _, n := range regions {
+			if r.Overlaps(n) {
+				keep = false
+				break
+			}
+		}
+
+		if keep {
+			result = append(result, r)
+		}
+	}
+
+	if len(result) > 0 {
+		doc = doc[:end] + xmpRegionsDescription(result, width, height) + doc[end:]
+	}
+
+	if err := os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(fileName, []byte(doc), 0644)
+}
+
+// removeEmptyDescriptions removes rdf:Description elements that have neither child elements
+// nor property attributes, so that they don't pile up when regions are written again.
+func removeEmptyDescriptions(doc string) string {
+	return xmpEmptyDescriptionRegexp.ReplaceAllStringFunc(doc, func(s string) string {
+		attrs := xmpEmptyDescriptionRegexp.FindStringSubmatch(s)[1]
+
+		for _, m := range xmpAttrNameRegexp.FindAllStringSubmatch(attrs, -1) {
+			if name := m[1]; name != "rdf:about" && name != "xmlns" && !strings.HasPrefix(name, "xmlns:") {
+				return s
+			}
+		}
+
+		return ""
+	})
+}
+
+// xmpRegionsDescription returns a rdf:Description element with mwg-rs regions.
+func xmpRegionsDescription(regions Regions, width, height int) string {
+	var b strings.Builder
+
+	b.WriteString(`<rdf:Description rdf:about=""
+    xmlns:mwg-rs="http://www.metadataworkinggroup.com/schemas/regions/"
+    xmlns:stDim="http://ns.adobe.com/xap/1.0/sType/Dimensions#"
+    xmlns:stArea="http://ns.adobe.com/xmp/sType/Area#">
+   <mwg-rs:Regions rdf:parseType="Resource">
+`)
+
+	if width > 0 && height > 0 {
+		fmt.Fprintf(&b, "    <mwg-rs:AppliedToDimensions stDim:w=\"%d\" stDim:h=\"%d\" stDim:unit=\"pixel\"/>\n", width, height)
+	}
+
+	b.WriteString("    <mwg-rs:RegionList>\n     <rdf:Bag>\n")
+
+	for _, r := range regions {
+		regionType := r.Type
+
+		if regionType == "" {
+			regionType = RegionTypeFace
+		}
+
+		fmt.Fprintf(&b, "      <rdf:li>\n       <rdf:Description mwg-rs:Name=\"%s\" mwg-rs:Type=\"%s\">\n", xmlEscape(r.Name), xmlEscape(regionType))
+		fmt.Fprintf(&b, "        <mwg-rs:Area stArea:x=\"%s\" stArea:y=\"%s\" stArea:w=\"%s\" stArea:h=\"%s\" stArea:unit=\"normalized\"/>\n",
+			formatRegionFloat(r.X+r.W/2), formatRegionFloat(r.Y+r.H/2), formatRegionFloat(r.W), formatRegionFloat(r.H))
+		b.WriteString("       </rdf:Description>\n      </rdf:li>\n")
+	}
+
+	b.WriteString("     </rdf:Bag>\n    </mwg-rs:RegionList>\n   </mwg-rs:Regions>\n  </rdf:Description>\n ")
+
+	return b.String()
+}
+
+// formatRegionFloat formats a relative region coordinate.
+func formatRegionFloat(f float32) string {
+	return strconv.FormatFloat(float64(f), 'f', 6, 32)
+}
+
+// xmlEscape escapes a string for use in XML attributes.
+func xmlEscape(s string) string {
+	var b bytes.Buffer
+
+	_ = xml.EscapeText(&b, []byte(s))
+
+	return b.String()
+}
@@ -0,0 +1,140 @@
+package meta
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteXmpRegions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "xmp")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	t.Run("new", func(t *testing.T) {
+		fileName := filepath.Join(dir, "new.xmp")
+		regions := Regions{{Name: "Jane & John", Type: RegionTypeFace, X: 0.1, Y: 0.2, W: 0.2, H: 0.2}}
+
+		if err := WriteXmpRegions(fileName, regions, 4000, 3000); err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := XMP(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, data.Regions, 1)
+		assert.Equal(t, "Jane & John", data.Regions[0].Name)
+		assert.InEpsilon(t, 0.1, data.Regions[0].X, 0.001)
+		assert.InEpsilon(t, 0.2, data.Regions[0].Y, 0.001)
+
+		// Writing again replaces the regions including their description.
+		if err := WriteXmpRegions(fileName, regions, 4000, 3000); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := ioutil.ReadFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, strings.Count(string(result), `<rdf:Description rdf:about=""`))
+	})
+
+	t.Run("existing", func(t *testing.T) {
+		fileName := filepath.Join(dir, "existing.xmp")
+		src, err := ioutil.ReadFile("testdata/regions-lightroom.xmp")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := ioutil.WriteFile(fileName, src, os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		// Replaces "Jane Doe", keeps "John Doe" and the unnamed region.
+		regions := Regions{{Name: "Jane Roe", Type: RegionTypeFace, X: 0.26, Y: 0.3, W: 0.1, H: 0.2}}
+
+		if err := WriteXmpRegions(fileName, regions, 4000, 3000); err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := XMP(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, data.Regions, 3)
+		assert.Equal(t, "Jane Roe", data.Regions[0].Name)
+		assert.Equal(t, "John Doe", data.Regions[1].Name)
+		assert.Equal(t, "", data.Regions[2].Name)
+
+		result, err := ioutil.ReadFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Other metadata must be preserved.
+		assert.Contains(t, string(result), "Adobe Photoshop Lightroom Classic 9.2 (Macintosh)")
+	})
+
+	t.Run("unnamed", func(t *testing.T) {
+		fileName := filepath.Join(dir, "unnamed.xmp")
+		src, err := ioutil.ReadFile("testdata/regions-digikam.xmp")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := ioutil.WriteFile(fileName, src, os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		// Removes "Gopher" without adding a new region.
+		regions := Regions{{Type: RegionTypeFace, X: 0.2, Y: 0.2, W: 0.1, H: 0.1}}
+
+		if err := WriteXmpRegions(fileName, regions, 1920, 1080); err != nil {
+			t.Fatal(err)
+		}
+
+		doc := XmpDocument{}
+
+		if err := doc.Load(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		result := doc.MwgRegions()
+
+		assert.Len(t, result, 1)
+		assert.Equal(t, "Berlin", result[0].Name)
+	})
+}
+
+func TestRemoveEmptyDescriptions(t *testing.T) {
+	doc := `<rdf:RDF>
+  <rdf:Description rdf:about="" xmlns:mwg-rs="http://www.metadataworkinggroup.com/schemas/regions/">
+  </rdf:Description>
+  <rdf:Description rdf:about="" xmlns:xmp="http://ns.adobe.com/xap/1.0/" xmp:Rating="3"/>
+  <rdf:Description rdf:about=""/>
+ </rdf:RDF>`
+
+	result := removeEmptyDescriptions(doc)
+
+	assert.NotContains(t, result, "mwg-rs")
+	assert.Contains(t, result, `xmp:Rating="3"`)
+	assert.Equal(t, 1, strings.Count(result, "<rdf:Description"))
+}
@@ -5,14 +5,16 @@ import (
 )
 
 var (
-	Db          = sync.Mutex{}
-	MainWorker  = Busy{}
-	SyncWorker  = Busy{}
-	ShareWorker = Busy{}
-	MetaWorker  = Busy{}
+	Db             = sync.Mutex{}
+	MainWorker     = Busy{}
+	SyncWorker     = Busy{}
+	ShareWorker    = Busy{}
+	MetaWorker     = Busy{}
+	BackupWorker   = Busy{}
+	MemoriesWorker = Busy{}
 )
 
 // WorkersBusy returns true if any worker is busy.
 func WorkersBusy() bool {
-	return MainWorker.Busy() || SyncWorker.Busy() || ShareWorker.Busy() || MetaWorker.Busy()
+	return MainWorker.Busy() || SyncWorker.Busy() || ShareWorker.Busy() || MetaWorker.Busy() || BackupWorker.Busy()
 }
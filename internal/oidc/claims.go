@@ -0,0 +1,125 @@
+package oidc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Audience represents the aud claim, which may be a single string or a list.
+type Audience []string
+
+// UnmarshalJSON accepts both a string and a list of strings.
+func (a *Audience) UnmarshalJSON(b []byte) error {
+	var s string
+
+	if err := json.Unmarshal(b, &s); err == nil {
+		*a = Audience{s}
+		return nil
+	}
+
+	var l []string
+
+	if err := json.Unmarshal(b, &l); err != nil {
+		return err
+	}
+
+	*a = l
+
+	return nil
+}
+
+// Contains returns true if the client id is part of the audience.
+func (a Audience) Contains(clientID string) bool {
+	for _, s := range a {
+		if s == clientID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Bool represents a boolean claim, some providers send "true" and "false" as strings.
+type Bool bool
+
+// UnmarshalJSON accepts both a boolean and a string, anything else than true is false.
+func (v *Bool) UnmarshalJSON(b []byte) error {
+	var s string
+
+	if err := json.Unmarshal(b, &s); err == nil {
+		*v = Bool(strings.ToLower(s) == "true")
+		return nil
+	}
+
+	var l bool
+
+	if err := json.Unmarshal(b, &l); err != nil {
+		return err
+	}
+
+	*v = Bool(l)
+
+	return nil
+}
+
+// Claims represents the ID token claims used to identify users.
+type Claims struct {
+	Issuer            string   `json:"iss"`
+	Subject           string   `json:"sub"`
+	Audience          Audience `json:"aud"`
+	Expires           int64    `json:"exp"`
+	IssuedAt          int64    `json:"iat"`
+	Nonce             string   `json:"nonce"`
+	Email             string   `json:"email"`
+	EmailVerified     Bool     `json:"email_verified"`
+	Name              string   `json:"name"`
+	GivenName         string   `json:"given_name"`
+	FamilyName        string   `json:"family_name"`
+	PreferredUsername string   `json:"preferred_username"`
+	Groups            []string `json:"groups"`
+}
+
+// Validate checks issuer, audience, expiration and nonce.
+func (c *Claims) Validate(issuer, clientID, nonce string, now time.Time) error {
+	if strings.TrimRight(c.Issuer, "/") != strings.TrimRight(issuer, "/") {
+		return fmt.Errorf("oidc: unexpected issuer %s", c.Issuer)
+	}
+
+	if !c.Audience.Contains(clientID) {
+		return errors.New("oidc: token was issued for another client")
+	}
+
+	if c.Expires == 0 || now.Add(-Leeway).Unix() > c.Expires {
+		return errors.New("oidc: token has expired")
+	}
+
+	if c.IssuedAt > now.Add(Leeway).Unix() {
+		return errors.New("oidc: token was issued in the future")
+	}
+
+	if nonce == "" || c.Nonce != nonce {
+		return errors.New("oidc: invalid nonce")
+	}
+
+	if c.Subject == "" {
+		return errors.New("oidc: token has no subject")
+	}
+
+	return nil
+}
+
+// UserName returns a suggested user name based on the claims.
+func (c *Claims) UserName() string {
+	if c.PreferredUsername != "" {
+		return c.PreferredUsername
+	}
+
+	if i := strings.Index(c.Email, "@"); i > 0 {
+		return c.Email[:i]
+	}
+
+	return c.Subject
+}
@@ -0,0 +1,349 @@
+/*
+
+Package oidc implements the OpenID Connect authorization code flow for single sign-on.
+
+Copyright (c) 2018 - 2020 Michael Mayer <hello@photoprism.org>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism™ is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.org if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.org/developer-guide/
+
+*/
+package oidc
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+var log = event.Log
+
+// Scopes requested from the identity provider.
+var Scopes = []string{"openid", "profile", "email", "groups"}
+
+// Allowed clock skew when validating token timestamps.
+const Leeway = time.Minute
+
+// Discovery represents the relevant parts of the provider metadata.
+type Discovery struct {
+	Issuer        string `json:"issuer"`
+	AuthEndpoint  string `json:"authorization_endpoint"`
+	TokenEndpoint string `json:"token_endpoint"`
+	JwksURI       string `json:"jwks_uri"`
+}
+
+// Provider represents an OpenID Connect identity provider.
+type Provider struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Client       *http.Client
+
+	discovery *Discovery
+	keys      map[string]*rsa.PublicKey
+	mutex     sync.Mutex
+}
+
+// New returns a new provider, metadata is loaded on first use.
+func New(issuer, clientID, clientSecret, redirectURL string) *Provider {
+	return &Provider{
+		Issuer:       strings.TrimRight(issuer, "/"),
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURL:  redirectURL,
+		Client:       &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// Discover loads the provider metadata from the well-known configuration endpoint.
+func (p *Provider) Discover() (*Discovery, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	result := &Discovery{}
+
+	if err := p.getJSON(p.Issuer+"/.well-known/openid-configuration", result); err != nil {
+		return nil, fmt.Errorf("oidc: discovery failed (%s)", err)
+	}
+
+	if strings.TrimRight(result.Issuer, "/") != p.Issuer {
+		return nil, fmt.Errorf("oidc: issuer %s does not match %s", result.Issuer, p.Issuer)
+	}
+
+	if result.AuthEndpoint == "" || result.TokenEndpoint == "" || result.JwksURI == "" {
+		return nil, errors.New("oidc: incomplete provider metadata")
+	}
+
+	p.discovery = result
+
+	return result, nil
+}
+
+// AuthURL returns the provider URL users must be redirected to for login.
+func (p *Provider) AuthURL(state, nonce string) (string, error) {
+	d, err := p.Discover()
+
+	if err != nil {
+		return "", err
+	}
+
+	q := url.Values{}
+	q.Set("response_type", "code")
+	q.Set("client_id", p.ClientID)
+	q.Set("redirect_uri", p.RedirectURL)
+	q.Set("scope", strings.Join(Scopes, " "))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+
+	if strings.Contains(d.AuthEndpoint, "?") {
+		return d.AuthEndpoint + "&" + q.Encode(), nil
+	}
+
+	return d.AuthEndpoint + "?" + q.Encode(), nil
+}
+
+// Exchange redeems an authorization code and returns the raw ID token.
+func (p *Provider) Exchange(code string) (string, error) {
+	d, err := p.Discover()
+
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.RedirectURL)
+
+	req, err := http.NewRequest(http.MethodPost, d.TokenEndpoint, strings.NewReader(form.Encode()))
+
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(p.ClientID), url.QueryEscape(p.ClientSecret))
+
+	resp, err := p.Client.Do(req)
+
+	if err != nil {
+		return "", fmt.Errorf("oidc: token request failed (%s)", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("oidc: token request failed with status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		IDToken string `json:"id_token"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("oidc: invalid token response (%s)", err)
+	}
+
+	if result.IDToken == "" {
+		return "", errors.New("oidc: token response contains no id token")
+	}
+
+	return result.IDToken, nil
+}
+
+// Login exchanges the authorization code and returns the verified claims.
+func (p *Provider) Login(code, nonce string) (*Claims, error) {
+	token, err := p.Exchange(code)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return p.Verify(token, nonce)
+}
+
+// Verify checks the signature and claims of an ID token and returns the claims.
+func (p *Provider) Verify(token, nonce string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+
+	if len(parts) != 3 {
+		return nil, errors.New("oidc: malformed id token")
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("oidc: invalid token header (%s)", err)
+	}
+
+	if header.Alg != "RS256" {
+		return nil, fmt.Errorf("oidc: unsupported signing algorithm %s", header.Alg)
+	}
+
+	key, err := p.key(header.Kid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+
+	if err != nil {
+		return nil, fmt.Errorf("oidc: invalid token signature (%s)", err)
+	}
+
+	hash := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, hash[:], sig); err != nil {
+		return nil, errors.New("oidc: invalid token signature")
+	}
+
+	claims := &Claims{}
+
+	if err := decodeSegment(parts[1], claims); err != nil {
+		return nil, fmt.Errorf("oidc: invalid token claims (%s)", err)
+	}
+
+	if err := claims.Validate(p.Issuer, p.ClientID, nonce, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// key returns the public key with the given id, keys are reloaded once if not found.
+func (p *Provider) key(kid string) (*rsa.PublicKey, error) {
+	d, err := p.Discover()
+
+	if err != nil {
+		return nil, err
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if k, ok := p.keys[kid]; ok {
+		return k, nil
+	}
+
+	var jwks struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+
+	if err := p.getJSON(d.JwksURI, &jwks); err != nil {
+		return nil, fmt.Errorf("oidc: failed loading keys (%s)", err)
+	}
+
+	p.keys = make(map[string]*rsa.PublicKey)
+
+	for _, k := range jwks.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+
+		if err != nil {
+			log.Warnf("oidc: invalid key %s (%s)", k.Kid, err)
+			continue
+		}
+
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+
+		if err != nil {
+			log.Warnf("oidc: invalid key %s (%s)", k.Kid, err)
+			continue
+		}
+
+		p.keys[k.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+
+	if k, ok := p.keys[kid]; ok {
+		return k, nil
+	}
+
+	// Tokens without key id are accepted if the provider only has a single key.
+	if kid == "" && len(p.keys) == 1 {
+		for _, k := range p.keys {
+			return k, nil
+		}
+	}
+
+	return nil, fmt.Errorf("oidc: unknown signing key %s", kid)
+}
+
+// getJSON fetches a URL and decodes the JSON response.
+func (p *Provider) getJSON(u string, result interface{}) error {
+	resp, err := p.Client.Get(u)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// decodeSegment decodes a base64url encoded JSON token segment.
+func decodeSegment(s string, result interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, result)
+}
@@ -0,0 +1,265 @@
+package oidc
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testIdP is a minimal stand-in identity provider for tests.
+type testIdP struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	claims map[string]interface{}
+}
+
+func newTestIdP(t *testing.T) *testIdP {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	idp := &testIdP{key: key}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(Discovery{
+			Issuer:        idp.server.URL,
+			AuthEndpoint:  idp.server.URL + "/authorize",
+			TokenEndpoint: idp.server.URL + "/token",
+			JwksURI:       idp.server.URL + "/jwks",
+		})
+	})
+
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "test",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if id, secret, ok := r.BasicAuth(); !ok || id != "photoprism" || secret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		if r.FormValue("code") != "valid" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]string{"id_token": idp.Token(t, "test", idp.claims)})
+	})
+
+	idp.server = httptest.NewServer(mux)
+
+	return idp
+}
+
+// Token returns a signed ID token.
+func (idp *testIdP) Token(t *testing.T, kid string, claims map[string]interface{}) string {
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": kid})
+	payload, _ := json.Marshal(claims)
+
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	hash := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, idp.key, crypto.SHA256, hash[:])
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+// Claims returns valid default claims.
+func (idp *testIdP) Claims() map[string]interface{} {
+	return map[string]interface{}{
+		"iss":                idp.server.URL,
+		"sub":                "1234",
+		"aud":                "photoprism",
+		"exp":                time.Now().Add(time.Hour).Unix(),
+		"iat":                time.Now().Unix(),
+		"nonce":              "abc",
+		"email":              "jens@example.com",
+		"email_verified":     true,
+		"preferred_username": "jens",
+		"groups":             []string{"family"},
+	}
+}
+
+func TestProvider_AuthURL(t *testing.T) {
+	idp := newTestIdP(t)
+	defer idp.server.Close()
+
+	p := New(idp.server.URL, "photoprism", "secret", "http://localhost:2342/auth/oidc")
+
+	result, err := p.AuthURL("state123", "abc")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Contains(t, result, idp.server.URL+"/authorize?")
+	assert.Contains(t, result, "state=state123")
+	assert.Contains(t, result, "nonce=abc")
+	assert.Contains(t, result, "client_id=photoprism")
+	assert.Contains(t, result, "redirect_uri=http%3A%2F%2Flocalhost%3A2342%2Fauth%2Foidc")
+}
+
+func TestProvider_Login(t *testing.T) {
+	idp := newTestIdP(t)
+	defer idp.server.Close()
+
+	idp.claims = idp.Claims()
+
+	t.Run("success", func(t *testing.T) {
+		p := New(idp.server.URL, "photoprism", "secret", "http://localhost:2342/auth/oidc")
+
+		claims, err := p.Login("valid", "abc")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "1234", claims.Subject)
+		assert.Equal(t, "jens@example.com", claims.Email)
+		assert.True(t, bool(claims.EmailVerified))
+		assert.Equal(t, "jens", claims.UserName())
+		assert.Equal(t, []string{"family"}, claims.Groups)
+	})
+	t.Run("invalid code", func(t *testing.T) {
+		p := New(idp.server.URL, "photoprism", "secret", "http://localhost:2342/auth/oidc")
+
+		_, err := p.Login("invalid", "abc")
+
+		assert.Error(t, err)
+	})
+	t.Run("invalid client secret", func(t *testing.T) {
+		p := New(idp.server.URL, "photoprism", "wrong", "http://localhost:2342/auth/oidc")
+
+		_, err := p.Login("valid", "abc")
+
+		assert.Error(t, err)
+	})
+	t.Run("wrong nonce", func(t *testing.T) {
+		p := New(idp.server.URL, "photoprism", "secret", "http://localhost:2342/auth/oidc")
+
+		_, err := p.Login("valid", "xyz")
+
+		assert.EqualError(t, err, "oidc: invalid nonce")
+	})
+}
+
+func TestProvider_Verify(t *testing.T) {
+	idp := newTestIdP(t)
+	defer idp.server.Close()
+
+	p := New(idp.server.URL, "photoprism", "secret", "")
+
+	t.Run("valid", func(t *testing.T) {
+		claims, err := p.Verify(idp.Token(t, "test", idp.Claims()), "abc")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "1234", claims.Subject)
+	})
+	t.Run("expired", func(t *testing.T) {
+		c := idp.Claims()
+		c["exp"] = time.Now().Add(-time.Hour).Unix()
+
+		_, err := p.Verify(idp.Token(t, "test", c), "abc")
+
+		assert.EqualError(t, err, "oidc: token has expired")
+	})
+	t.Run("audience list", func(t *testing.T) {
+		c := idp.Claims()
+		c["aud"] = []string{"other", "photoprism"}
+
+		_, err := p.Verify(idp.Token(t, "test", c), "abc")
+
+		assert.NoError(t, err)
+	})
+	t.Run("email verified string", func(t *testing.T) {
+		c := idp.Claims()
+		c["email_verified"] = "true"
+
+		claims, err := p.Verify(idp.Token(t, "test", c), "abc")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, bool(claims.EmailVerified))
+	})
+	t.Run("email not verified", func(t *testing.T) {
+		c := idp.Claims()
+		c["email_verified"] = "false"
+
+		claims, err := p.Verify(idp.Token(t, "test", c), "abc")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, bool(claims.EmailVerified))
+	})
+	t.Run("wrong audience", func(t *testing.T) {
+		c := idp.Claims()
+		c["aud"] = "other"
+
+		_, err := p.Verify(idp.Token(t, "test", c), "abc")
+
+		assert.EqualError(t, err, "oidc: token was issued for another client")
+	})
+	t.Run("wrong issuer", func(t *testing.T) {
+		c := idp.Claims()
+		c["iss"] = "https://evil.example.com"
+
+		_, err := p.Verify(idp.Token(t, "test", c), "abc")
+
+		assert.Error(t, err)
+	})
+	t.Run("unknown key", func(t *testing.T) {
+		_, err := p.Verify(idp.Token(t, "other", idp.Claims()), "abc")
+
+		assert.EqualError(t, err, "oidc: unknown signing key other")
+	})
+	t.Run("tampered", func(t *testing.T) {
+		token := idp.Token(t, "test", idp.Claims())
+		c := idp.Claims()
+		c["sub"] = "admin"
+		forged := idp.Token(t, "test", c)
+
+		// Combine the payload of one token with the signature of another.
+		_, err := p.Verify(forged[:strings.LastIndex(forged, ".")]+token[strings.LastIndex(token, "."):], "abc")
+
+		assert.EqualError(t, err, "oidc: invalid token signature")
+	})
+	t.Run("malformed", func(t *testing.T) {
+		_, err := p.Verify("foo.bar", "abc")
+
+		assert.EqualError(t, err, "oidc: malformed id token")
+	})
+}
@@ -0,0 +1,306 @@
+package photoprism
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// BackupFormat is the version of the index backup archive format.
+const BackupFormat = 1
+
+const (
+	backupInfoName    = "backup.json"
+	backupTablePath   = "index"
+	backupSidecarPath = "sidecar"
+	backupBatchSize   = 1000
+)
+
+// BackupInfo represents the manifest of an index backup archive.
+type BackupInfo struct {
+	Format    int            `json:"Format"`
+	Version   string         `json:"Version"`
+	Driver    string         `json:"Driver"`
+	CreatedAt time.Time      `json:"CreatedAt"`
+	Tables    map[string]int `json:"Tables"`
+	Sidecars  int            `json:"Sidecars"`
+}
+
+// BackupFileName returns the default backup file name in a directory.
+func BackupFileName(dir string, t time.Time) string {
+	return filepath.Join(dir, fmt.Sprintf("photoprism-%s.zip", t.UTC().Format("20060102-150405")))
+}
+
+// BackupFiles returns the default backup file names in a directory, sorted from oldest to newest.
+func BackupFiles(dir string) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, "photoprism-*.zip"))
+
+	if err != nil {
+		return matches, err
+	}
+
+	sort.Strings(matches)
+
+	return matches, nil
+}
+
+// BackupExclude contains the names of tables that are not included in backups. Sessions
+// are excluded, as they would allow to log in with a copy of the backup.
+var BackupExclude = map[string]bool{
+	"sessions": true,
+}
+
+// BackupTables returns the names of all tables included in backups sorted by name, and their models.
+func BackupTables() (names []string, models entity.Types) {
+	models = entity.Types{"migrations": &entity.MigrationVersion{}}
+
+	for name, model := range entity.Entities {
+		if !BackupExclude[name] {
+			models[name] = model
+		}
+	}
+
+	for name := range models {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names, models
+}
+
+// Backup represents a worker that writes index backups.
+type Backup struct {
+	conf *config.Config
+}
+
+// NewBackup returns a new index backup worker.
+func NewBackup(conf *config.Config) *Backup {
+	instance := &Backup{
+		conf: conf,
+	}
+
+	return instance
+}
+
+// Start writes a consistent, driver-independent backup of all index entities to a zip file
+// and optionally includes YAML sidecar files of photos.
+func (w *Backup) Start(fileName string, sidecars bool) (info BackupInfo, err error) {
+	if err := mutex.BackupWorker.Start(); err != nil {
+		return info, fmt.Errorf("backup: %s", err)
+	}
+
+	defer mutex.BackupWorker.Stop()
+
+	if err := os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
+		return info, err
+	}
+
+	// Write to a temporary file first, so that incomplete backups can't be restored.
+	tmpName := fileName + ".tmp"
+
+	// Only the owner may read backups, as they contain password hashes and account credentials.
+	f, err := os.OpenFile(tmpName, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+
+	if err != nil {
+		return info, err
+	}
+
+	defer os.Remove(tmpName)
+
+	info, err = w.write(f, sidecars)
+
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return info, err
+	}
+
+	if err := os.Rename(tmpName, fileName); err != nil {
+		return info, err
+	}
+
+	log.Infof("backup: saved %s", txt.Quote(filepath.Base(fileName)))
+
+	return info, nil
+}
+
+// write writes the backup archive, reading all tables in a single transaction.
+func (w *Backup) write(out io.Writer, sidecars bool) (info BackupInfo, err error) {
+	info = BackupInfo{
+		Format:    BackupFormat,
+		Version:   w.conf.Version(),
+		Driver:    entity.DbDialect(),
+		CreatedAt: entity.Timestamp(),
+		Tables:    make(map[string]int),
+	}
+
+	zw := zip.NewWriter(out)
+
+	tx := entity.UnscopedDb().Begin()
+
+	if err := tx.Error; err != nil {
+		return info, err
+	}
+
+	defer tx.Rollback()
+
+	names, models := BackupTables()
+
+	for _, name := range names {
+		if !tx.HasTable(name) {
+			continue
+		}
+
+		zf, err := zw.Create(path.Join(backupTablePath, name+".json"))
+
+		if err != nil {
+			return info, err
+		}
+
+		count, err := backupTable(tx, zf, name, models[name])
+
+		if err != nil {
+			return info, fmt.Errorf("backup: %s (%s)", err, name)
+		}
+
+		info.Tables[name] = count
+
+		log.Debugf("backup: saved %d rows from %s", count, name)
+	}
+
+	if sidecars {
+		if info.Sidecars, err = backupSidecars(tx, zw); err != nil {
+			return info, fmt.Errorf("backup: %s (sidecar files)", err)
+		}
+	}
+
+	zf, err := zw.Create(backupInfoName)
+
+	if err != nil {
+		return info, err
+	}
+
+	if err := json.NewEncoder(zf).Encode(info); err != nil {
+		return info, err
+	}
+
+	return info, zw.Close()
+}
+
+// backupRow returns the column values of an entity by column name.
+func backupRow(db *gorm.DB, value interface{}) map[string]interface{} {
+	row := make(map[string]interface{})
+
+	for _, field := range db.NewScope(value).Fields() {
+		if field.IsNormal && !field.IsIgnored {
+			row[field.DBName] = field.Field.Interface()
+		}
+	}
+
+	return row
+}
+
+// backupOrder returns the primary key columns to sort rows by.
+func backupOrder(db *gorm.DB, model interface{}) string {
+	scope := db.NewScope(model)
+
+	var cols []string
+
+	for _, field := range scope.PrimaryFields() {
+		cols = append(cols, scope.Quote(field.DBName))
+	}
+
+	return strings.Join(cols, ", ")
+}
+
+// backupTable writes all rows of a table as JSON lines.
+func backupTable(db *gorm.DB, w io.Writer, name string, model interface{}) (count int, err error) {
+	enc := json.NewEncoder(w)
+	modelType := reflect.TypeOf(model).Elem()
+	order := backupOrder(db, model)
+
+	for offset := 0; ; offset += backupBatchSize {
+		rows := reflect.New(reflect.SliceOf(modelType))
+
+		q := db.Table(name).Limit(backupBatchSize).Offset(offset)
+
+		if order != "" {
+			q = q.Order(order)
+		}
+
+		if err := q.Find(rows.Interface()).Error; err != nil {
+			return count, err
+		}
+
+		n := rows.Elem().Len()
+
+		for i := 0; i < n; i++ {
+			if err := enc.Encode(backupRow(db, rows.Elem().Index(i).Addr().Interface())); err != nil {
+				return count, err
+			}
+
+			count++
+		}
+
+		if n < backupBatchSize {
+			return count, nil
+		}
+	}
+}
+
+// backupSidecars adds YAML sidecar files of all photos that are not deleted.
+func backupSidecars(db *gorm.DB, zw *zip.Writer) (count int, err error) {
+	for offset := 0; ; offset += backupBatchSize {
+		var photos entity.Photos
+
+		if err := db.Preload("Details").
+			Where("deleted_at IS NULL").
+			Order("id").Limit(backupBatchSize).Offset(offset).
+			Find(&photos).Error; err != nil {
+			return count, err
+		}
+
+		for _, p := range photos {
+			data, err := p.Yaml()
+
+			if err != nil {
+				log.Errorf("backup: %s (yaml of %s)", err, p.PhotoUID)
+				continue
+			}
+
+			zf, err := zw.Create(path.Join(backupSidecarPath, p.PhotoPath, p.PhotoName+fs.YamlExt))
+
+			if err != nil {
+				return count, err
+			}
+
+			if _, err := zf.Write(data); err != nil {
+				return count, err
+			}
+
+			count++
+		}
+
+		if len(photos) < backupBatchSize {
+			return count, nil
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackupFileName(t *testing.T) {
+	result := BackupFileName("/srv/backup", time.Date(2020, 10, 17, 8, 30, 0, 0, time.UTC))
+
+	assert.Equal(t, "/srv/backup/photoprism-20201017-083000.zip", result)
+}
+
+func TestBackupTables(t *testing.T) {
+	names, models := BackupTables()
+
+	assert.Len(t, names, len(entity.Entities)+1-len(BackupExclude))
+	assert.Contains(t, names, "photos")
+	assert.Contains(t, names, "migrations")
+	assert.NotContains(t, names, "sessions")
+	assert.IsType(t, &entity.MigrationVersion{}, models["migrations"])
+}
+
+func TestBackup_Start(t *testing.T) {
+	conf := config.TestConfig()
+
+	fileName := filepath.Join(conf.BackupPath(), "test-backup.zip")
+
+	defer os.Remove(fileName)
+
+	info, err := NewBackup(conf).Start(fileName, true)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, fs.FileExists(fileName))
+
+	if stat, err := os.Stat(fileName); err != nil {
+		t.Fatal(err)
+	} else {
+		assert.Equal(t, os.FileMode(0600), stat.Mode().Perm())
+	}
+
+	assert.Equal(t, BackupFormat, info.Format)
+	assert.Zero(t, info.Tables["sessions"])
+	assert.Greater(t, info.Tables["photos"], 0)
+	assert.Greater(t, info.Tables["albums"], 0)
+	assert.Greater(t, info.Sidecars, 0)
+
+	t.Run("restore", func(t *testing.T) {
+		var photos, albums int
+
+		before := entity.Photo{ID: 1000000}
+
+		if err := before.Find(); err != nil {
+			t.Fatal(err)
+		}
+
+		entity.UnscopedDb().Model(&entity.Photo{}).Count(&photos)
+		entity.UnscopedDb().Model(&entity.Album{}).Count(&albums)
+
+		restored, err := NewRestore(conf).Start(fileName, false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, info.Tables, restored.Tables)
+
+		var restoredPhotos, restoredAlbums int
+
+		entity.UnscopedDb().Model(&entity.Photo{}).Count(&restoredPhotos)
+		entity.UnscopedDb().Model(&entity.Album{}).Count(&restoredAlbums)
+
+		assert.Equal(t, photos, restoredPhotos)
+		assert.Equal(t, albums, restoredAlbums)
+
+		after := entity.Photo{ID: 1000000}
+
+		if err := after.Find(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, before.PhotoUID, after.PhotoUID)
+		assert.Equal(t, before.PhotoTitle, after.PhotoTitle)
+		assert.Equal(t, before.TakenAt.UTC(), after.TakenAt.UTC())
+		assert.Equal(t, before.Details.Keywords, after.Details.Keywords)
+		assert.Equal(t, len(before.Labels), len(after.Labels))
+	})
+	t.Run("invalid file", func(t *testing.T) {
+		_, err := NewRestore(conf).Start(filepath.Join(conf.BackupPath(), "missing.zip"), false)
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,57 @@
+package photoprism
+
+import (
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// SaveFaceRegions writes the named faces of a photo to its XMP sidecar file if enabled,
+// so that other apps like Lightroom or digiKam can use them.
+func SaveFaceRegions(photoUID string) error {
+	if !Config().SidecarXmp() {
+		return nil
+	}
+
+	file, err := query.FileByPhotoUID(photoUID)
+
+	if err != nil {
+		return err
+	}
+
+	faces, err := query.PhotoFaces(photoUID)
+
+	if err != nil {
+		return err
+	}
+
+	regions := make(meta.Regions, 0, len(faces))
+
+	for _, f := range faces {
+		regions = append(regions, meta.Region{
+			Name: f.PersonName,
+			Type: meta.RegionTypeFace,
+			X:    f.FaceX,
+			Y:    f.FaceY,
+			W:    f.FaceW,
+			H:    f.FaceH,
+		})
+	}
+
+	var xmpName string
+
+	if xmp, err := query.XmpFileByPhotoUID(photoUID); err == nil {
+		xmpName = FileName(xmp.FileRoot, xmp.FileName)
+	} else {
+		xmpName = fs.AbsBase(FileName(file.FileRoot, file.FileName), false) + ".xmp"
+	}
+
+	if err := meta.WriteXmpRegions(xmpName, regions, file.FileWidth, file.FileHeight); err != nil {
+		return err
+	}
+
+	log.Infof("faces: updated xmp file %s", txt.Quote(fs.Rel(xmpName, Config().OriginalsPath())))
+
+	return nil
+}
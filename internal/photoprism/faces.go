@@ -0,0 +1,120 @@
+package photoprism
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+// Faces represents a worker that groups similar faces into clusters.
+type Faces struct {
+	conf *config.Config
+}
+
+// NewFaces returns a new face clustering worker.
+func NewFaces(conf *config.Config) *Faces {
+	instance := &Faces{
+		conf: conf,
+	}
+
+	return instance
+}
+
+// Start assigns new faces to the most similar cluster, or creates a new cluster if none is similar enough.
+// Faces added to a cluster that is linked to a person are recognized as this person.
+func (w *Faces) Start() (err error) {
+	if err := mutex.MainWorker.Start(); err != nil {
+		err = fmt.Errorf("faces: %s", err.Error())
+		event.Error(err.Error())
+		return err
+	}
+
+	defer func() {
+		mutex.MainWorker.Stop()
+
+		if err := recover(); err != nil {
+			log.Errorf("faces: %s [panic]", err)
+		} else {
+			runtime.GC()
+		}
+	}()
+
+	clusters, err := query.FaceClusters()
+
+	if err != nil {
+		return err
+	}
+
+	vectors := make([]face.Embedding, len(clusters))
+
+	for i := range clusters {
+		vectors[i] = clusters[i].Vector()
+	}
+
+	added, created, recognized := 0, 0, 0
+
+	for {
+		faces, err := query.UnclusteredFaces(500)
+
+		if err != nil {
+			return err
+		}
+
+		if len(faces) == 0 {
+			break
+		}
+
+		for i := range faces {
+			if mutex.MainWorker.Canceled() {
+				return fmt.Errorf("faces: clustering canceled")
+			}
+
+			f := &faces[i]
+			e := f.Vector()
+
+			best, dist := -1, face.ClusterDist
+
+			for j, v := range vectors {
+				if d := e.Dist(v); d < dist {
+					best, dist = j, d
+				}
+			}
+
+			if best < 0 {
+				cluster := entity.NewFaceCluster()
+
+				if err := cluster.Create(); err != nil {
+					return err
+				}
+
+				clusters = append(clusters, *cluster)
+				vectors = append(vectors, nil)
+				best = len(clusters) - 1
+				created++
+			}
+
+			if err := clusters[best].AddFace(f); err != nil {
+				return err
+			}
+
+			vectors[best] = clusters[best].Vector()
+			added++
+
+			if f.PersonUID != "" {
+				recognized++
+			}
+		}
+	}
+
+	if added > 0 {
+		log.Infof("faces: added %d faces to clusters, %d new clusters, %d faces recognized", added, created, recognized)
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFaces_Start(t *testing.T) {
+	conf := config.TestConfig()
+
+	file := entity.FileFixtures["exampleFileName.jpg"]
+
+	a := entity.NewFace(&file, face.Face{Embedding: face.Embedding{0.6, 0.8, 0}})
+	b := entity.NewFace(&file, face.Face{Embedding: face.Embedding{0.62, 0.78, 0.1}.Normalize()})
+	c := entity.NewFace(&file, face.Face{Embedding: face.Embedding{0, 0, 1}})
+
+	for _, f := range []*entity.Face{a, b, c} {
+		if err := f.Create(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	w := NewFaces(conf)
+
+	if err := w.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	var result entity.Faces
+
+	if err := entity.Db().Where("id IN (?)", []uint{a.ID, b.ID, c.ID}).Order("id").Find(&result).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, result, 3)
+	assert.NotEmpty(t, result[0].ClusterUID)
+	assert.Equal(t, result[0].ClusterUID, result[1].ClusterUID)
+	assert.NotEqual(t, result[0].ClusterUID, result[2].ClusterUID)
+}
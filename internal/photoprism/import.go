@@ -14,6 +14,7 @@ import (
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
@@ -83,7 +84,18 @@ func (imp *Import) Start(opt ImportOptions) map[string]bool {
 		}()
 	}
 
+	if opt.Dest == "" {
+		opt.Dest = imp.conf.Settings().Import.Dest
+	}
+
+	if err := ValidateImportDest(opt.Dest); err != nil {
+		log.Warnf("import: %s, using %s", err, txt.Quote(DefaultImportDest))
+		opt.Dest = DefaultImportDest
+	}
+
 	indexOpt := IndexOptionsAll()
+	indexOpt.UserUID = opt.UserUID
+	indexOpt.LinkUID = opt.LinkUID
 	ignore := fs.NewIgnoreList(fs.IgnoreFile, true, false)
 
 	if err := ignore.Dir(importPath); err != nil {
@@ -211,6 +223,14 @@ func (imp *Import) Start(opt ImportOptions) map[string]bool {
 		if err := entity.UpdatePhotoCounts(); err != nil {
 			log.Errorf("import: %s", err)
 		}
+
+		// Update the search index right away, as the command line may exit before queued changes are saved.
+		if _, err := entity.FlushFullText(); err != nil {
+			log.Errorf("import: %s (update search index)", err)
+		}
+
+		// Groups of near-duplicates must be computed again with the new photos.
+		query.FlushDuplicates()
 	}
 
 	runtime.GC()
@@ -223,11 +243,10 @@ func (imp *Import) Cancel() {
 	mutex.MainWorker.Cancel()
 }
 
-// DestinationFilename returns the destination filename of a MediaFile to be imported.
-func (imp *Import) DestinationFilename(mainFile *MediaFile, mediaFile *MediaFile) (string, error) {
-	fileName := mainFile.CanonicalName()
+// DestinationFilename returns the destination filename of a MediaFile to be imported,
+// based on the destination template in the import options.
+func (imp *Import) DestinationFilename(mainFile *MediaFile, mediaFile *MediaFile, opt ImportOptions) (string, error) {
 	fileExtension := mediaFile.Extension()
-	dateCreated := mainFile.DateCreated()
 
 	if !mediaFile.IsSidecar() {
 		if f, err := entity.FirstFileByHash(mediaFile.Hash()); err == nil {
@@ -240,22 +259,29 @@ func (imp *Import) DestinationFilename(mainFile *MediaFile, mediaFile *MediaFile
 		}
 	}
 
-	//	Mon Jan 2 15:04:05 -0700 MST 2006
-	pathName := filepath.Join(imp.originalsPath(), dateCreated.Format("2006/01"))
+	dest := filepath.Join(imp.originalsPath(), imp.importDest(mainFile, opt))
+	pathName, fileName := filepath.Split(dest)
 
 	iteration := 0
+	baseName := fileName
 
-	result := filepath.Join(pathName, fileName+fileExtension)
+	for {
+		result := filepath.Join(pathName, baseName+fileExtension)
 
-	for fs.FileExists(result) {
-		if mediaFile.Hash() == fs.Hash(result) {
+		if fs.FileExists(result) && mediaFile.Hash() == fs.Hash(result) {
 			return result, fmt.Errorf("%s already exists", txt.Quote(fs.Rel(result, imp.originalsPath())))
 		}
 
+		// Related files must get the same name as the main file, even if only the main file collides.
+		mainResult := filepath.Join(pathName, baseName+mainFile.Extension())
+		mainCollides := fs.FileExists(mainResult) && mainFile.Hash() != fs.Hash(mainResult)
+
+		if !fs.FileExists(result) && !mainCollides {
+			return result, nil
+		}
+
 		iteration++
 
-		result = filepath.Join(pathName, fileName+"."+fmt.Sprintf("%05d", iteration)+fileExtension)
+		baseName = fileName + "." + fmt.Sprintf("%05d", iteration)
 	}
-
-	return result, nil
 }
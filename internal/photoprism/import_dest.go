@@ -0,0 +1,186 @@
+package photoprism
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// DefaultImportDest is the default destination template, relative to the originals folder.
+const DefaultImportDest = "{yyyy}/{mm}/{canonical}"
+
+// ImportDestPlaceholders lists the placeholders that can be used in import destination templates.
+var ImportDestPlaceholders = map[string]string{
+	"yyyy":      "year, e.g. 2020",
+	"yy":        "two digit year, e.g. 20",
+	"mm":        "month, e.g. 07",
+	"month":     "month name, e.g. July",
+	"dd":        "day of month, e.g. 05",
+	"hh":        "hour, e.g. 15",
+	"min":       "minute, e.g. 32",
+	"ss":        "second, e.g. 30",
+	"make":      "camera make, e.g. Canon",
+	"model":     "camera model, e.g. EOS 6D",
+	"country":   "country name, e.g. Germany",
+	"cc":        "country code, e.g. de",
+	"city":      "city name, e.g. Berlin",
+	"place":     "place label, e.g. Berlin, Germany",
+	"name":      "original file name without extension, e.g. IMG_2567",
+	"folder":    "name of the import sub folder, e.g. Birthday",
+	"hash":      "first 8 characters of the SHA1 hash",
+	"canonical": "canonical name, e.g. 20190705_153230_C167C6FD",
+}
+
+var importDestRegexp = regexp.MustCompile(`\{([a-z]+)\}`)
+
+// Characters that are replaced in placeholder values as they can't be used in file names.
+var importDestReplacer = strings.NewReplacer("/", "-", "\\", "-", ":", "-", "*", "-", "?", "", "\"", "", "<", "", ">", "", "|", "-")
+
+// ValidateImportDest returns an error if the import destination template can't be used.
+func ValidateImportDest(dest string) error {
+	dest = strings.TrimSpace(dest)
+
+	if dest == "" {
+		return nil
+	}
+
+	for _, m := range importDestRegexp.FindAllStringSubmatch(dest, -1) {
+		if _, ok := ImportDestPlaceholders[m[1]]; !ok {
+			return fmt.Errorf("unknown placeholder %s in import template", txt.Quote(m[0]))
+		}
+	}
+
+	if strings.HasPrefix(dest, "/") || filepath.IsAbs(dest) {
+		return fmt.Errorf("import template must be relative to originals")
+	}
+
+	for _, s := range strings.Split(dest, "/") {
+		if s == ".." {
+			return fmt.Errorf("import template must not contain parent folders")
+		}
+	}
+
+	if strings.HasSuffix(dest, "/") {
+		return fmt.Errorf("import template must end with a file name")
+	}
+
+	return nil
+}
+
+// importDest renders the destination template for a main media file and returns
+// the file name relative to originals without extension.
+func (imp *Import) importDest(mainFile *MediaFile, opt ImportOptions) string {
+	dest := strings.TrimSpace(opt.Dest)
+
+	if dest == "" {
+		dest = DefaultImportDest
+	}
+
+	date := mainFile.DateCreated()
+	var location *entity.Location
+
+	values := func(name string) string {
+		switch name {
+		case "yyyy":
+			return date.Format("2006")
+		case "yy":
+			return date.Format("06")
+		case "mm":
+			return date.Format("01")
+		case "month":
+			return date.Format("January")
+		case "dd":
+			return date.Format("02")
+		case "hh":
+			return date.Format("15")
+		case "min":
+			return date.Format("04")
+		case "ss":
+			return date.Format("05")
+		case "make":
+			return mainFile.CameraMake()
+		case "model":
+			return mainFile.CameraModel()
+		case "country", "cc", "city", "place":
+			if location == nil {
+				location = imp.importLocation(mainFile)
+			}
+
+			if location.Unknown() {
+				return ""
+			}
+
+			switch name {
+			case "country":
+				return location.CountryName()
+			case "cc":
+				return location.CountryCode()
+			case "city":
+				return location.City()
+			default:
+				return location.Label()
+			}
+		case "name":
+			return mainFile.Base(false)
+		case "folder":
+			if opt.Path == "" {
+				return ""
+			}
+
+			if dir := filepath.Dir(mainFile.RelativeName(opt.Path)); dir != "." {
+				return filepath.Base(dir)
+			}
+
+			return ""
+		case "hash":
+			return txt.Clip(mainFile.Hash(), 8)
+		case "canonical":
+			return mainFile.CanonicalName()
+		default:
+			return ""
+		}
+	}
+
+	result := importDestRegexp.ReplaceAllStringFunc(dest, func(s string) string {
+		return importDestReplacer.Replace(strings.TrimSpace(values(s[1 : len(s)-1])))
+	})
+
+	// Remove separators left over from empty values, as well as empty folder names.
+	var parts []string
+
+	for _, s := range strings.Split(result, "/") {
+		s = strings.TrimRight(strings.Trim(s, " -."), "_")
+
+		if s != "" {
+			parts = append(parts, s)
+		}
+	}
+
+	if len(parts) == 0 {
+		return mainFile.CanonicalName()
+	}
+
+	return filepath.Join(parts...)
+}
+
+// importLocation returns the location where the media file was taken, if known.
+func (imp *Import) importLocation(m *MediaFile) *entity.Location {
+	data := m.MetaData()
+
+	if data.Lat == 0 && data.Lng == 0 {
+		return &entity.UnknownLocation
+	}
+
+	location := entity.NewLocation(data.Lat, data.Lng)
+
+	if err := location.Find(imp.conf.GeoCodingApi()); err != nil {
+		log.Warnf("import: %s (find location)", err)
+		return &entity.UnknownLocation
+	}
+
+	return location
+}
@@ -4,9 +4,12 @@ type ImportOptions struct {
 	Albums                 []string
 	Path                   string
 	Move                   bool
+	Dest                   string
 	RemoveDotFiles         bool
 	RemoveExistingFiles    bool
 	RemoveEmptyDirectories bool
+	UserUID                string
+	LinkUID                string
 }
 
 // ImportOptionsCopy returns import options for copying files to originals (read-only).
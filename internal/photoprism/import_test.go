@@ -5,6 +5,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/nsfw"
 	"github.com/stretchr/testify/assert"
 )
@@ -14,9 +15,10 @@ func TestNewImport(t *testing.T) {
 
 	tf := classify.New(conf.AssetsPath(), conf.TensorFlowOff())
 	nd := nsfw.New(conf.NSFWModelPath())
+	fn := face.New(conf.FaceNetModelPath(), conf.TensorFlowOff())
 	convert := NewConvert(conf)
 
-	ind := NewIndex(conf, tf, nd, convert)
+	ind := NewIndex(conf, tf, nd, fn, convert)
 	imp := NewImport(conf, ind, convert)
 
 	assert.IsType(t, &Import{}, imp)
@@ -29,9 +31,10 @@ func TestImport_DestinationFilename(t *testing.T) {
 
 	tf := classify.New(conf.AssetsPath(), conf.TensorFlowOff())
 	nd := nsfw.New(conf.NSFWModelPath())
+	fn := face.New(conf.FaceNetModelPath(), conf.TensorFlowOff())
 	convert := NewConvert(conf)
 
-	ind := NewIndex(conf, tf, nd, convert)
+	ind := NewIndex(conf, tf, nd, fn, convert)
 
 	imp := NewImport(conf, ind, convert)
 
@@ -41,13 +44,51 @@ func TestImport_DestinationFilename(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	fileName, err := imp.DestinationFilename(rawFile, rawFile)
+	t.Run("default", func(t *testing.T) {
+		fileName, err := imp.DestinationFilename(rawFile, rawFile, ImportOptionsCopy(conf.ImportPath()))
 
-	if err != nil {
-		t.Fatal(err)
-	}
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, conf.OriginalsPath()+"/2019/07/20190705_153230_C167C6FD.cr2", fileName)
+	})
+
+	t.Run("template", func(t *testing.T) {
+		opt := ImportOptionsCopy(conf.ImportPath())
+		opt.Dest = "{yyyy}/{yyyy}-{mm}-{dd} {folder}/{name}"
+
+		fileName, err := imp.DestinationFilename(rawFile, rawFile, opt)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, conf.OriginalsPath()+"/2019/2019-07-05 raw/IMG_2567.cr2", fileName)
+	})
+
+	t.Run("empty values", func(t *testing.T) {
+		opt := ImportOptionsCopy(conf.ImportPath())
+		opt.Dest = "{yyyy}/{mm} - {country}/{hash}"
+
+		fileName, err := imp.DestinationFilename(rawFile, rawFile, opt)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, conf.OriginalsPath()+"/2019/07/"+rawFile.Hash()[:8]+".cr2", fileName)
+	})
+}
 
-	assert.Equal(t, conf.OriginalsPath()+"/2019/07/20190705_153230_C167C6FD.cr2", fileName)
+func TestValidateImportDest(t *testing.T) {
+	assert.NoError(t, ValidateImportDest(""))
+	assert.NoError(t, ValidateImportDest(DefaultImportDest))
+	assert.NoError(t, ValidateImportDest("{yyyy}/{yyyy}-{mm}-{dd} {folder}/{model}_{name}"))
+	assert.Error(t, ValidateImportDest("{yyyy}/{foo}"))
+	assert.Error(t, ValidateImportDest("/{yyyy}/{name}"))
+	assert.Error(t, ValidateImportDest("../{name}"))
+	assert.Error(t, ValidateImportDest("{yyyy}/"))
 }
 
 func TestImport_Start(t *testing.T) {
@@ -61,9 +102,10 @@ func TestImport_Start(t *testing.T) {
 
 	tf := classify.New(conf.AssetsPath(), conf.TensorFlowOff())
 	nd := nsfw.New(conf.NSFWModelPath())
+	fn := face.New(conf.FaceNetModelPath(), conf.TensorFlowOff())
 	convert := NewConvert(conf)
 
-	ind := NewIndex(conf, tf, nd, convert)
+	ind := NewIndex(conf, tf, nd, fn, convert)
 
 	imp := NewImport(conf, ind, convert)
 
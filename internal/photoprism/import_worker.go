@@ -43,7 +43,7 @@ func ImportWorker(jobs <-chan ImportJob) {
 		for _, f := range related.Files {
 			relativeFilename := f.RelativeName(importPath)
 
-			if destinationFilename, err := imp.DestinationFilename(related.Main, f); err == nil {
+			if destinationFilename, err := imp.DestinationFilename(related.Main, f, opt); err == nil {
 				if err := os.MkdirAll(path.Dir(destinationFilename), os.ModePerm); err != nil {
 					log.Errorf("import: could not create folders (%s)", err.Error())
 				}
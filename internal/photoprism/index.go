@@ -13,6 +13,7 @@ import (
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/nsfw"
 	"github.com/photoprism/photoprism/internal/query"
@@ -25,17 +26,19 @@ type Index struct {
 	conf         *config.Config
 	tensorFlow   *classify.TensorFlow
 	nsfwDetector *nsfw.Detector
+	faceNet      *face.Net
 	convert      *Convert
 	db           *gorm.DB
 	q            *query.Query
 }
 
 // NewIndex returns a new indexer and expects its dependencies as arguments.
-func NewIndex(conf *config.Config, tensorFlow *classify.TensorFlow, nsfwDetector *nsfw.Detector, convert *Convert) *Index {
+func NewIndex(conf *config.Config, tensorFlow *classify.TensorFlow, nsfwDetector *nsfw.Detector, faceNet *face.Net, convert *Convert) *Index {
 	i := &Index{
 		conf:         conf,
 		tensorFlow:   tensorFlow,
 		nsfwDetector: nsfwDetector,
+		faceNet:      faceNet,
 		convert:      convert,
 		db:           conf.Db(),
 		q:            query.New(conf.Db()),
@@ -184,6 +187,14 @@ func (ind *Index) Start(opt IndexOptions) map[string]bool {
 		if err := entity.UpdatePhotoCounts(); err != nil {
 			log.Errorf("index: %s", err)
 		}
+
+		// Update the search index right away, as the command line may exit before queued changes are saved.
+		if _, err := entity.FlushFullText(); err != nil {
+			log.Errorf("index: %s (update search index)", err)
+		}
+
+		// Groups of near-duplicates must be computed again with the new photos.
+		query.FlushDuplicates()
 	}
 
 	runtime.GC()
@@ -8,8 +8,21 @@ import (
 
 func (ind *Index) estimateLocation(photo *entity.Photo) {
 	var recentPhoto entity.Photo
+	var dateExpr string
 
-	if result := ind.db.Unscoped().Order(gorm.Expr("ABS(DATEDIFF(taken_at, ?)) ASC", photo.TakenAt)).Preload("Place").First(&recentPhoto); result.Error == nil {
+	switch ind.db.Dialect().GetName() {
+	case entity.MySQL:
+		dateExpr = "ABS(DATEDIFF(taken_at, ?)) ASC"
+	case entity.SQLite:
+		dateExpr = "ABS(JulianDay(taken_at) - JulianDay(?)) ASC"
+	case entity.Postgres:
+		dateExpr = "ABS(EXTRACT(EPOCH FROM (taken_at - ?))) ASC"
+	default:
+		log.Errorf("index: unknown sql dialect %s", ind.db.Dialect().GetName())
+		return
+	}
+
+	if result := ind.db.Unscoped().Order(gorm.Expr(dateExpr, photo.TakenAt)).Preload("Place").First(&recentPhoto); result.Error == nil {
 		if recentPhoto.HasPlace() {
 			photo.Place = recentPhoto.Place
 			photo.PhotoCountry = photo.Place.LocCountry
@@ -12,6 +12,7 @@ import (
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/meta"
 	"github.com/photoprism/photoprism/internal/nsfw"
 	"github.com/photoprism/photoprism/internal/query"
@@ -66,6 +67,10 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 	photo := entity.NewPhoto()
 	metaData := meta.Data{}
 	labels := classify.Labels{}
+	faces := face.Faces{}
+	facesFound := false
+	regions := meta.Regions{}
+	regionsFound := false
 
 	fileRoot, fileBase, filePath, fileName := m.PathNameInfo()
 
@@ -159,7 +164,7 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 
 	if !file.FilePrimary {
 		if photoExists {
-			if q := entity.UnscopedDb().Where("file_type = 'jpg' AND file_primary = 1 AND photo_id = ?", photo.ID).First(&primaryFile); q.Error != nil {
+			if q := entity.UnscopedDb().Where("file_type = 'jpg' AND file_primary = TRUE AND photo_id = ?", photo.ID).First(&primaryFile); q.Error != nil {
 				file.FilePrimary = m.IsJpeg()
 			}
 		} else {
@@ -198,6 +203,14 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 			file.FileChroma = p.Chroma.Value()
 		}
 
+		// Perceptual hashes to find similar images
+		if dHash, pHash, err := m.PerceptualHashes(Config().ThumbPath()); err != nil {
+			log.Errorf("index: %s for %s", err.Error(), logName)
+		} else {
+			file.FileDHash = dHash.String()
+			file.FilePHash = pHash.String()
+		}
+
 		if m.Width() > 0 && m.Height() > 0 {
 			file.FileWidth = m.Width()
 			file.FileHeight = m.Height()
@@ -227,6 +240,8 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 			if details.NoCopyright() && data.Copyright != "" {
 				details.Copyright = data.Copyright
 			}
+
+			regions, regionsFound = data.Regions, true
 		}
 	case m.IsRaw(), m.IsHEIF(), m.IsImageOther():
 		if metaData := m.MetaData(); metaData.Error == nil {
@@ -378,6 +393,9 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 			if !photoExists && Config().Settings().Features.Private && Config().DetectNSFW() {
 				photo.PhotoPrivate = ind.NSFW(m)
 			}
+
+			// Face detection via TensorFlow, faces are stored after the file was saved.
+			faces, facesFound = ind.detectFaces(m)
 		}
 
 		// read metadata from embedded Exif and JSON sidecar file (if exists)
@@ -489,6 +507,15 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 			return result
 		}
 	} else {
+		if photo.OwnerUID == "" {
+			photo.OwnerUID = o.UserUID
+		}
+
+		// Remember the share link of guest uploads.
+		if photo.LinkUID == "" {
+			photo.LinkUID = o.LinkUID
+		}
+
 		if err := photo.Create(); err != nil {
 			log.Errorf("index: %s", err)
 			result.Status = IndexFailed
@@ -617,6 +644,22 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 	result.FileID = file.ID
 	result.FileUID = file.FileUID
 
+	if facesFound {
+		if err := entity.ReplaceFileFaces(&file, faces); err != nil {
+			log.Errorf("index: %s (faces) for %s", err, logName)
+		} else if len(faces) > 0 {
+			log.Infof("index: found %d faces in %s", len(faces), logName)
+		}
+	}
+
+	if regionsFound {
+		if n, err := entity.ReplaceFileRegions(&file, regions); err != nil {
+			log.Errorf("index: %s (face regions) for %s", err, logName)
+		} else if n > 0 {
+			log.Infof("index: found %d named face regions in %s", n, logName)
+		}
+	}
+
 	downloadedAs := fileName
 
 	if originalName != "" {
@@ -663,6 +706,33 @@ func (ind *Index) NSFW(jpeg *MediaFile) bool {
 	return false
 }
 
+// detectFaces returns the faces found in a media file and true if face detection was successful.
+func (ind *Index) detectFaces(jpeg *MediaFile) (face.Faces, bool) {
+	if ind.faceNet == nil || ind.faceNet.Disabled() {
+		return nil, false
+	}
+
+	start := time.Now()
+
+	filename, err := jpeg.Thumbnail(Config().ThumbPath(), "fit_1280")
+
+	if err != nil {
+		log.Error(err)
+		return nil, false
+	}
+
+	faces, err := ind.faceNet.File(filename)
+
+	if err != nil {
+		log.Error(err)
+		return nil, false
+	}
+
+	log.Debugf("index: face detection took %s", time.Since(start))
+
+	return faces, true
+}
+
 // classifyImage returns all matching labels for a media file.
 func (ind *Index) classifyImage(jpeg *MediaFile) (results classify.Labels) {
 	start := time.Now()
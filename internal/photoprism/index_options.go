@@ -4,6 +4,8 @@ type IndexOptions struct {
 	Path    string
 	Rescan  bool
 	Convert bool
+	UserUID string
+	LinkUID string
 }
 
 func (o *IndexOptions) SkipUnchanged() bool {
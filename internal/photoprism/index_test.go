@@ -5,6 +5,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/nsfw"
 )
 
@@ -19,9 +20,10 @@ func TestIndex_Start(t *testing.T) {
 
 	tf := classify.New(conf.AssetsPath(), conf.TensorFlowOff())
 	nd := nsfw.New(conf.NSFWModelPath())
+	fn := face.New(conf.FaceNetModelPath(), conf.TensorFlowOff())
 	convert := NewConvert(conf)
 
-	ind := NewIndex(conf, tf, nd, convert)
+	ind := NewIndex(conf, tf, nd, fn, convert)
 	imp := NewImport(conf, ind, convert)
 	opt := ImportOptionsMove(conf.ImportPath())
 
@@ -6,6 +6,7 @@ import (
 	"runtime"
 	"strings"
 
+	"github.com/gosimple/slug"
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
@@ -15,7 +16,7 @@ import (
 	"github.com/photoprism/photoprism/pkg/txt"
 )
 
-// Moments represents a worker that creates albums based on popular locations, dates and labels.
+// Moments represents a worker that creates albums based on popular locations, dates, labels and trips.
 type Moments struct {
 	conf *config.Config
 }
@@ -223,6 +224,133 @@ func (m *Moments) Start() (err error) {
 		}
 	}
 
+	// Trips away from home, the home location is different for each owner.
+	if owners, err := query.TripOwners(); err != nil {
+		log.Errorf("moments: %s", err.Error())
+	} else {
+		for _, owner := range owners {
+			m.trips(owner, threshold)
+		}
+	}
+
+	return nil
+}
+
+// trips creates or updates albums for trips of an owner. Photos are added explicitly, so that trip
+// albums only contain photos that were taken at the trip location by the same owner.
+func (m *Moments) trips(owner string, threshold int) {
+	results, err := query.TripLocations(owner)
+
+	if err != nil {
+		log.Errorf("moments: %s", err.Error())
+		return
+	}
+
+	albums, err := query.TripAlbums(owner)
+
+	if err != nil {
+		log.Errorf("moments: %s", err.Error())
+		return
+	}
+
+	entries, err := query.TripAlbumPhotos(owner)
+
+	if err != nil {
+		log.Errorf("moments: %s", err.Error())
+		return
+	}
+
+	for _, trip := range results.Trips(threshold) {
+		// Existing trips are updated when new photos extend them.
+		if a := findTripAlbum(albums, entries, trip); a != nil {
+			if a.DeletedAt != nil {
+				log.Tracef("moments: %s was deleted", txt.Quote(a.AlbumTitle))
+				continue
+			}
+
+			var uids []string
+
+			// Photos that were removed from the album are not added again.
+			for _, uid := range trip.PhotoUIDs {
+				if _, ok := entries[uid]; !ok {
+					uids = append(uids, uid)
+				}
+			}
+
+			if len(uids) == 0 {
+				log.Tracef("moments: %s already exists", txt.Quote(a.AlbumTitle))
+				continue
+			}
+
+			if updateTripAlbum(a, trip) {
+				if err := a.Save(); err != nil {
+					log.Errorf("moments: %s", err.Error())
+				}
+			}
+
+			if added := a.AddPhotos(uids); len(added) > 0 {
+				log.Infof("moments: added %d photos to %s", len(added), txt.Quote(a.AlbumTitle))
+			}
+		} else {
+			a := entity.NewAlbum(trip.Title(), entity.AlbumMoment)
+			a.AlbumSlug = trip.Slug()
+			a.AlbumCategory = query.TripCategory
+			a.AlbumYear = trip.StartLocal.Year()
+			a.AlbumMonth = int(trip.StartLocal.Month())
+			a.AlbumCountry = trip.Country
+			a.OwnerUID = owner
+
+			if err := a.Create(); err != nil {
+				log.Errorf("moments: %s", err.Error())
+				continue
+			}
+
+			a.AddPhotos(trip.PhotoUIDs)
+
+			for _, uid := range trip.PhotoUIDs {
+				entries[uid] = a.AlbumUID
+			}
+
+			albums = append(albums, *a)
+
+			log.Infof("moments: added %s with %d photos", txt.Quote(a.AlbumTitle), trip.PhotoCount)
+		}
+	}
+}
+
+// updateTripAlbum updates title, slug, date and country of a trip album that was extended, and returns
+// true if it has changed. Albums renamed by the user are left unchanged, as the slug of moments keeps
+// the generated title.
+func updateTripAlbum(a *entity.Album, trip query.Trip) bool {
+	if a.AlbumSlug != slug.Make(a.AlbumTitle) || a.AlbumTitle == trip.Title() {
+		return false
+	}
+
+	a.AlbumTitle = trip.Title()
+	a.AlbumSlug = trip.Slug()
+	a.AlbumYear = trip.StartLocal.Year()
+	a.AlbumMonth = int(trip.StartLocal.Month())
+	a.AlbumCountry = trip.Country
+
+	return true
+}
+
+// findTripAlbum returns the album that already contains photos of the trip, or nil.
+func findTripAlbum(albums entity.Albums, entries map[string]string, trip query.Trip) *entity.Album {
+	for _, uid := range trip.PhotoUIDs {
+		albumUID, ok := entries[uid]
+
+		if !ok {
+			continue
+		}
+
+		for i := range albums {
+			if albums[i].AlbumUID == albumUID {
+				return &albums[i]
+			}
+		}
+	}
+
 	return nil
 }
 
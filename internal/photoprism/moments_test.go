@@ -2,8 +2,12 @@ package photoprism
 
 import (
 	"testing"
+	"time"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestMoments_Start(t *testing.T) {
@@ -16,3 +20,58 @@ func TestMoments_Start(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestFindTripAlbum(t *testing.T) {
+	albums := entity.Albums{
+		{AlbumUID: "at9lxuqxpoaaaaa1", AlbumTitle: "Day Trip to Leipzig, April 2019"},
+		{AlbumUID: "at9lxuqxpoaaaaa2", AlbumTitle: "Weekend in Lisbon, May 2019"},
+	}
+
+	entries := map[string]string{
+		"pt9jtdre2lvl0aa1": "at9lxuqxpoaaaaa1",
+		"pt9jtdre2lvl0aa2": "at9lxuqxpoaaaaa2",
+		"pt9jtdre2lvl0aa3": "at9lxuqxpoaaaaa2",
+	}
+
+	t.Run("extended", func(t *testing.T) {
+		trip := query.Trip{PhotoUIDs: []string{"pt9jtdre2lvl0aa4", "pt9jtdre2lvl0aa3"}}
+
+		if a := findTripAlbum(albums, entries, trip); a == nil {
+			t.Fatal("album expected")
+		} else {
+			assert.Equal(t, "Weekend in Lisbon, May 2019", a.AlbumTitle)
+		}
+	})
+	t.Run("none", func(t *testing.T) {
+		trip := query.Trip{PhotoUIDs: []string{"pt9jtdre2lvl0aa4", "pt9jtdre2lvl0aa5"}}
+
+		assert.Nil(t, findTripAlbum(albums, entries, trip))
+	})
+}
+
+func TestUpdateTripAlbum(t *testing.T) {
+	trip := query.Trip{
+		StartLocal: time.Date(2019, 4, 30, 10, 0, 0, 0, time.UTC),
+		EndLocal:   time.Date(2019, 5, 2, 18, 0, 0, 0, time.UTC),
+		Country:    "pt",
+		City:       "Lisbon",
+	}
+
+	t.Run("extended", func(t *testing.T) {
+		a := entity.Album{AlbumTitle: "Day Trip to Lisbon, May 2019", AlbumSlug: "day-trip-to-lisbon-may-2019", AlbumYear: 2019, AlbumMonth: 5, AlbumCountry: "pt"}
+
+		assert.True(t, updateTripAlbum(&a, trip))
+		assert.Equal(t, trip.Title(), a.AlbumTitle)
+		assert.Equal(t, trip.Slug(), a.AlbumSlug)
+		assert.Equal(t, 2019, a.AlbumYear)
+		assert.Equal(t, 4, a.AlbumMonth)
+		assert.False(t, updateTripAlbum(&a, trip))
+	})
+	t.Run("renamed", func(t *testing.T) {
+		a := entity.Album{AlbumTitle: "Lisbon with Friends", AlbumSlug: "day-trip-to-lisbon-may-2019", AlbumYear: 2019, AlbumMonth: 5}
+
+		assert.False(t, updateTripAlbum(&a, trip))
+		assert.Equal(t, "Lisbon with Friends", a.AlbumTitle)
+		assert.Equal(t, 5, a.AlbumMonth)
+	})
+}
@@ -0,0 +1,23 @@
+package photoprism
+
+import (
+	"errors"
+
+	"github.com/photoprism/photoprism/pkg/phash"
+)
+
+// PerceptualHashes returns the difference and DCT hash of an image (only JPEG supported),
+// so that resized or re-encoded copies can be found.
+func (m *MediaFile) PerceptualHashes(thumbPath string) (dHash, pHash phash.Hash, err error) {
+	if !m.IsJpeg() {
+		return 0, 0, errors.New("no perceptual hash: not a JPEG file")
+	}
+
+	img, err := m.Resample(thumbPath, "fit_720")
+
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return phash.DHash(img), phash.PHash(img), nil
+}
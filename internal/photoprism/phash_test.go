@@ -0,0 +1,71 @@
+package photoprism
+
+import (
+	"os"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMediaFile_PerceptualHashes(t *testing.T) {
+	conf := config.TestConfig()
+
+	thumbsPath := os.TempDir() + "/TestMediaFile_PerceptualHashes"
+	defer os.RemoveAll(thumbsPath)
+
+	t.Run("similar", func(t *testing.T) {
+		a, err := NewMediaFile(conf.ExamplesPath() + "/IMG_4120.JPG")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := NewMediaFile(conf.ExamplesPath() + "/IMG_4120 copy.JPG")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		c, err := NewMediaFile(conf.ExamplesPath() + "/cat_brown.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		dA, pA, err := a.PerceptualHashes(thumbsPath)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		dB, pB, err := b.PerceptualHashes(thumbsPath)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		dC, pC, err := c.PerceptualHashes(thumbsPath)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, dA.Distance(dB))
+		assert.Equal(t, 0, pA.Distance(pB))
+		assert.Greater(t, dA.Distance(dC), 10)
+		assert.Greater(t, pA.Distance(pC), 10)
+	})
+
+	t.Run("not a jpeg", func(t *testing.T) {
+		m, err := NewMediaFile(conf.ExamplesPath() + "/canon_eos_6d.dng")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, _, err = m.PerceptualHashes(thumbsPath)
+
+		assert.Error(t, err)
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/photoprism/photoprism/internal/thumb"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/face"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -29,9 +30,10 @@ func TestResample_Start(t *testing.T) {
 
 	tf := classify.New(conf.AssetsPath(), conf.TensorFlowOff())
 	nd := nsfw.New(conf.NSFWModelPath())
+	fn := face.New(conf.FaceNetModelPath(), conf.TensorFlowOff())
 	convert := NewConvert(conf)
 
-	ind := NewIndex(conf, tf, nd, convert)
+	ind := NewIndex(conf, tf, nd, fn, convert)
 
 	imp := NewImport(conf, ind, convert)
 	opt := ImportOptionsMove(conf.ImportPath())
@@ -0,0 +1,275 @@
+package photoprism
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Restore represents a worker that restores index backups.
+type Restore struct {
+	conf *config.Config
+}
+
+// NewRestore returns a new index restore worker.
+func NewRestore(conf *config.Config) *Restore {
+	instance := &Restore{
+		conf: conf,
+	}
+
+	return instance
+}
+
+// Start replaces all index entities with the contents of a backup archive, which may have been
+// created with a different database driver, and optionally restores YAML sidecar files.
+func (w *Restore) Start(fileName string, sidecars bool) (info BackupInfo, err error) {
+	if err := mutex.MainWorker.Start(); err != nil {
+		return info, fmt.Errorf("restore: %s", err)
+	}
+
+	defer mutex.MainWorker.Stop()
+
+	zr, err := zip.OpenReader(fileName)
+
+	if err != nil {
+		return info, err
+	}
+
+	defer zr.Close()
+
+	files := make(map[string]*zip.File, len(zr.File))
+
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	if f, ok := files[backupInfoName]; !ok {
+		return info, fmt.Errorf("restore: %s is not a valid backup", txt.Quote(filepath.Base(fileName)))
+	} else if err := readBackupInfo(f, &info); err != nil {
+		return info, err
+	} else if info.Format > BackupFormat {
+		return info, fmt.Errorf("restore: unsupported backup format %d", info.Format)
+	}
+
+	log.Infof("restore: backup was created on %s with %s", info.CreatedAt.Format("2006-01-02 15:04:05"), info.Driver)
+
+	names, models := BackupTables()
+
+	// Create missing tables first, as MySQL implicitly commits schema changes.
+	for _, name := range names {
+		if err := entity.UnscopedDb().AutoMigrate(models[name]).Error; err != nil {
+			return info, err
+		}
+	}
+
+	tx := entity.UnscopedDb().Begin()
+
+	if err := tx.Error; err != nil {
+		return info, err
+	}
+
+	for _, name := range names {
+		if err := tx.Exec(fmt.Sprintf("DELETE FROM %s", tx.NewScope(nil).Quote(name))).Error; err != nil {
+			tx.Rollback()
+			return info, fmt.Errorf("restore: %s (%s)", err, name)
+		}
+
+		f, ok := files[path.Join(backupTablePath, name+".json")]
+
+		if !ok {
+			continue
+		}
+
+		count, err := restoreTable(tx, f, name, models[name])
+
+		if err != nil {
+			tx.Rollback()
+			return info, fmt.Errorf("restore: %s (%s)", err, name)
+		}
+
+		log.Debugf("restore: added %d rows to %s", count, name)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return info, err
+	}
+
+	if entity.IsDialect(entity.Postgres) {
+		restoreSequences(names)
+	}
+
+	// Apply migrations that are newer than the backup.
+	if _, err := entity.Migrations.Up(0, false); err != nil {
+		log.Errorf("restore: %s", err)
+	}
+
+	if entity.FullTextEnabled() {
+		if _, err := entity.RebuildFullText(); err != nil {
+			log.Errorf("restore: %s (full-text index)", err)
+		}
+	}
+
+	if err := entity.UpdatePhotoCounts(); err != nil {
+		log.Errorf("restore: %s (photo counts)", err)
+	}
+
+	if sidecars {
+		if err := w.restoreSidecars(zr.File); err != nil {
+			return info, err
+		}
+	}
+
+	log.Infof("restore: restored %s", txt.Quote(filepath.Base(fileName)))
+
+	return info, nil
+}
+
+// readBackupInfo reads the backup manifest.
+func readBackupInfo(f *zip.File, info *BackupInfo) error {
+	r, err := f.Open()
+
+	if err != nil {
+		return err
+	}
+
+	defer r.Close()
+
+	return json.NewDecoder(r).Decode(info)
+}
+
+// restoreTable inserts all rows from a backup file. Values are decoded into the entity fields,
+// so that they are converted to the types expected by the current database driver.
+func restoreTable(db *gorm.DB, f *zip.File, name string, model interface{}) (count int, err error) {
+	r, err := f.Open()
+
+	if err != nil {
+		return count, err
+	}
+
+	defer r.Close()
+
+	dec := json.NewDecoder(r)
+	modelType := reflect.TypeOf(model).Elem()
+
+	for {
+		var row map[string]json.RawMessage
+
+		if err := dec.Decode(&row); err == io.EOF {
+			return count, nil
+		} else if err != nil {
+			return count, err
+		}
+
+		scope := db.NewScope(reflect.New(modelType).Interface())
+
+		var cols []string
+		var values []interface{}
+
+		for _, field := range scope.Fields() {
+			if !field.IsNormal || field.IsIgnored {
+				continue
+			}
+
+			raw, ok := row[field.DBName]
+
+			if !ok {
+				continue
+			}
+
+			if err := json.Unmarshal(raw, field.Field.Addr().Interface()); err != nil {
+				return count, fmt.Errorf("%s (column %s)", err, field.DBName)
+			}
+
+			cols = append(cols, scope.Quote(field.DBName))
+			values = append(values, field.Field.Interface())
+		}
+
+		if len(cols) == 0 {
+			continue
+		}
+
+		stmt := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", scope.Quote(name), strings.Join(cols, ", "),
+			strings.TrimSuffix(strings.Repeat("?, ", len(cols)), ", "))
+
+		if err := db.Exec(stmt, values...).Error; err != nil {
+			return count, err
+		}
+
+		count++
+	}
+}
+
+// restoreSequences resets PostgreSQL auto increment sequences after ids were inserted.
+func restoreSequences(names []string) {
+	for _, name := range names {
+		if !entity.UnscopedDb().Dialect().HasColumn(name, "id") {
+			continue
+		}
+
+		if err := entity.Db().Exec(fmt.Sprintf("SELECT setval(pg_get_serial_sequence('%s', 'id'), "+
+			"COALESCE(MAX(id), 0) + 1, false) FROM %s", name, name)).Error; err != nil {
+			log.Debugf("restore: %s (sequence of %s)", err, name)
+		}
+	}
+}
+
+// restoreSidecars writes the YAML sidecar files contained in a backup.
+func (w *Restore) restoreSidecars(files []*zip.File) error {
+	if !w.conf.SidecarWritable() {
+		log.Warnf("restore: sidecar path is not writable, skipping yaml files")
+		return nil
+	}
+
+	for _, f := range files {
+		if !strings.HasPrefix(f.Name, backupSidecarPath+"/") || strings.Contains(f.Name, "..") {
+			continue
+		}
+
+		rel := strings.TrimSuffix(strings.TrimPrefix(f.Name, backupSidecarPath+"/"), fs.YamlExt)
+
+		p := entity.Photo{PhotoPath: path.Dir(rel), PhotoName: path.Base(rel)}
+
+		if p.PhotoPath == "." {
+			p.PhotoPath = ""
+		}
+
+		r, err := f.Open()
+
+		if err != nil {
+			return err
+		}
+
+		data, err := ioutil.ReadAll(r)
+		r.Close()
+
+		if err != nil {
+			return err
+		}
+
+		fileName := p.YamlFileName(w.conf.OriginalsPath(), w.conf.SidecarPath())
+
+		if err := os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
+			return err
+		}
+
+		if err := ioutil.WriteFile(fileName, data, os.ModePerm); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
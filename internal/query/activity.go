@@ -0,0 +1,131 @@
+package query
+
+import (
+	"sort"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Activity types.
+const (
+	ActivityComment = "comment"
+	ActivityLike    = "like"
+	ActivityAdd     = "add"
+)
+
+// Activity represents a recent comment, like or photo added to an album.
+type Activity struct {
+	ActivityType string    `json:"Type"`
+	SubjectUID   string    `json:"SubjectUID"`
+	AlbumUID     string    `json:"AlbumUID"`
+	PersonUID    string    `json:"PersonUID"`
+	PersonName   string    `json:"PersonName"`
+	ActivityText string    `json:"Text"`
+	CreatedAt    time.Time `json:"CreatedAt"`
+}
+
+// AlbumUIDsByOwner returns the UIDs of albums owned by a person.
+func AlbumUIDsByOwner(ownerUID string) (result []string, err error) {
+	err = Db().Table("albums").Where("owner_uid = ? AND deleted_at IS NULL", ownerUID).Pluck("album_uid", &result).Error
+
+	return result, err
+}
+
+// RecentActivity returns recent comments, likes and additions in albums and their photos,
+// the newest first. Activity in all albums is returned if albums is nil.
+func RecentActivity(albums []string, limit int) (result []Activity, err error) {
+	result = []Activity{}
+
+	if albums != nil && len(albums) == 0 {
+		return result, nil
+	}
+
+	// inAlbums restricts a query to the album UIDs, if any.
+	inAlbums := func(q *gorm.DB, col string) *gorm.DB {
+		if albums == nil {
+			return q
+		}
+
+		return q.Where(col+" IN (?)", albums)
+	}
+
+	queries := []*gorm.DB{
+		// Comments on albums.
+		inAlbums(Db().Table("comments").
+			Select("'comment' AS activity_type, comments.subject_uid, albums.album_uid, comments.person_uid, "+
+				"comments.person_name, comments.comment_text AS activity_text, comments.created_at").
+			Joins("JOIN albums ON albums.album_uid = comments.subject_uid AND albums.deleted_at IS NULL"),
+			"albums.album_uid").
+			Order("comments.created_at DESC"),
+		// Comments on photos in albums.
+		inAlbums(Db().Table("comments").
+			Select("'comment' AS activity_type, comments.subject_uid, photos_albums.album_uid, comments.person_uid, "+
+				"comments.person_name, comments.comment_text AS activity_text, comments.created_at").
+			Joins("JOIN photos_albums ON photos_albums.photo_uid = comments.subject_uid AND photos_albums.hidden = FALSE"),
+			"photos_albums.album_uid").
+			Order("comments.created_at DESC"),
+		// Liked albums.
+		inAlbums(Db().Table("likes").
+			Select("'like' AS activity_type, likes.subject_uid, albums.album_uid, likes.person_uid, "+
+				"likes.person_name, '' AS activity_text, likes.created_at").
+			Joins("JOIN albums ON albums.album_uid = likes.subject_uid AND albums.deleted_at IS NULL"),
+			"albums.album_uid").
+			Order("likes.created_at DESC"),
+		// Liked photos in albums.
+		inAlbums(Db().Table("likes").
+			Select("'like' AS activity_type, likes.subject_uid, photos_albums.album_uid, likes.person_uid, "+
+				"likes.person_name, '' AS activity_text, likes.created_at").
+			Joins("JOIN photos_albums ON photos_albums.photo_uid = likes.subject_uid AND photos_albums.hidden = FALSE"),
+			"photos_albums.album_uid").
+			Order("likes.created_at DESC"),
+		// Photos added to albums.
+		inAlbums(Db().Table("photos_albums").
+			Select("'add' AS activity_type, photos_albums.photo_uid AS subject_uid, photos_albums.album_uid, "+
+				"'' AS person_uid, '' AS person_name, '' AS activity_text, photos_albums.created_at").
+			Joins("JOIN photos ON photos.photo_uid = photos_albums.photo_uid AND photos.deleted_at IS NULL").
+			Where("photos_albums.hidden = FALSE"),
+			"photos_albums.album_uid").
+			Order("photos_albums.created_at DESC"),
+	}
+
+	// Photos may be in more than one album, so that the same activity is only listed once.
+	found := make(map[string]bool)
+
+	for _, q := range queries {
+		var rows []Activity
+
+		if limit > 0 {
+			q = q.Limit(limit)
+		}
+
+		if err := q.Scan(&rows).Error; err != nil {
+			return result, err
+		}
+
+		for _, row := range rows {
+			key := row.ActivityType + row.SubjectUID + row.PersonUID + row.CreatedAt.String()
+
+			if row.ActivityType == ActivityAdd {
+				key += row.AlbumUID
+			}
+
+			if found[key] {
+				continue
+			}
+
+			found[key] = true
+			result = append(result, row)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+
+	return result, nil
+}
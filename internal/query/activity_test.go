@@ -0,0 +1,64 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecentActivity(t *testing.T) {
+	albumComment := entity.NewComment("at9lxuqxpogaaba8", entity.Admin, "Great album!")
+
+	if err := albumComment.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	photoComment := entity.NewComment("pt9jtdre2lvl0yh7", entity.Guest, "Nice photo")
+
+	if err := photoComment.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := entity.AddLike("pt9jtdre2lvl0yh7", entity.Admin); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("shared album", func(t *testing.T) {
+		result, err := RecentActivity([]string{"at9lxuqxpogaaba8"}, 10)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		types := make(map[string]int)
+
+		for _, a := range result {
+			assert.Equal(t, "at9lxuqxpogaaba8", a.AlbumUID)
+			types[a.ActivityType]++
+		}
+
+		assert.Equal(t, 2, types[ActivityComment])
+		assert.Equal(t, 1, types[ActivityLike])
+		assert.LessOrEqual(t, 1, types[ActivityAdd])
+		assert.False(t, result[0].CreatedAt.Before(result[len(result)-1].CreatedAt))
+	})
+	t.Run("no albums", func(t *testing.T) {
+		result, err := RecentActivity([]string{}, 10)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, result)
+	})
+	t.Run("limit", func(t *testing.T) {
+		result, err := RecentActivity(nil, 1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 1)
+	})
+}
@@ -15,6 +15,7 @@ import (
 type AlbumResult struct {
 	ID               uint      `json:"-"`
 	AlbumUID         string    `json:"UID"`
+	OwnerUID         string    `json:"OwnerUID"`
 	CoverUID         string    `json:"CoverUID"`
 	FolderUID        string    `json:"FolderUID"`
 	AlbumSlug        string    `json:"Slug"`
@@ -51,6 +52,71 @@ func AlbumByUID(albumUID string) (album entity.Album, err error) {
 	return album, nil
 }
 
+// OwnedAlbumUIDs returns the UIDs of albums owned by a user.
+func OwnedAlbumUIDs(uids []string, ownerUID string) (result []string, err error) {
+	if len(uids) == 0 || ownerUID == "" {
+		return result, nil
+	}
+
+	err = Db().Model(&entity.Album{}).
+		Where("album_uid IN (?) AND owner_uid = ?", uids, ownerUID).
+		Pluck("album_uid", &result).Error
+
+	return result, err
+}
+
+// AlbumPhotoSearch returns a search form for the photos of an album, so that moments
+// and smart albums are resolved live based on their filter.
+func AlbumPhotoSearch(a entity.Album) form.PhotoSearch {
+	f := form.PhotoSearch{Album: a.AlbumUID, Filter: a.AlbumFilter}
+
+	// Dynamic albums of users without admin role may only contain their own photos.
+	if a.AlbumFilter != "" && a.OwnerUID != "" {
+		if owner := entity.FindPersonByUID(a.OwnerUID); owner == nil || !owner.Admin() {
+			f.Owner = a.OwnerUID
+		}
+	}
+
+	return f
+}
+
+// AlbumPhotoUIDs returns the UIDs of up to MaxResults photos in dynamic albums like moments and smart albums,
+// albums are restricted to those owned by or shared with the owner if not empty.
+func AlbumPhotoUIDs(albumUIDs []string, owner, shared string) (result []string, err error) {
+	if len(albumUIDs) == 0 {
+		return result, nil
+	}
+
+	var albums entity.Albums
+
+	s := Db().Where("album_uid IN (?) AND album_filter <> ''", albumUIDs)
+
+	if owner != "" {
+		s = s.Where("owner_uid = ? OR album_uid IN (?)", owner, strings.Split(shared, ","))
+	}
+
+	if err := s.Find(&albums).Error; err != nil {
+		return result, err
+	}
+
+	for _, a := range albums {
+		f := AlbumPhotoSearch(a)
+		f.Count = MaxResults
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			return result, err
+		}
+
+		for _, p := range photos {
+			result = append(result, p.PhotoUID)
+		}
+	}
+
+	return result, nil
+}
+
 // AlbumCoverByUID returns a album preview file based on the uid.
 func AlbumCoverByUID(albumUID string) (file entity.File, err error) {
 	a := entity.Album{}
@@ -58,13 +124,15 @@ func AlbumCoverByUID(albumUID string) (file entity.File, err error) {
 	if err := Db().Where("album_uid = ?", albumUID).First(&a).Error; err != nil {
 		return file, err
 	} else if a.AlbumType != entity.AlbumDefault { // TODO: Optimize
-		f := form.PhotoSearch{Album: a.AlbumUID, Filter: a.AlbumFilter, Order: entity.SortOrderRelevance, Count: 1, Offset: 0, Merged: false}
+		f := AlbumPhotoSearch(a)
+		f.Order = entity.SortOrderRelevance
+		f.Count = 1
 
 		if photos, _, err := PhotoSearch(f); err != nil {
 			return file, err
 		} else if len(photos) > 0 {
 			for _, photo := range photos {
-				if err := Db().Where("photo_uid = ? AND file_primary = 1", photo.PhotoUID).First(&file).Error; err != nil {
+				if err := Db().Where("photo_uid = ? AND file_primary = TRUE", photo.PhotoUID).First(&file).Error; err != nil {
 					return file, err
 				} else {
 					return file, nil
@@ -75,10 +143,10 @@ func AlbumCoverByUID(albumUID string) (file entity.File, err error) {
 		return file, fmt.Errorf("found no cover for moment")
 	}
 
-	if err := Db().Where("files.file_primary = 1 AND files.file_missing = 0 AND files.file_type = 'jpg' AND files.deleted_at IS NULL").
+	if err := Db().Where("files.file_primary = TRUE AND files.file_missing = FALSE AND files.file_type = 'jpg' AND files.deleted_at IS NULL").
 		Joins("JOIN albums ON albums.album_uid = ?", albumUID).
 		Joins("JOIN photos_albums pa ON pa.album_uid = albums.album_uid AND pa.photo_uid = files.photo_uid").
-		Joins("JOIN photos ON photos.id = files.photo_id AND photos.photo_private = 0 AND photos.deleted_at IS NULL").
+		Joins("JOIN photos ON photos.id = files.photo_id AND photos.photo_private = FALSE AND photos.deleted_at IS NULL").
 		Order("photos.photo_quality DESC, photos.taken_at DESC").
 		First(&file).Error; err != nil {
 		return file, err
@@ -89,12 +157,10 @@ func AlbumCoverByUID(albumUID string) (file entity.File, err error) {
 
 // AlbumPhotos returns up to count photos from an album.
 func AlbumPhotos(a entity.Album, count int) (results PhotoResults, err error) {
-	results, _, err = PhotoSearch(form.PhotoSearch{
-		Album:  a.AlbumUID,
-		Filter: a.AlbumFilter,
-		Count:  count,
-		Offset: 0,
-	})
+	f := AlbumPhotoSearch(a)
+	f.Count = count
+
+	results, _, err = PhotoSearch(f)
 
 	return results, err
 }
@@ -109,10 +175,19 @@ func AlbumSearch(f form.AlbumSearch) (results AlbumResults, err error) {
 
 	s := UnscopedDb().Table("albums").
 		Select("albums.*, cp.photo_count,	cl.link_count").
-		Joins("LEFT JOIN (SELECT album_uid, count(photo_uid) AS photo_count FROM photos_albums WHERE hidden = 0 GROUP BY album_uid) AS cp ON cp.album_uid = albums.album_uid").
+		Joins("LEFT JOIN (SELECT album_uid, count(photo_uid) AS photo_count FROM photos_albums WHERE hidden = FALSE GROUP BY album_uid) AS cp ON cp.album_uid = albums.album_uid").
 		Joins("LEFT JOIN (SELECT share_uid, count(share_uid) AS link_count FROM links GROUP BY share_uid) AS cl ON cl.share_uid = albums.album_uid").
 		Where("albums.deleted_at IS NULL")
 
+	// Restrict results to albums owned by or shared with a user.
+	if f.Owner != "" {
+		if f.Shared != "" {
+			s = s.Where("albums.owner_uid = ? OR albums.album_uid IN (?)", f.Owner, strings.Split(f.Shared, ","))
+		} else {
+			s = s.Where("albums.owner_uid = ?", f.Owner)
+		}
+	}
+
 	if f.ID != "" {
 		s = s.Where("albums.album_uid IN (?)", strings.Split(f.ID, ","))
 
@@ -137,7 +212,7 @@ func AlbumSearch(f form.AlbumSearch) (results AlbumResults, err error) {
 	}
 
 	if f.Favorite {
-		s = s.Where("albums.album_favorite = 1")
+		s = s.Where("albums.album_favorite = TRUE")
 	}
 
 	if (f.Year > 0 && f.Year <= txt.YearMax) || f.Year == entity.YearUnknown {
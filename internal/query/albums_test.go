@@ -58,6 +58,95 @@ func TestAlbumPhotos(t *testing.T) {
 	})
 }
 
+func TestOwnedAlbumUIDs(t *testing.T) {
+	uid := "at9lxuqxpogaaba8"
+
+	if err := Db().Model(&entity.Album{}).Where("album_uid = ?", uid).UpdateColumn("owner_uid", "uqxc08w3d0ej2283").Error; err != nil {
+		t.Fatal(err)
+	}
+
+	defer Db().Model(&entity.Album{}).Where("album_uid = ?", uid).UpdateColumn("owner_uid", "")
+
+	t.Run("owner", func(t *testing.T) {
+		result, err := OwnedAlbumUIDs([]string{uid, "at9lxuqxpogaaba7"}, "uqxc08w3d0ej2283")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{uid}, result)
+	})
+	t.Run("other user", func(t *testing.T) {
+		result, err := OwnedAlbumUIDs([]string{uid}, "uqxc08w3d0ej2284")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, result)
+	})
+}
+
+func TestAlbumPhotoSearch(t *testing.T) {
+	t.Run("folder", func(t *testing.T) {
+		a := entity.AlbumFixtures.Get("april-1990")
+		f := AlbumPhotoSearch(a)
+
+		assert.Equal(t, a.AlbumUID, f.Album)
+		assert.Equal(t, a.AlbumFilter, f.Filter)
+		assert.Equal(t, "", f.Owner)
+	})
+	t.Run("smart", func(t *testing.T) {
+		a := *entity.NewSmartAlbum("Cats", "label:cat")
+		a.OwnerUID = "u000000000000002"
+		f := AlbumPhotoSearch(a)
+
+		assert.Equal(t, "label:cat", f.Filter)
+		assert.Equal(t, "u000000000000002", f.Owner)
+	})
+	t.Run("moment", func(t *testing.T) {
+		a := *entity.NewAlbum("Cats", entity.AlbumMoment)
+		a.AlbumFilter = "label:cat"
+		a.OwnerUID = "u000000000000002"
+		f := AlbumPhotoSearch(a)
+
+		assert.Equal(t, "label:cat", f.Filter)
+		assert.Equal(t, "u000000000000002", f.Owner)
+	})
+}
+
+func TestAlbumPhotoUIDs(t *testing.T) {
+	t.Run("folder", func(t *testing.T) {
+		a := entity.AlbumFixtures.Get("april-1990")
+		results, err := AlbumPhotoUIDs([]string{a.AlbumUID}, "", "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(results), 2)
+	})
+	t.Run("not shared", func(t *testing.T) {
+		a := entity.AlbumFixtures.Get("april-1990")
+		results, err := AlbumPhotoUIDs([]string{a.AlbumUID}, "u000000000000099", "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, results)
+	})
+	t.Run("empty", func(t *testing.T) {
+		results, err := AlbumPhotoUIDs(nil, "", "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, results)
+	})
+}
+
 func TestAlbumSearch(t *testing.T) {
 	t.Run("search with string", func(t *testing.T) {
 		query := form.NewAlbumSearch("chr")
@@ -0,0 +1,245 @@
+package query
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	gc "github.com/patrickmn/go-cache"
+	"github.com/photoprism/photoprism/pkg/phash"
+)
+
+// Default and maximum perceptual hash distance of near-duplicates.
+const (
+	DuplicateDist    = 6
+	DuplicateDistMax = 16
+)
+
+// DuplicateCacheTime is the time after which groups of near-duplicates are computed again.
+var DuplicateCacheTime = 15 * time.Minute
+
+// duplicateCache contains precomputed groups of near-duplicates by owner and distance.
+var duplicateCache = gc.New(DuplicateCacheTime, DuplicateCacheTime)
+
+// FlushDuplicates removes all cached groups of near-duplicates, e.g. after photos were archived.
+func FlushDuplicates() {
+	duplicateCache.Flush()
+}
+
+// DuplicatePhoto represents a photo in a group of near-duplicates.
+type DuplicatePhoto struct {
+	PhotoUID     string    `json:"UID"`
+	PhotoTitle   string    `json:"Title"`
+	PhotoQuality int       `json:"Quality"`
+	TakenAt      time.Time `json:"TakenAt"`
+	FileUID      string    `json:"FileUID"`
+	FileRoot     string    `json:"FileRoot"`
+	FileName     string    `json:"FileName"`
+	FileHash     string    `json:"Hash"`
+	FileSize     int64     `json:"Size"`
+	FileWidth    int       `json:"Width"`
+	FileHeight   int       `json:"Height"`
+	FileDHash    string    `json:"DHash"`
+	FilePHash    string    `json:"PHash"`
+}
+
+// DuplicateGroup represents a group of similar photos, e.g. resized copies or burst shots.
+type DuplicateGroup struct {
+	Best   string           `json:"Best"`
+	Photos []DuplicatePhoto `json:"Photos"`
+}
+
+// better returns true if the photo should be kept rather than the other one.
+func (m DuplicatePhoto) better(other DuplicatePhoto) bool {
+	if res, otherRes := m.FileWidth*m.FileHeight, other.FileWidth*other.FileHeight; res != otherRes {
+		return res > otherRes
+	}
+
+	if m.PhotoQuality != other.PhotoQuality {
+		return m.PhotoQuality > other.PhotoQuality
+	}
+
+	if m.FileSize != other.FileSize {
+		return m.FileSize > other.FileSize
+	}
+
+	return m.TakenAt.Before(other.TakenAt)
+}
+
+// Contains returns true if the photo belongs to the group.
+func (g DuplicateGroup) Contains(photoUID string) bool {
+	for _, p := range g.Photos {
+		if p.PhotoUID == photoUID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NearDuplicates returns up to limit groups of near-duplicates starting at offset, the largest groups first.
+// Results are restricted to photos of the owner unless the owner is empty. A negative distance
+// selects the default.
+func NearDuplicates(owner string, dist, limit, offset int) (groups []DuplicateGroup, err error) {
+	if groups, err = DuplicateGroups(owner, dist); err != nil {
+		return groups, err
+	}
+
+	if offset >= len(groups) {
+		return []DuplicateGroup{}, nil
+	}
+
+	groups = groups[offset:]
+
+	if limit > 0 && limit < len(groups) {
+		groups = groups[:limit]
+	}
+
+	return groups, nil
+}
+
+// FindDuplicateGroup returns the group of near-duplicates containing a photo of the owner.
+func FindDuplicateGroup(owner string, dist int, photoUID string) (group DuplicateGroup, err error) {
+	groups, err := DuplicateGroups(owner, dist)
+
+	if err != nil {
+		return group, err
+	}
+
+	for _, g := range groups {
+		if g.Contains(photoUID) {
+			return g, nil
+		}
+	}
+
+	return group, fmt.Errorf("no duplicates found for %s", photoUID)
+}
+
+// DuplicateGroups returns all groups of photos whose primary files have similar perceptual hashes,
+// the largest groups first. A photo with the highest resolution and quality is suggested as best.
+// Groups are cached, as comparing the hashes of a large library takes a while.
+func DuplicateGroups(owner string, dist int) (groups []DuplicateGroup, err error) {
+	if dist < 0 {
+		dist = DuplicateDist
+	} else if dist > DuplicateDistMax {
+		dist = DuplicateDistMax
+	}
+
+	cacheKey := fmt.Sprintf("%s:%d", owner, dist)
+
+	if cached, ok := duplicateCache.Get(cacheKey); ok {
+		return cached.([]DuplicateGroup), nil
+	}
+
+	var photos []DuplicatePhoto
+
+	s := Db().Table("files").
+		Select("photos.photo_uid, photos.photo_title, photos.photo_quality, photos.taken_at, " +
+			"files.file_uid, files.file_root, files.file_name, files.file_hash, files.file_size, " +
+			"files.file_width, files.file_height, files.file_d_hash, files.file_p_hash").
+		Joins("JOIN photos ON photos.id = files.photo_id AND photos.deleted_at IS NULL").
+		Where("files.file_primary = TRUE AND files.file_missing = FALSE AND files.deleted_at IS NULL").
+		Where("files.file_d_hash <> '' AND files.file_p_hash <> ''")
+
+	if owner != "" {
+		s = s.Where("photos.owner_uid = ?", owner)
+	}
+
+	if err := s.Order("photos.taken_at, files.id").Scan(&photos).Error; err != nil {
+		return groups, err
+	}
+
+	dHashes := make([]phash.Hash, len(photos))
+	pHashes := make([]phash.Hash, len(photos))
+
+	for i := range photos {
+		dHashes[i], _ = phash.Parse(photos[i].FileDHash)
+		pHashes[i], _ = phash.Parse(photos[i].FilePHash)
+	}
+
+	// Similar hashes have at least one identical block if the hash is split
+	// into dist + 1 blocks, so only photos in the same bucket are compared.
+	parent := make([]int, len(photos))
+
+	for i := range parent {
+		parent[i] = i
+	}
+
+	var find func(i int) int
+
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+
+		return parent[i]
+	}
+
+	blocks := dist + 1
+
+	for b := 0; b < blocks; b++ {
+		start, end := uint(b*64/blocks), uint((b+1)*64/blocks)
+		mask := phash.Hash(1)<<(end-start) - 1
+		buckets := make(map[phash.Hash][]int)
+
+		for i, h := range dHashes {
+			key := (h >> start) & mask
+			buckets[key] = append(buckets[key], i)
+		}
+
+		for _, bucket := range buckets {
+			for x := 0; x < len(bucket); x++ {
+				for y := x + 1; y < len(bucket); y++ {
+					i, j := bucket[x], bucket[y]
+
+					if find(i) == find(j) {
+						continue
+					}
+
+					if dHashes[i].Distance(dHashes[j]) <= dist && pHashes[i].Distance(pHashes[j]) <= dist {
+						parent[find(j)] = find(i)
+					}
+				}
+			}
+		}
+	}
+
+	grouped := make(map[int][]DuplicatePhoto)
+	var roots []int
+
+	for i := range photos {
+		root := find(i)
+
+		if _, ok := grouped[root]; !ok {
+			roots = append(roots, root)
+		}
+
+		grouped[root] = append(grouped[root], photos[i])
+	}
+
+	for _, root := range roots {
+		if len(grouped[root]) < 2 {
+			continue
+		}
+
+		group := DuplicateGroup{Photos: grouped[root]}
+		best := group.Photos[0]
+
+		for _, p := range group.Photos[1:] {
+			if p.better(best) {
+				best = p
+			}
+		}
+
+		group.Best = best.PhotoUID
+		groups = append(groups, group)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return len(groups[i].Photos) > len(groups[j].Photos)
+	})
+
+	duplicateCache.SetDefault(cacheKey, groups)
+
+	return groups, nil
+}
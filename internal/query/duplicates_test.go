@@ -0,0 +1,88 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNearDuplicates(t *testing.T) {
+	bridge := entity.FileFixtures["bridge.jpg"]
+	reunion := entity.FileFixtures["reunion.jpg"]
+
+	setHashes := func(file entity.File, dHash, pHash string) {
+		if err := Db().Model(&file).UpdateColumns(map[string]interface{}{"file_d_hash": dHash, "file_p_hash": pHash}).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	setHashes(bridge, "f0f0f0f0f0f0f0f0", "0123456789abcdef")
+	setHashes(reunion, "f0f0f0f0f0f0f0f1", "0123456789abcdee")
+
+	FlushDuplicates()
+
+	defer FlushDuplicates()
+	defer setHashes(bridge, "", "")
+	defer setHashes(reunion, "", "")
+
+	t.Run("similar", func(t *testing.T) {
+		groups, err := NearDuplicates("", DuplicateDist, 100, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		found := false
+
+		for _, g := range groups {
+			var uids []string
+
+			for _, p := range g.Photos {
+				uids = append(uids, p.PhotoUID)
+			}
+
+			if assert.Len(t, g.Photos, 2) && assert.Contains(t, uids, bridge.PhotoUID) {
+				found = true
+				assert.Contains(t, uids, reunion.PhotoUID)
+				assert.Contains(t, uids, g.Best)
+			}
+		}
+
+		assert.True(t, found)
+	})
+
+	t.Run("exact", func(t *testing.T) {
+		groups, err := NearDuplicates("", 0, 100, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, groups)
+	})
+	t.Run("owner", func(t *testing.T) {
+		groups, err := NearDuplicates("uqxc08w3d0ej2283", DuplicateDist, 100, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, groups)
+	})
+
+	t.Run("find group", func(t *testing.T) {
+		group, err := FindDuplicateGroup("", DuplicateDist, reunion.PhotoUID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, group.Contains(bridge.PhotoUID))
+		assert.True(t, group.Contains(reunion.PhotoUID))
+
+		_, err = FindDuplicateGroup("", 0, reunion.PhotoUID)
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,100 @@
+package query
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// FaceClusterResult represents a face cluster with a sample face for display.
+type FaceClusterResult struct {
+	ClusterUID string  `json:"UID"`
+	PersonUID  string  `json:"PersonUID"`
+	PersonName string  `json:"PersonName"`
+	FaceCount  int     `json:"FaceCount"`
+	PhotoUID   string  `json:"PhotoUID"`
+	FileHash   string  `json:"Hash"`
+	FaceX      float32 `json:"X"`
+	FaceY      float32 `json:"Y"`
+	FaceW      float32 `json:"W"`
+	FaceH      float32 `json:"H"`
+}
+
+// UnclusteredFaces returns faces that have not been assigned to a cluster yet.
+func UnclusteredFaces(limit int) (result entity.Faces, err error) {
+	err = Db().Where("cluster_uid = '' AND embedding <> ''").Order("id").Limit(limit).Find(&result).Error
+
+	return result, err
+}
+
+// FaceClusters returns all face clusters.
+func FaceClusters() (result entity.FaceClusters, err error) {
+	err = Db().Order("face_count DESC").Find(&result).Error
+
+	return result, err
+}
+
+// FaceClusterResults returns face clusters with at least minFaces faces, named clusters first.
+// Clusters are restricted to those with faces in photos of the owner if not empty.
+func FaceClusterResults(minFaces, limit, offset int, owner string) (results []FaceClusterResult, err error) {
+	s := Db().Table("face_clusters").
+		Select("face_clusters.cluster_uid, face_clusters.person_uid, face_clusters.face_count, people.display_name AS person_name, " +
+			"faces.photo_uid, files.file_hash, faces.face_x, faces.face_y, faces.face_w, faces.face_h").
+		Joins("LEFT JOIN people ON people.person_uid = face_clusters.person_uid AND people.deleted_at IS NULL")
+
+	if owner != "" {
+		s = s.Joins("JOIN faces ON faces.id = (SELECT MAX(f.id) FROM faces f JOIN photos p ON p.photo_uid = f.photo_uid "+
+			"WHERE f.cluster_uid = face_clusters.cluster_uid AND p.owner_uid = ?)", owner)
+	} else {
+		s = s.Joins("JOIN faces ON faces.id = (SELECT MAX(f.id) FROM faces f WHERE f.cluster_uid = face_clusters.cluster_uid)")
+	}
+
+	err = s.Joins("JOIN files ON files.id = faces.file_id AND files.deleted_at IS NULL").
+		Where("face_clusters.face_count >= ?", minFaces).
+		Order("face_clusters.person_uid = '', face_clusters.face_count DESC").
+		Limit(limit).Offset(offset).
+		Scan(&results).Error
+
+	return results, err
+}
+
+// PhotoFaceResult represents a face of a photo with the name of the person, if known.
+type PhotoFaceResult struct {
+	FaceSrc    string  `json:"Src"`
+	PersonUID  string  `json:"PersonUID"`
+	PersonName string  `json:"PersonName"`
+	FaceX      float32 `json:"X"`
+	FaceY      float32 `json:"Y"`
+	FaceW      float32 `json:"W"`
+	FaceH      float32 `json:"H"`
+}
+
+// PhotoFaces returns the faces of a photo, including the names of the people shown.
+func PhotoFaces(photoUID string) (results []PhotoFaceResult, err error) {
+	err = Db().Table("faces").
+		Select("faces.face_src, faces.person_uid, people.display_name AS person_name, "+
+			"faces.face_x, faces.face_y, faces.face_w, faces.face_h").
+		Joins("LEFT JOIN people ON people.person_uid = faces.person_uid AND faces.person_uid <> '' AND people.deleted_at IS NULL").
+		Where("faces.photo_uid = ?", photoUID).
+		Order("faces.id").
+		Scan(&results).Error
+
+	return results, err
+}
+
+// FaceClusterOwnedBy returns true if the cluster contains faces in photos of the owner.
+func FaceClusterOwnedBy(clusterUID, owner string) bool {
+	var count int
+
+	Db().Table("faces").
+		Joins("JOIN photos ON photos.photo_uid = faces.photo_uid").
+		Where("faces.cluster_uid = ? AND photos.owner_uid = ?", clusterUID, owner).
+		Count(&count)
+
+	return count > 0
+}
+
+// FaceClusterPhotoUIDs returns the UIDs of photos with faces in a cluster.
+func FaceClusterPhotoUIDs(clusterUID string) (result []string, err error) {
+	err = Db().Model(&entity.Face{}).Where("cluster_uid = ?", clusterUID).Pluck("DISTINCT photo_uid", &result).Error
+
+	return result, err
+}
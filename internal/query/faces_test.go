@@ -0,0 +1,103 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFaceClusterResults(t *testing.T) {
+	file := entity.FileFixtures["exampleFileName.jpg"]
+
+	f := entity.NewFace(&file, face.Face{Embedding: face.Embedding{1, 0}})
+
+	if err := f.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	unclustered, err := UnclusteredFaces(1000)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, unclustered)
+
+	cluster := entity.NewFaceCluster()
+
+	if err := cluster.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cluster.AddFace(f); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := FaceClusterResults(1, 100, 0, "")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := false
+
+	for _, r := range results {
+		if r.ClusterUID == cluster.ClusterUID {
+			found = true
+			assert.Equal(t, 1, r.FaceCount)
+			assert.Equal(t, file.FileHash, r.FileHash)
+		}
+	}
+
+	assert.True(t, found)
+
+	// Clusters without faces in photos of the owner are not returned.
+	owned, err := FaceClusterResults(1, 100, 0, "uqxc08w3d0ej2283")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, r := range owned {
+		assert.NotEqual(t, cluster.ClusterUID, r.ClusterUID)
+	}
+
+	assert.False(t, FaceClusterOwnedBy(cluster.ClusterUID, "uqxc08w3d0ej2283"))
+}
+
+func TestPhotoFaces(t *testing.T) {
+	file := entity.FileFixtures["exampleFileName.jpg"]
+
+	person, err := entity.FirstOrCreateSubject("Fiona Face")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := entity.NewFace(&file, face.Face{Area: face.Area{X: 0.3, Y: 0.3, W: 0.1, H: 0.1}, Embedding: face.Embedding{0, 1}})
+	f.PersonUID = person.PersonUID
+
+	if err := f.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := PhotoFaces(file.PhotoUID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := false
+
+	for _, r := range results {
+		if r.PersonUID == person.PersonUID {
+			found = true
+			assert.Equal(t, "Fiona Face", r.PersonName)
+			assert.Equal(t, float32(0.3), r.FaceX)
+		}
+	}
+
+	assert.True(t, found)
+}
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
 )
 
 // FilesByPath returns a slice of files in a given originals folder.
@@ -15,7 +16,7 @@ func FilesByPath(limit, offset int, rootName, pathName string) (files entity.Fil
 	err = Db().
 		Table("files").Select("files.*").
 		Joins("JOIN photos ON photos.id = files.photo_id AND photos.deleted_at IS NULL").
-		Where("files.file_missing = 0").
+		Where("files.file_missing = FALSE").
 		Where("files.file_root = ? AND photos.photo_path = ?", rootName, pathName).
 		Order("files.file_name").
 		Limit(limit).Offset(offset).
@@ -33,7 +34,7 @@ func Files(limit, offset int, pathName string, includeMissing bool) (files entit
 	stmt := Db()
 
 	if !includeMissing {
-		stmt = stmt.Where("file_missing = 0")
+		stmt = stmt.Where("file_missing = FALSE")
 	}
 
 	if pathName != "" {
@@ -47,7 +48,7 @@ func Files(limit, offset int, pathName string, includeMissing bool) (files entit
 
 // FilesByUID
 func FilesByUID(u []string, limit int, offset int) (files entity.Files, err error) {
-	if err := Db().Where("(photo_uid IN (?) AND file_primary = 1) OR file_uid IN (?)", u, u).Preload("Photo").Limit(limit).Offset(offset).Find(&files).Error; err != nil {
+	if err := Db().Where("(photo_uid IN (?) AND file_primary = TRUE) OR file_uid IN (?)", u, u).Preload("Photo").Limit(limit).Offset(offset).Find(&files).Error; err != nil {
 		return files, err
 	}
 
@@ -56,7 +57,16 @@ func FilesByUID(u []string, limit int, offset int) (files entity.Files, err erro
 
 // FileByPhotoUID
 func FileByPhotoUID(u string) (file entity.File, err error) {
-	if err := Db().Where("photo_uid = ? AND file_primary = 1", u).Preload("Photo").First(&file).Error; err != nil {
+	if err := Db().Where("photo_uid = ? AND file_primary = TRUE", u).Preload("Photo").First(&file).Error; err != nil {
+		return file, err
+	}
+
+	return file, nil
+}
+
+// XmpFileByPhotoUID returns the XMP sidecar file of a photo.
+func XmpFileByPhotoUID(u string) (file entity.File, err error) {
+	if err := Db().Where("photo_uid = ? AND file_type = ? AND file_missing = FALSE", u, string(fs.TypeXMP)).First(&file).Error; err != nil {
 		return file, err
 	}
 
@@ -65,7 +75,7 @@ func FileByPhotoUID(u string) (file entity.File, err error) {
 
 // VideoByPhotoUID
 func VideoByPhotoUID(u string) (file entity.File, err error) {
-	if err := Db().Where("photo_uid = ? AND file_video = 1", u).Preload("Photo").First(&file).Error; err != nil {
+	if err := Db().Where("photo_uid = ? AND file_video = TRUE", u).Preload("Photo").First(&file).Error; err != nil {
 		return file, err
 	}
 
@@ -0,0 +1,85 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// FullTextMatch returns a full-text search query for the current sql dialect, or an empty string
+// if the index is not available or the search string does not contain any keywords.
+// Words are stemmed like the indexed text and matched as prefix.
+func FullTextMatch(s string) string {
+	if !entity.FullTextEnabled() {
+		return ""
+	}
+
+	words := txt.StemKeywords(s, entity.FullTextLang())
+
+	if len(words) == 0 {
+		return ""
+	}
+
+	var terms []string
+
+	switch DbDialect() {
+	case MySQL:
+		for _, w := range words {
+			if strings.Contains(w, "-") {
+				terms = append(terms, fmt.Sprintf("+\"%s\"", w))
+			} else {
+				terms = append(terms, fmt.Sprintf("+%s*", w))
+			}
+		}
+
+		return strings.Join(terms, " ")
+	case SQLite:
+		for _, w := range words {
+			terms = append(terms, fmt.Sprintf("\"%s\"*", w))
+		}
+
+		return strings.Join(terms, " AND ")
+	case Postgres:
+		for _, w := range words {
+			if strings.Contains(w, "-") {
+				terms = append(terms, w)
+			} else {
+				terms = append(terms, w+":*")
+			}
+		}
+
+		return strings.Join(terms, " & ")
+	}
+
+	return ""
+}
+
+// FullTextJoin returns a join with the full-text search index and its values, so that results
+// can be sorted by relevance using the ft.score column.
+func FullTextJoin(match string) (join string, values []interface{}) {
+	switch DbDialect() {
+	case MySQL:
+		return fmt.Sprintf("JOIN (SELECT photo_id, MATCH(search_text) AGAINST (? IN BOOLEAN MODE) AS score FROM %s "+
+			"WHERE MATCH(search_text) AGAINST (? IN BOOLEAN MODE)) ft ON ft.photo_id = photos.id", entity.FullTextTable), []interface{}{match, match}
+	case Postgres:
+		return fmt.Sprintf("JOIN (SELECT photo_id, ts_rank(to_tsvector('simple', search_text), to_tsquery('simple', ?)) AS score FROM %s "+
+			"WHERE to_tsvector('simple', search_text) @@ to_tsquery('simple', ?)) ft ON ft.photo_id = photos.id", entity.FullTextTable), []interface{}{match, match}
+	default:
+		return fmt.Sprintf("JOIN (SELECT rowid AS photo_id, -rank AS score FROM %s "+
+			"WHERE %s MATCH ?) ft ON ft.photo_id = photos.id", entity.FullTextTable, entity.FullTextTable), []interface{}{match}
+	}
+}
+
+// FullTextCondition returns a condition for photos that match a full-text search query.
+func FullTextCondition(match string) (where string, values []interface{}) {
+	switch DbDialect() {
+	case MySQL:
+		return fmt.Sprintf("photos.id IN (SELECT photo_id FROM %s WHERE MATCH(search_text) AGAINST (? IN BOOLEAN MODE))", entity.FullTextTable), []interface{}{match}
+	case Postgres:
+		return fmt.Sprintf("photos.id IN (SELECT photo_id FROM %s WHERE to_tsvector('simple', search_text) @@ to_tsquery('simple', ?))", entity.FullTextTable), []interface{}{match}
+	default:
+		return fmt.Sprintf("photos.id IN (SELECT rowid FROM %s WHERE %s MATCH ?)", entity.FullTextTable, entity.FullTextTable), []interface{}{match}
+	}
+}
@@ -0,0 +1,64 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFullTextMatch(t *testing.T) {
+	if !entity.FullTextEnabled() {
+		assert.Equal(t, "", FullTextMatch("frogs"))
+		t.Skip("full-text search index not available")
+	}
+
+	t.Run("stemmed prefix", func(t *testing.T) {
+		switch DbDialect() {
+		case MySQL:
+			assert.Equal(t, "+frog* +lake*", FullTextMatch("Frogs Lakes"))
+		case SQLite:
+			assert.Equal(t, "\"frog\"* AND \"lake\"*", FullTextMatch("Frogs Lakes"))
+		case Postgres:
+			assert.Equal(t, "frog:* & lake:*", FullTextMatch("Frogs Lakes"))
+		}
+	})
+	t.Run("stopwords only", func(t *testing.T) {
+		assert.Equal(t, "", FullTextMatch("the"))
+	})
+}
+
+func TestPhotoSearch_FullText(t *testing.T) {
+	if !entity.FullTextEnabled() {
+		t.Skip("full-text search index not available")
+	}
+
+	t.Run("stemmed keyword", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "frogs"
+		f.Count = 10
+		f.Order = entity.SortOrderRelevance
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+	})
+	t.Run("no result", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "xylophonist"
+		f.Count = 10
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 0)
+	})
+}
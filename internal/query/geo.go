@@ -33,10 +33,19 @@ func Geo(f form.GeoSearch) (results GeoResults, err error) {
 		photos.photo_title, photos.photo_description, photos.photo_favorite, photos.taken_at, files.file_hash, files.file_width, 
 		files.file_height`).
 		Joins(`JOIN files ON files.photo_id = photos.id AND 
-		files.file_missing = 0 AND files.file_primary AND files.deleted_at IS NULL`).
+		files.file_missing = FALSE AND files.file_primary AND files.deleted_at IS NULL`).
 		Where("photos.deleted_at IS NULL").
 		Where("photos.photo_lat <> 0")
 
+	// Restrict results to photos owned by or shared with a user.
+	if f.Owner != "" {
+		if f.Shared != "" {
+			s = s.Where("photos.owner_uid = ? OR photos.photo_uid IN (SELECT pa.photo_uid FROM photos_albums pa WHERE pa.hidden = FALSE AND pa.album_uid IN (?))", f.Owner, strings.Split(f.Shared, ","))
+		} else {
+			s = s.Where("photos.owner_uid = ?", f.Owner)
+		}
+	}
+
 	f.Query = txt.Clip(f.Query, txt.ClipKeyword)
 
 	if f.Query != "" {
@@ -78,7 +87,7 @@ func Geo(f form.GeoSearch) (results GeoResults, err error) {
 	}
 
 	if f.Album != "" {
-		s = s.Joins("JOIN photos_albums ON photos_albums.photo_uid = photos.photo_uid").Where("photos_albums.hidden = 0 AND photos_albums.album_uid = ?", f.Album)
+		s = s.Joins("JOIN photos_albums ON photos_albums.photo_uid = photos.photo_uid").Where("photos_albums.hidden = FALSE AND photos_albums.album_uid = ?", f.Album)
 	}
 
 	if f.Camera > 0 {
@@ -102,7 +111,7 @@ func Geo(f form.GeoSearch) (results GeoResults, err error) {
 	}
 
 	if f.Favorite {
-		s = s.Where("photos.photo_favorite = 1")
+		s = s.Where("photos.photo_favorite = TRUE")
 	}
 
 	if f.Country != "" {
@@ -147,9 +156,9 @@ func Geo(f form.GeoSearch) (results GeoResults, err error) {
 		s = s.Where("photos.deleted_at IS NULL")
 
 		if f.Private {
-			s = s.Where("photos.photo_private = 1")
+			s = s.Where("photos.photo_private = TRUE")
 		} else if f.Public {
-			s = s.Where("photos.photo_private = 0")
+			s = s.Where("photos.photo_private = FALSE")
 		}
 
 		if f.Review {
@@ -160,7 +169,7 @@ func Geo(f form.GeoSearch) (results GeoResults, err error) {
 	}
 
 	if f.Favorite {
-		s = s.Where("photos.photo_favorite = 1")
+		s = s.Where("photos.photo_favorite = TRUE")
 	}
 
 	if f.S2 != "" {
@@ -68,11 +68,11 @@ func Labels(f form.LabelSearch) (results []LabelResult, err error) {
 	}
 
 	if f.Favorite {
-		s = s.Where("labels.label_favorite = 1")
+		s = s.Where("labels.label_favorite = TRUE")
 	}
 
 	if !f.All {
-		s = s.Where("labels.label_priority >= 0 OR labels.label_favorite = 1")
+		s = s.Where("labels.label_priority >= 0 OR labels.label_favorite = TRUE")
 	}
 
 	switch f.Order {
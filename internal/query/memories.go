@@ -0,0 +1,244 @@
+package query
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/phash"
+)
+
+// MemoryQuality is the default minimum quality of photos shown as memories.
+const MemoryQuality = 3
+
+// HighlightCount is the maximum number of daily highlights.
+var HighlightCount = 5
+
+// Memory represents a photo taken on the same day in a previous year.
+type Memory struct {
+	PhotoUID         string    `json:"UID"`
+	PhotoType        string    `json:"Type"`
+	PhotoTitle       string    `json:"Title"`
+	PhotoDescription string    `json:"Description"`
+	PhotoFavorite    bool      `json:"Favorite"`
+	PhotoQuality     int       `json:"Quality"`
+	PhotoYear        int       `json:"Year"`
+	TakenAt          time.Time `json:"TakenAt"`
+	TakenAtLocal     time.Time `json:"TakenAtLocal"`
+	YearsAgo         int       `json:"YearsAgo"`
+	FileUID          string    `json:"FileUID"`
+	FileHash         string    `json:"Hash"`
+	FileWidth        int       `json:"Width"`
+	FileHeight       int       `json:"Height"`
+	FileDHash        string    `json:"-"`
+	FilePHash        string    `json:"-"`
+}
+
+// Memories represents a list of memories.
+type Memories []Memory
+
+// better returns true if the memory should be shown rather than the other one.
+func (m Memory) better(other Memory) bool {
+	if m.PhotoFavorite != other.PhotoFavorite {
+		return m.PhotoFavorite
+	}
+
+	if m.PhotoQuality != other.PhotoQuality {
+		return m.PhotoQuality > other.PhotoQuality
+	}
+
+	if res, otherRes := m.FileWidth*m.FileHeight, other.FileWidth*other.FileHeight; res != otherRes {
+		return res > otherRes
+	}
+
+	return m.TakenAt.Before(other.TakenAt)
+}
+
+// similar returns true if both photos were taken in the same year and their perceptual hashes are similar.
+func (m Memory) similar(other Memory, dist int) bool {
+	if m.PhotoYear != other.PhotoYear {
+		return false
+	}
+
+	if m.FileHash != "" && m.FileHash == other.FileHash {
+		return true
+	}
+
+	if m.FileDHash == "" || m.FilePHash == "" || other.FileDHash == "" || other.FilePHash == "" {
+		return false
+	}
+
+	d1, err1 := phash.Parse(m.FileDHash)
+	d2, err2 := phash.Parse(other.FileDHash)
+	p1, err3 := phash.Parse(m.FilePHash)
+	p2, err4 := phash.Parse(other.FilePHash)
+
+	if err1 != nil || err2 != nil || err3 != nil || err4 != nil {
+		return false
+	}
+
+	return d1.Distance(d2) <= dist && p1.Distance(p2) <= dist
+}
+
+// Collapse sorts memories by year, most recent years first, and keeps only the best photo of each
+// group of near-duplicates taken in the same year.
+func (m Memories) Collapse(dist int) (results Memories) {
+	sort.SliceStable(m, func(i, j int) bool {
+		if m[i].PhotoYear != m[j].PhotoYear {
+			return m[i].PhotoYear > m[j].PhotoYear
+		}
+
+		return m[i].better(m[j])
+	})
+
+	for _, c := range m {
+		duplicate := false
+
+		// Only compare with photos of the same year, which are at the end of the list.
+		for i := len(results) - 1; i >= 0 && results[i].PhotoYear == c.PhotoYear; i-- {
+			if c.similar(results[i], dist) {
+				duplicate = true
+				break
+			}
+		}
+
+		if !duplicate {
+			results = append(results, c)
+		}
+	}
+
+	return results
+}
+
+// Highlights returns the first memory of each year up to limit, which is the best one
+// if the memories are sorted as returned by MemoriesOnDay.
+func (m Memories) Highlights(limit int) (results Memories) {
+	for _, c := range m {
+		if limit > 0 && len(results) >= limit {
+			break
+		}
+
+		if n := len(results); n > 0 && results[n-1].PhotoYear == c.PhotoYear {
+			continue
+		}
+
+		results = append(results, c)
+	}
+
+	return results
+}
+
+// memoryColumns contains the columns selected for memories.
+const memoryColumns = "photos.photo_uid, photos.photo_type, photos.photo_title, photos.photo_description, " +
+	"photos.photo_favorite, photos.photo_quality, photos.photo_year, photos.taken_at, photos.taken_at_local, " +
+	"files.file_uid, files.file_hash, files.file_width, files.file_height, files.file_d_hash, files.file_p_hash"
+
+// memorySearch returns a query for public memories, optionally restricted to photos owned by or shared with a user.
+func memorySearch(f form.MemorySearch) *gorm.DB {
+	s := UnscopedDb().Table("photos").
+		Select(memoryColumns).
+		Joins("JOIN files ON files.photo_id = photos.id AND files.file_primary = TRUE AND files.file_missing = FALSE AND files.deleted_at IS NULL").
+		Where("photos.deleted_at IS NULL AND photos.photo_private = FALSE")
+
+	// Restrict results to photos owned by or shared with a user.
+	if f.Owner != "" {
+		if f.Shared != "" {
+			s = s.Where("photos.owner_uid = ? OR photos.photo_uid IN (SELECT pa.photo_uid FROM photos_albums pa WHERE pa.hidden = FALSE AND pa.album_uid IN (?))", f.Owner, strings.Split(f.Shared, ","))
+		} else {
+			s = s.Where("photos.owner_uid = ?", f.Owner)
+		}
+	}
+
+	return s
+}
+
+// MemoriesOnDay returns photos taken on the same day in previous years, most recent years first.
+// Only favorites and photos with at least the given quality are included, near-duplicates
+// taken in the same year are collapsed into the best photo.
+func MemoriesOnDay(f form.MemorySearch) (results Memories, err error) {
+	now := time.Now()
+
+	if f.Year <= 0 {
+		f.Year = now.Year()
+	}
+
+	if f.Month == 0 && f.Day == 0 {
+		f.Month = int(now.Month())
+		f.Day = now.Day()
+	}
+
+	if f.Month < 1 || f.Month > 12 || f.Day < 1 || f.Day > 31 {
+		return results, fmt.Errorf("invalid date")
+	}
+
+	if f.Quality <= 0 {
+		f.Quality = MemoryQuality
+	}
+
+	if f.Dist <= 0 {
+		f.Dist = DuplicateDist
+	} else if f.Dist > DuplicateDistMax {
+		f.Dist = DuplicateDistMax
+	}
+
+	s := memorySearch(f).
+		Where("photos.photo_month = ? AND photos.photo_day = ?", f.Month, f.Day).
+		Where("photos.photo_year > 0 AND photos.photo_year < ?", f.Year)
+
+	if f.Favorite {
+		s = s.Where("photos.photo_favorite = TRUE")
+	} else {
+		s = s.Where("photos.photo_quality >= ? OR photos.photo_favorite = TRUE", f.Quality)
+	}
+
+	var photos Memories
+
+	if err := s.Order("photos.photo_year DESC, photos.taken_at").Scan(&photos).Error; err != nil {
+		return results, err
+	}
+
+	results = photos.Collapse(f.Dist)
+
+	for i := range results {
+		results[i].YearsAgo = f.Year - results[i].PhotoYear
+	}
+
+	if f.Count > 0 && f.Count < len(results) {
+		results = results[:f.Count]
+	}
+
+	return results, nil
+}
+
+// HighlightsOnDay returns the memories picked as highlights of a day. Stored highlights are picked
+// from all photos, so highlights of users who may only see their own and shared photos are picked
+// from their memories instead.
+func HighlightsOnDay(date time.Time, f form.MemorySearch) (results Memories, err error) {
+	if f.Owner != "" {
+		f.Year, f.Month, f.Day = date.Year(), int(date.Month()), date.Day()
+
+		memories, err := MemoriesOnDay(f)
+
+		if err != nil {
+			return results, err
+		}
+
+		return memories.Highlights(HighlightCount), nil
+	}
+
+	s := memorySearch(f).
+		Select(memoryColumns+", h.years_ago").
+		Joins("JOIN highlights h ON h.photo_uid = photos.photo_uid").
+		Where("h.highlight_date = ?", date.Format(entity.HighlightDateFormat)).
+		Order("h.years_ago")
+
+	if err := s.Scan(&results).Error; err != nil {
+		return results, err
+	}
+
+	return results, nil
+}
@@ -0,0 +1,109 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemories_Collapse(t *testing.T) {
+	memories := Memories{
+		{PhotoUID: "pt9jtdre2lvl0y01", PhotoYear: 2018, PhotoQuality: 3, FileDHash: "f0f0f0f0f0f0f0f0", FilePHash: "0f0f0f0f0f0f0f0f"},
+		{PhotoUID: "pt9jtdre2lvl0y02", PhotoYear: 2018, PhotoQuality: 5, FileDHash: "f0f0f0f0f0f0f0f1", FilePHash: "0f0f0f0f0f0f0f0f"},
+		{PhotoUID: "pt9jtdre2lvl0y03", PhotoYear: 2018, PhotoQuality: 4, FileDHash: "123456789abcdef0", FilePHash: "fedcba9876543210"},
+		{PhotoUID: "pt9jtdre2lvl0y04", PhotoYear: 2019, PhotoQuality: 3, FileDHash: "f0f0f0f0f0f0f0f0", FilePHash: "0f0f0f0f0f0f0f0f"},
+		{PhotoUID: "pt9jtdre2lvl0y05", PhotoYear: 2016, PhotoQuality: 3, PhotoFavorite: true},
+		{PhotoUID: "pt9jtdre2lvl0y06", PhotoYear: 2016, PhotoQuality: 6},
+	}
+
+	result := memories.Collapse(DuplicateDist)
+
+	if len(result) != 5 {
+		t.Fatalf("5 memories expected: %+v", result)
+	}
+
+	assert.Equal(t, "pt9jtdre2lvl0y04", result[0].PhotoUID)
+	assert.Equal(t, "pt9jtdre2lvl0y02", result[1].PhotoUID)
+	assert.Equal(t, "pt9jtdre2lvl0y03", result[2].PhotoUID)
+	assert.Equal(t, "pt9jtdre2lvl0y05", result[3].PhotoUID)
+	assert.Equal(t, "pt9jtdre2lvl0y06", result[4].PhotoUID)
+
+	t.Run("highlights", func(t *testing.T) {
+		highlights := result.Highlights(2)
+
+		if len(highlights) != 2 {
+			t.Fatalf("2 highlights expected: %+v", highlights)
+		}
+
+		assert.Equal(t, "pt9jtdre2lvl0y04", highlights[0].PhotoUID)
+		assert.Equal(t, "pt9jtdre2lvl0y02", highlights[1].PhotoUID)
+		assert.Len(t, result.Highlights(0), 3)
+	})
+}
+
+func TestMemoriesOnDay(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		results, err := MemoriesOnDay(form.MemorySearch{Year: 2020, Month: 7, Day: 17})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, m := range results {
+			assert.Less(t, m.PhotoYear, 2020)
+			assert.Equal(t, 2020-m.PhotoYear, m.YearsAgo)
+		}
+	})
+	t.Run("favorites", func(t *testing.T) {
+		results, err := MemoriesOnDay(form.MemorySearch{Month: 2, Day: 1, Favorite: true, Count: 1})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, len(results), 1)
+
+		for _, m := range results {
+			assert.True(t, m.PhotoFavorite)
+		}
+	})
+	t.Run("invalid date", func(t *testing.T) {
+		_, err := MemoriesOnDay(form.MemorySearch{Month: 13, Day: 1})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestHighlightsOnDay(t *testing.T) {
+	t.Run("stored", func(t *testing.T) {
+		results, err := HighlightsOnDay(time.Date(2020, 10, 17, 0, 0, 0, 0, time.UTC), form.MemorySearch{})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i := 1; i < len(results); i++ {
+			assert.LessOrEqual(t, results[i-1].YearsAgo, results[i].YearsAgo)
+		}
+	})
+	t.Run("owner", func(t *testing.T) {
+		date := time.Date(2020, 10, 17, 0, 0, 0, 0, time.UTC)
+		f := form.MemorySearch{Owner: "uqxetse3cy5eo9z2"}
+
+		results, err := HighlightsOnDay(date, f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		memories, err := MemoriesOnDay(form.MemorySearch{Year: 2020, Month: 10, Day: 17, Owner: f.Owner})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, memories.Highlights(HighlightCount), results)
+	})
+}
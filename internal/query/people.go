@@ -0,0 +1,110 @@
+package query
+
+import (
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+// UserResult contains user account details without credentials like the API token.
+type UserResult struct {
+	PersonUID     string    `json:"UID"`
+	UserName      string    `json:"UserName"`
+	FirstName     string    `json:"FirstName"`
+	LastName      string    `json:"LastName"`
+	DisplayName   string    `json:"DisplayName"`
+	UserEmail     string    `json:"Email"`
+	UserActive    bool      `json:"Active"`
+	UserConfirmed bool      `json:"Confirmed"`
+	RoleAdmin     bool      `json:"Admin"`
+	RoleGuest     bool      `json:"Guest"`
+	RoleChild     bool      `json:"Child"`
+	RoleFamily    bool      `json:"Family"`
+	RoleFriend    bool      `json:"Friend"`
+	WebDAV        bool      `json:"WebDAV"`
+	AuthProvider  string    `json:"AuthProvider"`
+	CreatedAt     time.Time `json:"CreatedAt"`
+	UpdatedAt     time.Time `json:"UpdatedAt"`
+}
+
+type UserResults []UserResult
+
+// NewUserResults returns the account details of users without credentials.
+func NewUserResults(people entity.People) UserResults {
+	results := make(UserResults, len(people))
+
+	for i, m := range people {
+		results[i] = UserResult{
+			PersonUID:     m.PersonUID,
+			UserName:      m.UserName,
+			FirstName:     m.FirstName,
+			LastName:      m.LastName,
+			DisplayName:   m.DisplayName,
+			UserEmail:     m.UserEmail,
+			UserActive:    m.UserActive,
+			UserConfirmed: m.UserConfirmed,
+			RoleAdmin:     m.RoleAdmin,
+			RoleGuest:     m.RoleGuest,
+			RoleChild:     m.RoleChild,
+			RoleFamily:    m.RoleFamily,
+			RoleFriend:    m.RoleFriend,
+			WebDAV:        m.WebDAV,
+			AuthProvider:  m.AuthProvider,
+			CreatedAt:     m.CreatedAt,
+			UpdatedAt:     m.UpdatedAt,
+		}
+	}
+
+	return results
+}
+
+// UserSearch returns a list of registered users.
+func UserSearch(f form.UserSearch) (result entity.People, err error) {
+	s := Db().Where("user_name <> '' AND person_uid NOT IN (?)", []string{entity.UnknownPerson.PersonUID, entity.Guest.PersonUID})
+
+	if f.Query != "" {
+		like := "%" + strings.ToLower(f.Query) + "%"
+		s = s.Where("LOWER(user_name) LIKE ? OR LOWER(display_name) LIKE ? OR LOWER(user_email) LIKE ?", like, like, like)
+	}
+
+	switch acl.Role(f.Role) {
+	case acl.RoleAdmin:
+		s = s.Where("role_admin = TRUE")
+	case acl.RoleFamily:
+		s = s.Where("role_family = TRUE")
+	case acl.RoleFriend:
+		s = s.Where("role_friend = TRUE")
+	case acl.RoleChild:
+		s = s.Where("role_child = TRUE")
+	case acl.RoleGuest:
+		s = s.Where("role_guest = TRUE")
+	}
+
+	if f.Active {
+		s = s.Where("user_active = TRUE")
+	}
+
+	switch f.Order {
+	case "newest":
+		s = s.Order("created_at DESC")
+	case "name":
+		s = s.Order("display_name ASC, user_name ASC")
+	default:
+		s = s.Order("user_name ASC")
+	}
+
+	if f.Count > 0 && f.Count <= MaxResults {
+		s = s.Limit(f.Count).Offset(f.Offset)
+	} else {
+		s = s.Limit(MaxResults).Offset(f.Offset)
+	}
+
+	if err := s.Find(&result).Error; err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
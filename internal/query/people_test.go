@@ -0,0 +1,62 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserSearch(t *testing.T) {
+	t.Run("all users", func(t *testing.T) {
+		r, err := UserSearch(form.UserSearch{Count: 10})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(r))
+
+		for _, user := range r {
+			assert.NotEmpty(t, user.UserName)
+		}
+	})
+	t.Run("admins", func(t *testing.T) {
+		r, err := UserSearch(form.UserSearch{Role: "admin", Active: true, Count: 10})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(r))
+
+		for _, user := range r {
+			assert.True(t, user.RoleAdmin)
+		}
+	})
+	t.Run("results", func(t *testing.T) {
+		r, err := UserSearch(form.UserSearch{Role: "admin", Count: 10})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		results := NewUserResults(r)
+
+		assert.Len(t, results, len(r))
+
+		for i, user := range results {
+			assert.Equal(t, r[i].PersonUID, user.PersonUID)
+			assert.True(t, user.RoleAdmin)
+		}
+	})
+	t.Run("no match", func(t *testing.T) {
+		r, err := UserSearch(form.UserSearch{Query: "xxx-no-user", Count: 10})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, r)
+	})
+}
@@ -74,8 +74,8 @@ func PhotosMissing(limit int, offset int) (entities entity.Photos, err error) {
 	err = Db().
 		Select("photos.*").
 		Joins("JOIN files a ON photos.id = a.photo_id ").
-		Joins("LEFT JOIN files b ON a.photo_id = b.photo_id AND a.id != b.id AND b.file_missing = 0").
-		Where("a.file_missing = 1 AND b.id IS NULL").
+		Joins("LEFT JOIN files b ON a.photo_id = b.photo_id AND a.id != b.id AND b.file_missing = FALSE").
+		Where("a.file_missing = TRUE AND b.id IS NULL").
 		Where("photos.photo_type <> ?", entity.TypeText).
 		Group("photos.id").
 		Limit(limit).Offset(offset).Find(&entities).Error
@@ -86,7 +86,7 @@ func PhotosMissing(limit int, offset int) (entities entity.Photos, err error) {
 // ResetPhotoQuality resets the quality of photos without primary file to -1.
 func ResetPhotoQuality() error {
 	return Db().Table("photos").
-		Where("id IN (SELECT photos.id FROM photos LEFT JOIN files ON photos.id = files.photo_id AND files.file_primary = 1 WHERE files.id IS NULL GROUP BY photos.id)").
+		Where("id IN (SELECT photos.id FROM photos LEFT JOIN files ON photos.id = files.photo_id AND files.file_primary = TRUE WHERE files.id IS NULL GROUP BY photos.id)").
 		Update("photo_quality", -1).Error
 }
 
@@ -109,3 +109,27 @@ func PhotosCheck(limit int, offset int) (entities entity.Photos, err error) {
 
 	return entities, err
 }
+
+// PhotoInAlbums returns true if a photo is visible in at least one of the given albums.
+func PhotoInAlbums(photoUID string, albums []string) bool {
+	if photoUID == "" || len(albums) == 0 {
+		return false
+	}
+
+	result := entity.PhotoAlbum{}
+
+	return Db().Where("photo_uid = ? AND hidden = FALSE AND album_uid IN (?)", photoUID, albums).First(&result).Error == nil
+}
+
+// OwnedPhotoUIDs returns the UIDs of photos owned by a user, including archived photos.
+func OwnedPhotoUIDs(uids []string, ownerUID string) (result []string, err error) {
+	if len(uids) == 0 || ownerUID == "" {
+		return result, nil
+	}
+
+	err = UnscopedDb().Model(&entity.Photo{}).
+		Where("photo_uid IN (?) AND owner_uid = ?", uids, ownerUID).
+		Pluck("photo_uid", &result).Error
+
+	return result, err
+}
@@ -0,0 +1,297 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gosimple/slug"
+	"github.com/jinzhu/inflection"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// photoExprNumbers maps numeric search expression fields to their column.
+var photoExprNumbers = map[string]string{
+	"iso":     "photos.photo_iso",
+	"f":       "photos.photo_f_number",
+	"mm":      "photos.photo_focal_length",
+	"year":    "photos.photo_year",
+	"month":   "photos.photo_month",
+	"day":     "photos.photo_day",
+	"quality": "photos.photo_quality",
+	"chroma":  "files.file_chroma",
+	"lat":     "photos.photo_lat",
+	"lng":     "photos.photo_lng",
+}
+
+// photoExprFloats contains float columns that need a tolerance when compared for equality.
+var photoExprFloats = map[string]float64{
+	"f":   0.05,
+	"lat": 0.0001,
+	"lng": 0.0001,
+}
+
+// photoExprDates maps date search expression fields to their column.
+var photoExprDates = map[string]string{
+	"taken": "photos.taken_at",
+	"added": "photos.created_at",
+}
+
+// photoExprBools maps boolean search expression fields to their condition.
+var photoExprBools = map[string]string{
+	"favorite": "photos.photo_favorite = TRUE",
+	"private":  "photos.photo_private = TRUE",
+	"public":   "photos.photo_private = FALSE",
+	"scan":     "photos.photo_scan = TRUE",
+	"video":    "photos.photo_type = 'video'",
+	"portrait": "files.file_portrait = TRUE",
+	"mono":     "files.file_chroma = 0",
+}
+
+// PhotoExpr compiles a search expression to an SQL condition with values for use in PhotoSearch,
+// which joins the files, cameras, lenses and places tables.
+func PhotoExpr(expr form.QueryExpr) (where string, values []interface{}, err error) {
+	switch e := expr.(type) {
+	case form.QueryAnd:
+		return photoExprJoin(e, " AND ")
+	case form.QueryOr:
+		return photoExprJoin(e, " OR ")
+	case form.QueryNot:
+		if where, values, err = PhotoExpr(e.Expr); err != nil {
+			return "", nil, err
+		}
+
+		return fmt.Sprintf("NOT (%s)", where), values, nil
+	case form.QueryTerm:
+		return photoExprTerm(e)
+	default:
+		return "", nil, fmt.Errorf("unsupported search expression %T", expr)
+	}
+}
+
+// photoExprJoin compiles and joins a list of search expressions.
+func photoExprJoin(exprs []form.QueryExpr, sep string) (where string, values []interface{}, err error) {
+	wheres := make([]string, len(exprs))
+
+	for i, e := range exprs {
+		w, v, err := PhotoExpr(e)
+
+		if err != nil {
+			return "", nil, err
+		}
+
+		wheres[i] = "(" + w + ")"
+		values = append(values, v...)
+	}
+
+	return strings.Join(wheres, sep), values, nil
+}
+
+// photoExprLike returns a LIKE pattern where * is used as wildcard, or which
+// matches anywhere in the string if no wildcard was specified.
+func photoExprLike(s string) string {
+	s = strings.ToLower(s)
+
+	if strings.Contains(s, "*") {
+		return strings.ReplaceAll(s, "*", "%")
+	}
+
+	return "%" + s + "%"
+}
+
+// photoExprSlugs returns the slug of a name and its singular form.
+func photoExprSlugs(s string) []string {
+	result := []string{slug.Make(s)}
+
+	if singular := inflection.Singular(s); singular != s {
+		result = append(result, slug.Make(singular))
+	}
+
+	return result
+}
+
+// photoExprKeyword returns a keyword condition like LikeAny for a single word.
+func photoExprKeyword(s string) (where string, values []interface{}) {
+	s = strings.ToLower(s)
+
+	where = "photos.id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE k.keyword LIKE ? OR k.keyword = ?)"
+
+	if len(s) > 3 {
+		return where, []interface{}{s + "%", inflection.Singular(s)}
+	}
+
+	return where, []interface{}{s, inflection.Singular(s)}
+}
+
+// photoExprLabel returns a condition for photos with a label, including its categories.
+func photoExprLabel(s string) (where string, values []interface{}) {
+	slugs := photoExprSlugs(s)
+
+	where = "photos.id IN (SELECT pl.photo_id FROM photos_labels pl WHERE pl.uncertainty < 100 AND pl.label_id IN (" +
+		"SELECT l.id FROM labels l WHERE l.deleted_at IS NULL AND (l.label_slug IN (?) OR l.custom_slug IN (?)) UNION " +
+		"SELECT c.label_id FROM categories c JOIN labels l ON l.id = c.category_id WHERE l.deleted_at IS NULL AND (l.label_slug IN (?) OR l.custom_slug IN (?))))"
+
+	return where, []interface{}{slugs, slugs, slugs, slugs}
+}
+
+// photoExprTerm compiles a single search term.
+func photoExprTerm(t form.QueryTerm) (where string, values []interface{}, err error) {
+	if t.Key == "" {
+		// Phrases are matched against title and description, words against keywords and labels.
+		if strings.Contains(t.Value, " ") {
+			like := photoExprLike(t.Value)
+			return "LOWER(photos.photo_title) LIKE ? OR LOWER(photos.photo_description) LIKE ?", []interface{}{like, like}, nil
+		}
+
+		if match := FullTextMatch(t.Value); match != "" {
+			where, values = FullTextCondition(match)
+			return where, values, nil
+		}
+
+		kw, kwValues := photoExprKeyword(t.Value)
+		l, lValues := photoExprLabel(t.Value)
+
+		return kw + " OR " + l, append(kwValues, lValues...), nil
+	}
+
+	if col, ok := photoExprNumbers[t.Key]; ok {
+		return photoExprNumber(t, col)
+	} else if col, ok := photoExprDates[t.Key]; ok {
+		return photoExprDate(t, col)
+	} else if cond, ok := photoExprBools[t.Key]; ok {
+		if txt.Bool(t.Value) {
+			return cond, nil, nil
+		}
+
+		return fmt.Sprintf("NOT (%s)", cond), nil, nil
+	}
+
+	v := strings.ToLower(t.Value)
+
+	switch t.Key {
+	case "label":
+		where, values = photoExprLabel(t.Value)
+		return where, values, nil
+	case "keyword":
+		where, values = photoExprKeyword(t.Value)
+		return where, values, nil
+	case "title":
+		return "LOWER(photos.photo_title) LIKE ?", []interface{}{photoExprLike(t.Value)}, nil
+	case "description":
+		return "LOWER(photos.photo_description) LIKE ?", []interface{}{photoExprLike(t.Value)}, nil
+	case "name":
+		return "photos.photo_name LIKE ?", []interface{}{strings.ReplaceAll(t.Value, "*", "%")}, nil
+	case "original":
+		return "photos.original_name LIKE ?", []interface{}{strings.ReplaceAll(t.Value, "*", "%")}, nil
+	case "path", "folder":
+		return "photos.photo_path LIKE ?", []interface{}{strings.ReplaceAll(strings.Trim(t.Value, "/"), "*", "%")}, nil
+	case "camera":
+		if id, err := strconv.Atoi(t.Value); err == nil {
+			return "photos.camera_id = ?", []interface{}{id}, nil
+		}
+
+		like := photoExprLike(t.Value)
+
+		return "LOWER(cameras.camera_make) LIKE ? OR LOWER(cameras.camera_model) LIKE ?", []interface{}{like, like}, nil
+	case "lens":
+		if id, err := strconv.Atoi(t.Value); err == nil {
+			return "photos.lens_id = ?", []interface{}{id}, nil
+		}
+
+		like := photoExprLike(t.Value)
+
+		return "LOWER(lenses.lens_make) LIKE ? OR LOWER(lenses.lens_model) LIKE ?", []interface{}{like, like}, nil
+	case "country":
+		return "photos.photo_country = ?", []interface{}{v}, nil
+	case "state":
+		return "places.loc_state = ?", []interface{}{t.Value}, nil
+	case "city":
+		return "places.loc_city = ?", []interface{}{t.Value}, nil
+	case "category":
+		return "photos.location_id IN (SELECT locations.id FROM locations WHERE locations.loc_category = ?)", []interface{}{v}, nil
+	case "color":
+		return "files.file_main_color = ?", []interface{}{v}, nil
+	case "type":
+		return "photos.photo_type = ?", []interface{}{v}, nil
+	case "person":
+		where = "photos.id IN (SELECT faces.photo_id FROM faces JOIN people ON people.person_uid = faces.person_uid " +
+			"WHERE people.deleted_at IS NULL AND %s)"
+
+		if rnd.IsPPID(t.Value, 'u') {
+			return fmt.Sprintf(where, "people.person_uid = ?"), []interface{}{t.Value}, nil
+		}
+
+		return fmt.Sprintf(where, "LOWER(people.display_name) = ?"), []interface{}{v}, nil
+	case "album":
+		return "photos.photo_uid IN (SELECT pa.photo_uid FROM photos_albums pa JOIN albums a ON a.album_uid = pa.album_uid " +
+			"WHERE pa.hidden = FALSE AND a.deleted_at IS NULL AND (a.album_uid = ? OR a.album_slug = ?))", []interface{}{t.Value, v}, nil
+	}
+
+	return "", nil, fmt.Errorf("unknown filter: %s", t.Key)
+}
+
+// photoExprNumber compiles a numeric comparison or range.
+func photoExprNumber(t form.QueryTerm, col string) (where string, values []interface{}, err error) {
+	value, err := strconv.ParseFloat(t.Value, 64)
+
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid number for %s: %s", t.Key, t.Value)
+	}
+
+	switch t.Op {
+	case form.QueryOpEqual:
+		if delta, ok := photoExprFloats[t.Key]; ok {
+			return col + " BETWEEN ? AND ?", []interface{}{value - delta, value + delta}, nil
+		}
+
+		return col + " = ?", []interface{}{value}, nil
+	case form.QueryOpRange:
+		maxValue, err := strconv.ParseFloat(t.Max, 64)
+
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid number for %s: %s", t.Key, t.Max)
+		}
+
+		return col + " BETWEEN ? AND ?", []interface{}{value, maxValue}, nil
+	case form.QueryOpLess, form.QueryOpLessEqual, form.QueryOpGreater, form.QueryOpGreaterEqual:
+		return fmt.Sprintf("%s %s ?", col, t.Op), []interface{}{value}, nil
+	}
+
+	return "", nil, fmt.Errorf("operator %s not supported for %s", t.Op, t.Key)
+}
+
+// photoExprDate compiles a date comparison or range, where dates can be a year, month or day.
+func photoExprDate(t form.QueryTerm, col string) (where string, values []interface{}, err error) {
+	const layout = "2006-01-02"
+
+	start, end, err := form.QueryDateRange(t.Value)
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	switch t.Op {
+	case form.QueryOpEqual:
+		return col + " >= ? AND " + col + " < ?", []interface{}{start.Format(layout), end.Format(layout)}, nil
+	case form.QueryOpRange:
+		_, maxEnd, err := form.QueryDateRange(t.Max)
+
+		if err != nil {
+			return "", nil, err
+		}
+
+		return col + " >= ? AND " + col + " < ?", []interface{}{start.Format(layout), maxEnd.Format(layout)}, nil
+	case form.QueryOpLess:
+		return col + " < ?", []interface{}{start.Format(layout)}, nil
+	case form.QueryOpLessEqual:
+		return col + " < ?", []interface{}{end.Format(layout)}, nil
+	case form.QueryOpGreater:
+		return col + " >= ?", []interface{}{end.Format(layout)}, nil
+	case form.QueryOpGreaterEqual:
+		return col + " >= ?", []interface{}{start.Format(layout)}, nil
+	}
+
+	return "", nil, fmt.Errorf("operator %s not supported for %s", t.Op, t.Key)
+}
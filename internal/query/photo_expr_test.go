@@ -0,0 +1,134 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhotoExpr(t *testing.T) {
+	t.Run("or", func(t *testing.T) {
+		expr, err := form.ParseQueryExpr("country:DE OR color:red")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		where, values, err := PhotoExpr(expr)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "(photos.photo_country = ?) OR (files.file_main_color = ?)", where)
+		assert.Equal(t, []interface{}{"de", "red"}, values)
+	})
+	t.Run("not", func(t *testing.T) {
+		expr, err := form.ParseQueryExpr("favorite:true -type:video")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		where, values, err := PhotoExpr(expr)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "(photos.photo_favorite = TRUE) AND (NOT (photos.photo_type = ?))", where)
+		assert.Equal(t, []interface{}{"video"}, values)
+	})
+	t.Run("number range", func(t *testing.T) {
+		where, values, err := PhotoExpr(form.QueryTerm{Key: "iso", Op: form.QueryOpRange, Value: "100", Max: "400"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "photos.photo_iso BETWEEN ? AND ?", where)
+		assert.Equal(t, []interface{}{float64(100), float64(400)}, values)
+	})
+	t.Run("number comparison", func(t *testing.T) {
+		where, values, err := PhotoExpr(form.QueryTerm{Key: "mm", Op: form.QueryOpGreaterEqual, Value: "200"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "photos.photo_focal_length >= ?", where)
+		assert.Equal(t, []interface{}{float64(200)}, values)
+	})
+	t.Run("date range", func(t *testing.T) {
+		where, values, err := PhotoExpr(form.QueryTerm{Key: "taken", Op: form.QueryOpRange, Value: "2019-06", Max: "2019-08"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "photos.taken_at >= ? AND photos.taken_at < ?", where)
+		assert.Equal(t, []interface{}{"2019-06-01", "2019-09-01"}, values)
+	})
+	t.Run("date comparison", func(t *testing.T) {
+		where, values, err := PhotoExpr(form.QueryTerm{Key: "taken", Op: form.QueryOpGreater, Value: "2019"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "photos.taken_at >= ?", where)
+		assert.Equal(t, []interface{}{"2020-01-01"}, values)
+	})
+	t.Run("unknown filter", func(t *testing.T) {
+		_, _, err := PhotoExpr(form.QueryTerm{Key: "foo", Op: form.QueryOpEqual, Value: "bar"})
+		assert.Error(t, err)
+	})
+}
+
+func TestPhotoSearch_Expr(t *testing.T) {
+	t.Run("label or label", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "label:flower OR label:cake"
+		f.Count = 100
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		flowers, _, err := PhotoSearch(form.PhotoSearch{Label: "flower", Count: 100})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), len(flowers))
+	})
+	t.Run("year range", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "year:1990-2014 -label:cake"
+		f.Count = 100
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, p := range photos {
+			assert.GreaterOrEqual(t, p.PhotoYear, 1990)
+			assert.LessOrEqual(t, p.PhotoYear, 2014)
+		}
+	})
+	t.Run("invalid expression", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "(label:cat"
+		f.Count = 10
+
+		_, _, err := PhotoSearch(f)
+
+		assert.Error(t, err)
+	})
+}
@@ -16,6 +16,7 @@ type PhotoResult struct {
 	ID               uint          `json:"-"`
 	UUID             string        `json:"DocumentID,omitempty"`
 	PhotoUID         string        `json:"UID"`
+	OwnerUID         string        `json:"OwnerUID"`
 	PhotoType        string        `json:"Type"`
 	TakenAt          time.Time     `json:"TakenAt"`
 	TakenAtLocal     time.Time     `json:"TakenAtLocal"`
@@ -8,6 +8,7 @@ import (
 	"github.com/jinzhu/gorm"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/rnd"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
 
@@ -15,6 +16,27 @@ import (
 func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error) {
 	start := time.Now()
 
+	// Albums with a filter are resolved dynamically, see below.
+	dynamic := f.Filter != ""
+
+	// Search expressions with boolean operators, ranges or comparisons are compiled to SQL.
+	var exprs []form.QueryExpr
+
+	for _, q := range []*string{&f.Query, &f.Filter} {
+		if !form.IsQueryExpr(*q) {
+			continue
+		}
+
+		expr, err := form.ParseQueryExpr(*q)
+
+		if err != nil {
+			return results, 0, err
+		}
+
+		exprs = append(exprs, expr)
+		*q = ""
+	}
+
 	if err := f.ParseQueryString(); err != nil {
 		return results, 0, err
 	}
@@ -34,13 +56,13 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 		cameras.camera_make, cameras.camera_model,
 		lenses.lens_make, lenses.lens_model,
 		places.loc_label, places.loc_city, places.loc_state, places.loc_country`).
-		Joins("JOIN files ON photos.id = files.photo_id AND files.file_missing = 0 AND files.deleted_at IS NULL").
+		Joins("JOIN files ON photos.id = files.photo_id AND files.file_missing = FALSE AND files.deleted_at IS NULL").
 		Joins("JOIN cameras ON photos.camera_id = cameras.id").
 		Joins("JOIN lenses ON photos.lens_id = lenses.id").
 		Joins("JOIN places ON photos.place_id = places.id")
 
 	if !f.Hidden {
-		s = s.Where("files.file_type = 'jpg' OR files.file_video = 1")
+		s = s.Where("files.file_type = 'jpg' OR files.file_video = TRUE")
 
 		if f.Error {
 			s = s.Where("files.file_error <> ''")
@@ -51,7 +73,16 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 
 	// Return primary files only.
 	if f.Primary {
-		s = s.Where("files.file_primary = 1")
+		s = s.Where("files.file_primary = TRUE")
+	}
+
+	// Restrict results to photos owned by or shared with a user.
+	if f.Owner != "" {
+		if f.Shared != "" {
+			s = s.Where("photos.owner_uid = ? OR photos.photo_uid IN (SELECT pa.photo_uid FROM photos_albums pa WHERE pa.hidden = FALSE AND pa.album_uid IN (?))", f.Owner, strings.Split(f.Shared, ","))
+		} else {
+			s = s.Where("photos.owner_uid = ?", f.Owner)
+		}
 	}
 
 	// Shortcut for known photo ids.
@@ -72,6 +103,16 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 		return results, len(results), nil
 	}
 
+	for _, expr := range exprs {
+		where, values, err := PhotoExpr(expr)
+
+		if err != nil {
+			return results, 0, err
+		}
+
+		s = s.Where(where, values...)
+	}
+
 	// Filter by label, label category and keywords.
 	var categories []entity.Category
 	var labels []entity.Label
@@ -94,11 +135,15 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 				}
 			}
 
+			// Joined tables must be grouped by primary key for PostgreSQL.
 			s = s.Joins("JOIN photos_labels ON photos_labels.photo_id = photos.id AND photos_labels.uncertainty < 100 AND photos_labels.label_id IN (?)", labelIds).
-				Group("photos.id, files.id")
+				Group("photos.id, files.id, cameras.id, lenses.id, places.id")
 		}
 	}
 
+	// Results found in the full-text index can be sorted by relevance.
+	fullText := false
+
 	// Filter by location.
 	if f.Location == true {
 		s = s.Where("location_id <> ''")
@@ -106,6 +151,14 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 		if likeAny := LikeAny("k.keyword", f.Query); likeAny != "" {
 			s = s.Where("photos.id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?))", gorm.Expr(likeAny))
 		}
+	} else if match := FullTextMatch(f.Query); match != "" {
+		join, values := FullTextJoin(match)
+		s = s.Joins(join, values...)
+		fullText = true
+
+		if f.Label != "" {
+			s = s.Group("photos.id, files.id, cameras.id, lenses.id, places.id, ft.score")
+		}
 	} else if f.Query != "" {
 		if len(f.Query) < 2 {
 			return results, 0, fmt.Errorf("query too short")
@@ -149,9 +202,9 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 		s = s.Where("photos.deleted_at IS NULL")
 
 		if f.Private {
-			s = s.Where("photos.photo_private = 1")
+			s = s.Where("photos.photo_private = TRUE")
 		} else if f.Public {
-			s = s.Where("photos.photo_private = 0")
+			s = s.Where("photos.photo_private = FALSE")
 		}
 
 		if f.Review {
@@ -170,6 +223,21 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 		s = s.Where("photos.lens_id = ?", f.Lens)
 	}
 
+	if f.Person != "" {
+		var uids, names []string
+
+		for _, v := range strings.Split(f.Person, ",") {
+			if v = strings.TrimSpace(v); rnd.IsPPID(v, 'u') {
+				uids = append(uids, v)
+			} else if v != "" {
+				names = append(names, strings.ToLower(v))
+			}
+		}
+
+		s = s.Where("photos.id IN (SELECT faces.photo_id FROM faces JOIN people ON people.person_uid = faces.person_uid "+
+			"WHERE people.deleted_at IS NULL AND (people.person_uid IN (?) OR LOWER(people.display_name) IN (?)))", uids, names)
+	}
+
 	if (f.Year > 0 && f.Year <= txt.YearMax) || f.Year == entity.YearUnknown {
 		s = s.Where("photos.photo_year = ?", f.Year)
 	}
@@ -187,11 +255,11 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 	}
 
 	if f.Favorite {
-		s = s.Where("photos.photo_favorite = 1")
+		s = s.Where("photos.photo_favorite = TRUE")
 	}
 
 	if f.Scan {
-		s = s.Where("photos.photo_scan = 1")
+		s = s.Where("photos.photo_scan = TRUE")
 	}
 
 	if f.Country != "" {
@@ -251,11 +319,11 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 	}
 
 	if f.Duplicate {
-		s = s.Where("files.file_duplicate = 1")
+		s = s.Where("files.file_duplicate = TRUE")
 	}
 
 	if f.Portrait {
-		s = s.Where("files.file_portrait = 1")
+		s = s.Where("files.file_portrait = TRUE")
 	}
 
 	if f.Mono {
@@ -310,20 +378,22 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 	}
 
 	if f.Album != "" {
-		if f.Filter != "" {
-			s = s.Where("photos.photo_uid NOT IN (SELECT photo_uid FROM photos_albums pa WHERE pa.hidden = 1 AND pa.album_uid = ?)", f.Album)
+		if dynamic {
+			s = s.Where("photos.photo_uid NOT IN (SELECT photo_uid FROM photos_albums pa WHERE pa.hidden = TRUE AND pa.album_uid = ?)", f.Album)
 		} else {
-			s = s.Joins("JOIN photos_albums ON photos_albums.photo_uid = photos.photo_uid").Where("photos_albums.hidden = 0 AND photos_albums.album_uid = ?", f.Album)
+			s = s.Joins("JOIN photos_albums ON photos_albums.photo_uid = photos.photo_uid").Where("photos_albums.hidden = FALSE AND photos_albums.album_uid = ?", f.Album)
 		}
-	} else if f.Unsorted && f.Filter == "" {
-		s = s.Where("photos.photo_uid NOT IN (SELECT photo_uid FROM photos_albums pa WHERE pa.hidden = 0)")
+	} else if f.Unsorted && !dynamic {
+		s = s.Where("photos.photo_uid NOT IN (SELECT photo_uid FROM photos_albums pa WHERE pa.hidden = FALSE)")
 	}
 
 	// Set sort order for results.
 	switch f.Order {
 	case entity.SortOrderRelevance:
-		if f.Label != "" {
-			s = s.Order("photo_quality DESC, photos_labels.uncertainty ASC, taken_at DESC, files.file_primary DESC")
+		if fullText {
+			s = s.Order("ft.score DESC, photo_quality DESC, taken_at DESC, files.file_primary DESC")
+		} else if f.Label != "" {
+			s = s.Order("photo_quality DESC, MIN(photos_labels.uncertainty) ASC, taken_at DESC, files.file_primary DESC")
 		} else {
 			s = s.Order("photo_quality DESC, taken_at DESC, files.file_primary DESC")
 		}
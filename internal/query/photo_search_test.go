@@ -570,4 +570,18 @@ func TestPhotoSearch(t *testing.T) {
 
 		assert.LessOrEqual(t, 1, len(photos))
 	})
+
+	t.Run("search for person", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Person = "Unknown Person,u000000000000009"
+		f.Count = 10
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, len(photos))
+	})
 }
@@ -3,6 +3,7 @@ package query
 import (
 	"testing"
 
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -68,3 +69,52 @@ func TestResetPhotosQuality(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestPhotoInAlbums(t *testing.T) {
+	t.Run("shared", func(t *testing.T) {
+		assert.True(t, PhotoInAlbums("pt9jtdre2lvl0yh7", []string{"at9lxuqxpogaaba8"}))
+	})
+
+	t.Run("not shared", func(t *testing.T) {
+		assert.False(t, PhotoInAlbums("pt9jtdre2lvl0yh7", []string{"at9lxuqxpogaaba7"}))
+	})
+
+	t.Run("no albums", func(t *testing.T) {
+		assert.False(t, PhotoInAlbums("pt9jtdre2lvl0yh7", []string{}))
+	})
+}
+
+func TestOwnedPhotoUIDs(t *testing.T) {
+	uid := "pt9jtdre2lvl0yh7"
+
+	if err := UnscopedDb().Model(&entity.Photo{}).Where("photo_uid = ?", uid).UpdateColumn("owner_uid", "uqxc08w3d0ej2283").Error; err != nil {
+		t.Fatal(err)
+	}
+
+	defer UnscopedDb().Model(&entity.Photo{}).Where("photo_uid = ?", uid).UpdateColumn("owner_uid", "")
+
+	t.Run("owner", func(t *testing.T) {
+		result, err := OwnedPhotoUIDs([]string{uid, "pt9jtdre2lvl0y11"}, "uqxc08w3d0ej2283")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{uid}, result)
+	})
+	t.Run("other user", func(t *testing.T) {
+		result, err := OwnedPhotoUIDs([]string{uid}, "uqxc08w3d0ej2284")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, result)
+	})
+	t.Run("no owner", func(t *testing.T) {
+		result, err := OwnedPhotoUIDs([]string{uid}, "")
+
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+}
@@ -47,8 +47,9 @@ import (
 var log = event.Log
 
 const (
-	MySQL  = "mysql"
-	SQLite = "sqlite3"
+	MySQL    = "mysql"
+	SQLite   = "sqlite3"
+	Postgres = "postgres"
 )
 
 // Max result limit for queries.
@@ -3,6 +3,7 @@ package query
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
@@ -14,12 +15,21 @@ func PhotoSelection(f form.Selection) (results entity.Photos, err error) {
 		return results, errors.New("no items selected")
 	}
 
+	// Dynamic albums like moments and smart albums are resolved based on their filter.
+	albumPhotos, err := AlbumPhotoUIDs(f.Albums, f.Owner, f.Shared)
+
+	if err != nil {
+		return results, err
+	}
+
+	f.Photos = append(f.Photos, albumPhotos...)
+
 	var concat string
 
 	switch DbDialect() {
 	case MySQL:
 		concat = "CONCAT(a.path, '/%')"
-	case SQLite:
+	case SQLite, Postgres:
 		concat = "a.path || '/%'"
 	default:
 		return results, fmt.Errorf("unknown sql dialect: %s", DbDialect())
@@ -31,7 +41,7 @@ func PhotoSelection(f form.Selection) (results entity.Photos, err error) {
 		OR photos.photo_path IN (
 			SELECT a.path FROM folders a WHERE a.folder_uid IN (?) UNION
 			SELECT b.path FROM folders a JOIN folders b ON b.path LIKE %s WHERE a.folder_uid IN (?))
-		OR photos.photo_uid IN (SELECT photo_uid FROM photos_albums WHERE hidden = 0 AND album_uid IN (?))
+		OR photos.photo_uid IN (SELECT photo_uid FROM photos_albums WHERE hidden = FALSE AND album_uid IN (?))
 		OR photos.id IN (SELECT pl.photo_id FROM photos_labels pl JOIN labels l ON pl.label_id = l.id AND l.deleted_at IS NULL WHERE l.label_uid IN (?))
 		OR photos.id IN (SELECT pl.photo_id FROM photos_labels pl JOIN categories c ON c.label_id = pl.label_id JOIN labels lc ON lc.id = c.category_id AND lc.deleted_at IS NULL WHERE lc.label_uid IN (?))`,
 		concat)
@@ -40,6 +50,11 @@ func PhotoSelection(f form.Selection) (results entity.Photos, err error) {
 		Select("photos.*").
 		Where(where, f.Photos, f.Places, f.Files, f.Files, f.Files, f.Albums, f.Labels, f.Labels)
 
+	// Restrict results to photos owned by or shared with a user.
+	if f.Owner != "" {
+		s = s.Where("photos.owner_uid = ? OR photos.photo_uid IN (SELECT pa.photo_uid FROM photos_albums pa WHERE pa.hidden = FALSE AND pa.album_uid IN (?)) OR photos.photo_uid IN (?)", f.Owner, strings.Split(f.Shared, ","), albumPhotos)
+	}
+
 	if result := s.Scan(&results); result.Error != nil {
 		return results, result.Error
 	}
@@ -53,12 +68,21 @@ func FileSelection(f form.Selection) (results entity.Files, err error) {
 		return results, errors.New("no items selected")
 	}
 
+	// Dynamic albums like moments and smart albums are resolved based on their filter.
+	albumPhotos, err := AlbumPhotoUIDs(f.Albums, f.Owner, f.Shared)
+
+	if err != nil {
+		return results, err
+	}
+
+	f.Photos = append(f.Photos, albumPhotos...)
+
 	var concat string
 
 	switch DbDialect() {
 	case MySQL:
 		concat = "CONCAT(a.path, '/%')"
-	case SQLite:
+	case SQLite, Postgres:
 		concat = "a.path || '/%'"
 	default:
 		return results, fmt.Errorf("unknown sql dialect: %s", DbDialect())
@@ -70,7 +94,7 @@ func FileSelection(f form.Selection) (results entity.Files, err error) {
 		OR photos.photo_path IN (
 			SELECT a.path FROM folders a WHERE a.folder_uid IN (?) UNION
 			SELECT b.path FROM folders a JOIN folders b ON b.path LIKE %s WHERE a.folder_uid IN (?))
-		OR photos.photo_uid IN (SELECT photo_uid FROM photos_albums WHERE hidden = 0 AND album_uid IN (?))
+		OR photos.photo_uid IN (SELECT photo_uid FROM photos_albums WHERE hidden = FALSE AND album_uid IN (?))
 		OR photos.id IN (SELECT pl.photo_id FROM photos_labels pl JOIN labels l ON pl.label_id = l.id AND l.deleted_at IS NULL WHERE l.label_uid IN (?))
 		OR photos.id IN (SELECT pl.photo_id FROM photos_labels pl JOIN categories c ON c.label_id = pl.label_id JOIN labels lc ON lc.id = c.category_id AND lc.deleted_at IS NULL WHERE lc.label_uid IN (?))`,
 		concat)
@@ -79,10 +103,15 @@ func FileSelection(f form.Selection) (results entity.Files, err error) {
 		Select("files.*").
 		Joins("JOIN photos ON photos.id = files.photo_id").
 		Where("photos.deleted_at IS NULL").
-		Where("files.file_missing = 0").
+		Where("files.file_missing = FALSE").
 		Where(where, f.Photos, f.Places, f.Files, f.Files, f.Files, f.Albums, f.Labels, f.Labels).
 		Group("files.id")
 
+	// Restrict results to files of photos owned by or shared with a user.
+	if f.Owner != "" {
+		s = s.Where("photos.owner_uid = ? OR photos.photo_uid IN (SELECT pa.photo_uid FROM photos_albums pa WHERE pa.hidden = FALSE AND pa.album_uid IN (?)) OR photos.photo_uid IN (?)", f.Owner, strings.Split(f.Shared, ","), albumPhotos)
+	}
+
 	if result := s.Scan(&results); result.Error != nil {
 		return results, result.Error
 	}
@@ -33,6 +33,20 @@ func TestPhotoSelection(t *testing.T) {
 		assert.Equal(t, 2, len(r))
 		assert.IsType(t, entity.Photos{}, r)
 	})
+	t.Run("restricted", func(t *testing.T) {
+		f := form.Selection{
+			Photos: []string{"pt9jtdre2lvl0yh7", "pt9jtdre2lvl0yh8"},
+			Owner:  "u000000000000099",
+		}
+
+		r, err := PhotoSelection(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, r)
+	})
 }
 
 func TestFileSelection(t *testing.T) {
@@ -60,4 +74,18 @@ func TestFileSelection(t *testing.T) {
 		assert.Equal(t, 3, len(r))
 		assert.IsType(t, entity.Files{}, r)
 	})
+	t.Run("restricted", func(t *testing.T) {
+		f := form.Selection{
+			Photos: []string{"pt9jtdre2lvl0yh7", "pt9jtdre2lvl0yh8"},
+			Owner:  "u000000000000099",
+		}
+
+		r, err := FileSelection(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, r)
+	})
 }
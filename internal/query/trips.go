@@ -0,0 +1,295 @@
+package query
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gosimple/slug"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/maps"
+	"github.com/photoprism/photoprism/pkg/s2"
+)
+
+// TripCategory is the album category of trips.
+const TripCategory = "Trips"
+
+var (
+	// TripHomeLevel is the S2 cell level used to find the home location, about 10 km wide.
+	TripHomeLevel = 10
+
+	// TripHomeRadius is the distance from home in kilometers below which photos don't belong to a trip.
+	TripHomeRadius = 50.0
+
+	// TripMaxGap is the maximum time between two photos of the same trip.
+	TripMaxGap = 48 * time.Hour
+)
+
+// TripPhoto contains the time and location of a photo.
+type TripPhoto struct {
+	ID           uint
+	PhotoUID     string
+	TakenAt      time.Time
+	TakenAtLocal time.Time
+	PhotoLat     float32
+	PhotoLng     float32
+	PhotoCountry string
+	LocState     string
+	LocCity      string
+}
+
+// TripPhotos represents a list of photos sorted by time.
+type TripPhotos []TripPhoto
+
+// TripOwners returns the UIDs of all users with public photos that have coordinates.
+func TripOwners() (results []string, err error) {
+	err = UnscopedDb().Table("photos").
+		Where("photos.photo_quality >= 3 AND photos.deleted_at IS NULL AND photos.photo_private = FALSE AND (photos.photo_lat <> 0 OR photos.photo_lng <> 0)").
+		Order("photos.owner_uid").
+		Pluck("DISTINCT photos.owner_uid", &results).Error
+
+	return results, err
+}
+
+// TripLocations returns the public photos with coordinates of an owner sorted by the time they were taken.
+func TripLocations(owner string) (results TripPhotos, err error) {
+	db := UnscopedDb().Table("photos").
+		Select("photos.id, photos.photo_uid, photos.taken_at, photos.taken_at_local, photos.photo_lat, photos.photo_lng, photos.photo_country, p.loc_state, p.loc_city").
+		Joins("LEFT JOIN places p ON p.id = photos.place_id").
+		Where("photos.photo_quality >= 3 AND photos.deleted_at IS NULL AND photos.photo_private = FALSE AND (photos.photo_lat <> 0 OR photos.photo_lng <> 0)").
+		Where("photos.owner_uid = ?", owner).
+		Order("photos.taken_at, photos.id")
+
+	if err := db.Scan(&results).Error; err != nil {
+		return results, err
+	}
+
+	return results, nil
+}
+
+// TripAlbums returns all trip albums of an owner including deleted ones.
+func TripAlbums(owner string) (results entity.Albums, err error) {
+	err = UnscopedDb().
+		Where("album_type = ? AND album_category = ? AND owner_uid = ?", entity.AlbumMoment, TripCategory, owner).
+		Order("id").Find(&results).Error
+
+	return results, err
+}
+
+// TripAlbumPhotos maps the photo UIDs in trip albums of an owner to their album UIDs, including
+// photos that were removed from an album.
+func TripAlbumPhotos(owner string) (results map[string]string, err error) {
+	var entries []entity.PhotoAlbum
+
+	err = UnscopedDb().Table("photos_albums").
+		Select("photos_albums.photo_uid, photos_albums.album_uid").
+		Joins("JOIN albums a ON a.album_uid = photos_albums.album_uid").
+		Where("a.album_type = ? AND a.album_category = ? AND a.owner_uid = ?", entity.AlbumMoment, TripCategory, owner).
+		Scan(&entries).Error
+
+	results = make(map[string]string, len(entries))
+
+	for _, entry := range entries {
+		results[entry.PhotoUID] = entry.AlbumUID
+	}
+
+	return results, err
+}
+
+// Home returns the center of the area where most photos were taken.
+func (photos TripPhotos) Home() (lat, lng float64) {
+	counts := make(map[string]int)
+	home, max := "", 0
+
+	for _, p := range photos {
+		token := s2.TokenLevel(float64(p.PhotoLat), float64(p.PhotoLng), TripHomeLevel)
+
+		if token == "" {
+			continue
+		}
+
+		counts[token]++
+
+		if counts[token] > max {
+			home, max = token, counts[token]
+		}
+	}
+
+	return s2.LatLng(home)
+}
+
+// Trips returns groups of at least minPhotos photos taken away from home. A trip ends when the
+// time between two photos exceeds TripMaxGap, or when a photo was taken at home.
+func (photos TripPhotos) Trips(minPhotos int) (results Trips) {
+	if len(photos) == 0 {
+		return results
+	}
+
+	homeLat, homeLng := photos.Home()
+
+	var current TripPhotos
+
+	done := func() {
+		if len(current) > 0 && len(current) >= minPhotos {
+			results = append(results, NewTrip(current))
+		}
+
+		current = nil
+	}
+
+	for _, p := range photos {
+		if s2.Distance(homeLat, homeLng, float64(p.PhotoLat), float64(p.PhotoLng)) < TripHomeRadius {
+			done()
+			continue
+		}
+
+		if n := len(current); n > 0 && p.TakenAt.Sub(current[n-1].TakenAt) > TripMaxGap {
+			done()
+		}
+
+		current = append(current, p)
+	}
+
+	done()
+
+	return results
+}
+
+// Trip represents photos taken on a trip away from home.
+type Trip struct {
+	Start      time.Time
+	End        time.Time
+	StartLocal time.Time
+	EndLocal   time.Time
+	Country    string
+	State      string
+	City       string
+	PhotoCount int
+	PhotoUIDs  []string
+}
+
+// Trips represents a list of trips.
+type Trips []Trip
+
+// NewTrip creates a trip from a list of photos sorted by time.
+func NewTrip(photos TripPhotos) Trip {
+	n := len(photos)
+
+	if n == 0 {
+		return Trip{}
+	}
+
+	result := Trip{
+		Start:      photos[0].TakenAt,
+		End:        photos[n-1].TakenAt,
+		StartLocal: photos[0].TakenAtLocal,
+		EndLocal:   photos[n-1].TakenAtLocal,
+		PhotoCount: n,
+		PhotoUIDs:  make([]string, 0, n),
+	}
+
+	countries := make(map[string]int)
+	states := make(map[string]int)
+	cities := make(map[string]int)
+
+	for _, p := range photos {
+		result.PhotoUIDs = append(result.PhotoUIDs, p.PhotoUID)
+
+		if p.PhotoCountry == "" || p.PhotoCountry == entity.UnknownCountry.ID {
+			continue
+		}
+
+		countries[p.PhotoCountry]++
+
+		if p.LocState != "" {
+			states[p.LocState]++
+		}
+
+		if p.LocCity != "" {
+			cities[p.LocCity]++
+		}
+	}
+
+	result.Country, _ = tripMost(countries)
+
+	// Only use state and city names if they contain at least half of the photos.
+	if name, count := tripMost(states); count*2 >= n {
+		result.State = name
+	}
+
+	if name, count := tripMost(cities); count*2 >= n {
+		result.City = name
+	}
+
+	return result
+}
+
+// tripMost returns the most frequent name and its count, preferring the name that comes first on a tie.
+func tripMost(counts map[string]int) (name string, max int) {
+	for k, v := range counts {
+		if v > max || v == max && k < name {
+			name, max = k, v
+		}
+	}
+
+	return name, max
+}
+
+// Location returns the name of the city, state or country the trip went to.
+func (t Trip) Location() string {
+	switch {
+	case t.City != "":
+		return t.City
+	case t.State != "":
+		return t.State
+	case t.Country != "":
+		return maps.CountryName(t.Country)
+	default:
+		return ""
+	}
+}
+
+// Days returns the number of calendar days of the trip.
+func (t Trip) Days() int {
+	start := time.Date(t.StartLocal.Year(), t.StartLocal.Month(), t.StartLocal.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(t.EndLocal.Year(), t.EndLocal.Month(), t.EndLocal.Day(), 0, 0, 0, 0, time.UTC)
+
+	return int(end.Sub(start).Hours()/24) + 1
+}
+
+// Weekend returns true if the trip took place on a weekend.
+func (t Trip) Weekend() bool {
+	if days := t.Days(); days < 2 || days > 3 {
+		return false
+	}
+
+	start, end := t.StartLocal.Weekday(), t.EndLocal.Weekday()
+
+	return (start == time.Friday || start == time.Saturday) &&
+		(end == time.Saturday || end == time.Sunday || end == time.Monday)
+}
+
+// Title returns an english title for the trip, for example "Weekend in Lisbon, May 2019".
+func (t Trip) Title() string {
+	where := t.Location()
+	when := t.StartLocal.Format("January 2006")
+
+	if where == "" {
+		return fmt.Sprintf("Trip, %s", when)
+	}
+
+	switch days := t.Days(); {
+	case days <= 1:
+		return fmt.Sprintf("Day Trip to %s, %s", where, when)
+	case t.Weekend():
+		return fmt.Sprintf("Weekend in %s, %s", where, when)
+	case days >= 6 && days <= 9:
+		return fmt.Sprintf("Week in %s, %s", where, when)
+	default:
+		return fmt.Sprintf("Trip to %s, %s", where, when)
+	}
+}
+
+// Slug returns an identifier string for the trip.
+func (t Trip) Slug() string {
+	return slug.Make(t.Title())
+}
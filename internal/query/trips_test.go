@@ -0,0 +1,158 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// tripPhotos returns photos taken in Berlin with a weekend in Lisbon and a day trip to Leipzig.
+func tripPhotos() TripPhotos {
+	day := func(d, h int) time.Time {
+		return time.Date(2019, 5, d, h, 0, 0, 0, time.UTC)
+	}
+
+	berlin := func(d, h int) TripPhoto {
+		return TripPhoto{TakenAt: day(d, h), TakenAtLocal: day(d, h), PhotoLat: 52.520008, PhotoLng: 13.404954, PhotoCountry: "de", LocState: "Berlin", LocCity: "Berlin"}
+	}
+
+	lisbon := func(d, h int) TripPhoto {
+		return TripPhoto{TakenAt: day(d, h), TakenAtLocal: day(d, h), PhotoLat: 38.722252, PhotoLng: -9.139337, PhotoCountry: "pt", LocState: "Lisbon", LocCity: "Lisbon"}
+	}
+
+	sintra := func(d, h int) TripPhoto {
+		return TripPhoto{TakenAt: day(d, h), TakenAtLocal: day(d, h), PhotoLat: 38.802, PhotoLng: -9.381, PhotoCountry: "pt", LocState: "Lisbon", LocCity: "Sintra"}
+	}
+
+	return TripPhotos{
+		berlin(1, 10), berlin(2, 10), berlin(3, 10), berlin(8, 10),
+		lisbon(10, 12), lisbon(10, 18), sintra(11, 11), lisbon(11, 20), lisbon(12, 9),
+		berlin(13, 8), berlin(14, 9), berlin(15, 20),
+		{TakenAt: day(18, 10), TakenAtLocal: day(18, 10), PhotoLat: 51.3397, PhotoLng: 12.3731, PhotoCountry: "de"},
+		berlin(20, 10), berlin(21, 10),
+	}
+}
+
+func TestTripOwners(t *testing.T) {
+	if _, err := TripOwners(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTripLocations(t *testing.T) {
+	results, err := TripLocations("")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 1; i < len(results); i++ {
+		assert.False(t, results[i].TakenAt.Before(results[i-1].TakenAt))
+	}
+}
+
+func TestTripAlbums(t *testing.T) {
+	if _, err := TripAlbums(""); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTripAlbumPhotos(t *testing.T) {
+	results, err := TripAlbumPhotos("")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotNil(t, results)
+}
+
+func TestTripPhotos_Home(t *testing.T) {
+	lat, lng := tripPhotos().Home()
+
+	assert.InDelta(t, 52.52, lat, 0.2)
+	assert.InDelta(t, 13.4, lng, 0.2)
+}
+
+func TestTripPhotos_Trips(t *testing.T) {
+	t.Run("lisbon", func(t *testing.T) {
+		results := tripPhotos().Trips(3)
+
+		if len(results) != 1 {
+			t.Fatalf("one trip expected, got %+v", results)
+		}
+
+		trip := results[0]
+
+		assert.Equal(t, 5, trip.PhotoCount)
+		assert.Equal(t, "pt", trip.Country)
+		assert.Equal(t, "Lisbon", trip.State)
+		assert.Equal(t, "Lisbon", trip.City)
+		assert.Equal(t, 3, trip.Days())
+		assert.True(t, trip.Weekend())
+		assert.Equal(t, "Weekend in Lisbon, May 2019", trip.Title())
+		assert.Equal(t, "weekend-in-lisbon-may-2019", trip.Slug())
+		assert.Len(t, trip.PhotoUIDs, 5)
+	})
+	t.Run("day trip", func(t *testing.T) {
+		results := tripPhotos().Trips(1)
+
+		if len(results) != 2 {
+			t.Fatalf("two trips expected, got %+v", results)
+		}
+
+		assert.Equal(t, "Day Trip to Germany, May 2019", results[1].Title())
+	})
+	t.Run("gap", func(t *testing.T) {
+		photos := tripPhotos()[:9]
+
+		// Add a gap of more than two days before the last two photos in Lisbon.
+		photos[7].TakenAt = photos[7].TakenAt.AddDate(0, 0, 3)
+		photos[8].TakenAt = photos[8].TakenAt.AddDate(0, 0, 3)
+
+		results := photos.Trips(1)
+
+		if len(results) != 2 {
+			t.Fatalf("two trips expected, got %+v", results)
+		}
+
+		assert.Equal(t, 3, results[0].PhotoCount)
+		assert.Equal(t, 2, results[1].PhotoCount)
+	})
+	t.Run("empty", func(t *testing.T) {
+		results := TripPhotos{}.Trips(1)
+
+		assert.Empty(t, results)
+	})
+}
+
+func TestTrip_Title(t *testing.T) {
+	t.Run("week", func(t *testing.T) {
+		trip := Trip{
+			StartLocal: time.Date(2019, 8, 3, 10, 0, 0, 0, time.UTC),
+			EndLocal:   time.Date(2019, 8, 10, 18, 0, 0, 0, time.UTC),
+			Country:    "it",
+			State:      "Sicily",
+		}
+
+		assert.Equal(t, "Week in Sicily, August 2019", trip.Title())
+	})
+	t.Run("trip", func(t *testing.T) {
+		trip := Trip{
+			StartLocal: time.Date(2019, 8, 5, 10, 0, 0, 0, time.UTC),
+			EndLocal:   time.Date(2019, 8, 8, 18, 0, 0, 0, time.UTC),
+			Country:    "fr",
+		}
+
+		assert.Equal(t, "Trip to France, August 2019", trip.Title())
+	})
+	t.Run("unknown", func(t *testing.T) {
+		trip := Trip{
+			StartLocal: time.Date(2019, 8, 5, 10, 0, 0, 0, time.UTC),
+			EndLocal:   time.Date(2019, 8, 8, 18, 0, 0, 0, time.UTC),
+		}
+
+		assert.Equal(t, "Trip, August 2019", trip.Title())
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/photoprism/photoprism/internal/remote/s3"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
 
@@ -46,6 +47,11 @@ func Discover(rawUrl, user, pass string) (result Account, err error) {
 		u.User = url.UserPassword(result.AccUser, result.AccPass)
 	}
 
+	// S3-compatible object storage, e.g. "s3://s3.eu-central-1.wasabisys.com/bucket"
+	if u.Scheme == "s3" || u.Scheme == "s3+http" {
+		return discoverS3(u, result)
+	}
+
 	// Set default scheme
 	if u.Scheme == "" {
 		u.Scheme = "https"
@@ -74,3 +80,28 @@ func Discover(rawUrl, user, pass string) (result Account, err error) {
 
 	return result, errors.New("could not connect")
 }
+
+// discoverS3 checks if the bucket in an "s3://" or "s3+http://" URL can be accessed with the credentials.
+func discoverS3(u *url.URL, result Account) (Account, error) {
+	serviceUrl := &url.URL{Scheme: "https", Host: u.Host, Path: u.Path, RawQuery: u.RawQuery}
+
+	if u.Scheme == "s3+http" {
+		serviceUrl.Scheme = "http"
+	}
+
+	c, err := s3.New(serviceUrl.String(), result.AccUser, result.AccPass)
+
+	if err != nil {
+		return result, err
+	}
+
+	if _, err := c.Files("/"); err != nil {
+		return result, err
+	}
+
+	result.AccName = c.Bucket()
+	result.AccType = ServiceS3
+	result.AccURL = serviceUrl.String()
+
+	return result, nil
+}
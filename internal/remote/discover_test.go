@@ -1,8 +1,10 @@
 package remote
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/photoprism/photoprism/internal/remote/s3/s3test"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -69,4 +71,33 @@ func TestDiscover(t *testing.T) {
 		assert.Equal(t, "", r.AccUser)
 		assert.Equal(t, "", r.AccPass)
 	})
+	t.Run("s3", func(t *testing.T) {
+		srv := s3test.NewServer("photos")
+		defer srv.Close()
+
+		endpoint := strings.Replace(srv.Endpoint(), "http://", "s3+http://", 1)
+
+		r, err := Discover(endpoint, srv.AccessKey, srv.SecretKey)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "photos", r.AccName)
+		assert.Equal(t, "s3", r.AccType)
+		assert.Equal(t, srv.Endpoint(), r.AccURL)
+		assert.Equal(t, srv.AccessKey, r.AccUser)
+		assert.Equal(t, srv.SecretKey, r.AccPass)
+	})
+
+	t.Run("s3 access denied", func(t *testing.T) {
+		srv := s3test.NewServer("photos")
+		defer srv.Close()
+
+		endpoint := strings.Replace(srv.Endpoint(), "http://", "s3+http://", 1)
+
+		_, err := Discover(endpoint, "invalid", srv.SecretKey)
+
+		assert.Error(t, err)
+	})
 }
@@ -37,12 +37,15 @@ package remote
 import (
 	"net/http"
 	"time"
+
+	"github.com/photoprism/photoprism/pkg/fs"
 )
 
 var client = &http.Client{Timeout: 30 * time.Second} // TODO: Change timeout if needed
 
 const (
 	ServiceWebDAV    = "webdav"
+	ServiceS3        = "s3"
 	ServiceFacebook  = "facebook"
 	ServiceTwitter   = "twitter"
 	ServiceFlickr    = "flickr"
@@ -55,6 +58,26 @@ const (
 	ServiceOneDrive  = "onedrive"
 )
 
+// Client represents a remote storage service that supports sharing and syncing files.
+type Client interface {
+	Files(dir string) (fs.FileInfos, error)
+	Directories(root string, recursive bool) (fs.FileInfos, error)
+	Download(from, to string, force bool) error
+	CreateDir(dir string) error
+	Upload(from, to string) error
+	Delete(path string) error
+}
+
+// CanSync returns true if accounts of the service type can be used for sharing and syncing.
+func CanSync(serviceType string) bool {
+	switch serviceType {
+	case ServiceWebDAV, ServiceS3:
+		return true
+	default:
+		return false
+	}
+}
+
 func HttpOk(method, rawUrl string) bool {
 	req, err := http.NewRequest(method, rawUrl, nil)
 
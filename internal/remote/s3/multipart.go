@@ -0,0 +1,122 @@
+package s3
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// initiateResult represents the result of a CreateMultipartUpload request.
+type initiateResult struct {
+	UploadId string `xml:"UploadId"`
+}
+
+// completePart represents an uploaded part in a CompleteMultipartUpload request.
+type completePart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+// completeUpload represents the body of a CompleteMultipartUpload request.
+type completeUpload struct {
+	XMLName xml.Name       `xml:"CompleteMultipartUpload"`
+	Parts   []completePart `xml:"Part"`
+}
+
+// uploadParts uploads a file in parts of PartSize bytes. Incomplete uploads are aborted, so
+// that no storage is used by orphaned parts.
+func (c Client) uploadParts(r io.Reader, key string, header http.Header) (err error) {
+	resp, err := c.request(http.MethodPost, key, url.Values{"uploads": {""}}, header, nil)
+
+	if err != nil {
+		return err
+	}
+
+	var upload initiateResult
+
+	err = xml.NewDecoder(resp.Body).Decode(&upload)
+	resp.Body.Close()
+
+	if err != nil {
+		return err
+	} else if upload.UploadId == "" {
+		return fmt.Errorf("s3: upload id missing")
+	}
+
+	defer func() {
+		if err == nil {
+			return
+		}
+
+		if resp, abortErr := c.request(http.MethodDelete, key, url.Values{"uploadId": {upload.UploadId}}, nil, nil); abortErr != nil {
+			log.Errorf("s3: %s (abort upload)", abortErr)
+		} else {
+			resp.Body.Close()
+		}
+	}()
+
+	var parts []completePart
+
+	buf := make([]byte, PartSize)
+
+	for n := 1; ; n++ {
+		size, readErr := io.ReadFull(r, buf)
+
+		if readErr != nil && readErr != io.EOF && readErr != io.ErrUnexpectedEOF {
+			return readErr
+		}
+
+		if size == 0 && n > 1 {
+			break
+		}
+
+		query := url.Values{"partNumber": {strconv.Itoa(n)}, "uploadId": {upload.UploadId}}
+
+		resp, err := c.request(http.MethodPut, key, query, nil, buf[:size])
+
+		if err != nil {
+			return err
+		}
+
+		resp.Body.Close()
+
+		parts = append(parts, completePart{PartNumber: n, ETag: resp.Header.Get("ETag")})
+
+		if readErr != nil {
+			break
+		}
+	}
+
+	body, err := xml.Marshal(completeUpload{Parts: parts})
+
+	if err != nil {
+		return err
+	}
+
+	resp, err = c.request(http.MethodPost, key, url.Values{"uploadId": {upload.UploadId}}, nil, body)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+
+	if err != nil {
+		return err
+	}
+
+	// The request may still fail after the response status was sent.
+	var e errorResponse
+
+	if xml.Unmarshal(data, &e) == nil && e.Code != "" {
+		return fmt.Errorf("s3: %s (%s)", e.Message, e.Code)
+	}
+
+	return nil
+}
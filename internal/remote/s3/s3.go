@@ -0,0 +1,430 @@
+/*
+
+Package s3 implements sharing and syncing with S3-compatible object storage like MinIO, Backblaze B2 or Wasabi.
+
+Copyright (c) 2018 - 2020 Michael Mayer <hello@photoprism.org>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism™ is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.org if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.org/developer-guide/
+
+*/
+package s3
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+var log = event.Log
+
+// DefaultRegion is used if the region can't be derived from the endpoint.
+const DefaultRegion = "us-east-1"
+
+// PartSize is the size of parts in multipart uploads, larger files are uploaded in multiple parts.
+var PartSize int64 = 16 * 1024 * 1024
+
+// Client represents a client for a bucket in S3-compatible object storage.
+type Client struct {
+	endpoint  string
+	bucket    string
+	region    string
+	accessKey string
+	secretKey string
+	client    *http.Client
+}
+
+// New creates a new S3 client. The bucket name is the first path segment of the URL, e.g.
+// "https://s3.eu-central-1.wasabisys.com/photos". The region is derived from the host name
+// unless it is specified with the "region" query parameter.
+func New(rawUrl, user, pass string) (c Client, err error) {
+	u, err := url.Parse(rawUrl)
+
+	if err != nil {
+		return c, err
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return c, fmt.Errorf("s3: invalid endpoint %s", rawUrl)
+	}
+
+	bucket := strings.Split(strings.Trim(u.Path, "/"), "/")[0]
+
+	if bucket == "" {
+		return c, fmt.Errorf("s3: bucket name missing in %s", rawUrl)
+	}
+
+	region := u.Query().Get("region")
+
+	if region == "" {
+		region = Region(u.Hostname())
+	}
+
+	c = Client{
+		endpoint:  u.Scheme + "://" + u.Host,
+		bucket:    bucket,
+		region:    region,
+		accessKey: user,
+		secretKey: pass,
+		client:    &http.Client{Timeout: 10 * time.Minute}, // TODO: Change timeout if needed
+	}
+
+	return c, nil
+}
+
+// Region returns the region of endpoints like "s3.eu-central-1.wasabisys.com", or the default region.
+func Region(host string) string {
+	labels := strings.Split(host, ".")
+
+	if len(labels) > 3 && labels[0] == "s3" {
+		return labels[1]
+	}
+
+	if len(labels) > 2 && strings.HasPrefix(labels[0], "s3-") {
+		return strings.TrimPrefix(labels[0], "s3-")
+	}
+
+	return DefaultRegion
+}
+
+// Bucket returns the bucket name.
+func (c Client) Bucket() string {
+	return c.bucket
+}
+
+// objectKey returns the object key for a remote file name.
+func objectKey(name string) string {
+	return strings.TrimPrefix(path.Clean("/"+name), "/")
+}
+
+// validKey returns true if the object key is a clean relative path without ".." segments.
+func validKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") || path.Clean(key) != key {
+		return false
+	}
+
+	for _, name := range strings.Split(key, "/") {
+		if name == ".." {
+			return false
+		}
+	}
+
+	return true
+}
+
+// request sends a signed request for an object, or the bucket if the key is empty. An error is
+// returned if the server responds with an error status code.
+func (c Client) request(method, key string, query url.Values, header http.Header, body []byte) (*http.Response, error) {
+	rawUrl := c.endpoint + "/" + uriEncode(c.bucket, true)
+
+	if key != "" {
+		rawUrl += "/" + uriEncode(key, false)
+	}
+
+	if len(query) > 0 {
+		rawUrl += "?" + canonicalQuery(query)
+	}
+
+	req, err := http.NewRequest(method, rawUrl, bytes.NewReader(body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	c.sign(req, payloadHash(body), time.Now().UTC())
+
+	resp, err := c.client.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		return nil, responseError(resp)
+	}
+
+	return resp, nil
+}
+
+// errorResponse represents an S3 error response.
+type errorResponse struct {
+	XMLName xml.Name `xml:"Error"`
+	Code    string   `xml:"Code"`
+	Message string   `xml:"Message"`
+}
+
+// responseError returns the error contained in a response.
+func responseError(resp *http.Response) error {
+	var e errorResponse
+
+	if data, err := ioutil.ReadAll(resp.Body); err == nil && len(data) > 0 && xml.Unmarshal(data, &e) == nil && e.Code != "" {
+		if e.Message == "" {
+			return fmt.Errorf("s3: %s", e.Code)
+		}
+
+		return fmt.Errorf("s3: %s (%s)", e.Message, e.Code)
+	}
+
+	return fmt.Errorf("s3: %s", resp.Status)
+}
+
+// listResult represents the result of a ListObjectsV2 request.
+type listResult struct {
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+	Contents              []struct {
+		Key          string    `xml:"Key"`
+		LastModified time.Time `xml:"LastModified"`
+		ETag         string    `xml:"ETag"`
+		Size         int64     `xml:"Size"`
+	} `xml:"Contents"`
+	CommonPrefixes []struct {
+		Prefix string `xml:"Prefix"`
+	} `xml:"CommonPrefixes"`
+}
+
+// list returns the files in a directory and the names of its sub directories. If recursive is
+// true, files in sub directories are included and no sub directories are returned.
+func (c Client) list(dir string, recursive bool) (files fs.FileInfos, dirs []string, err error) {
+	prefix := objectKey(dir)
+
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+
+	if !recursive {
+		query.Set("delimiter", "/")
+	}
+
+	for {
+		resp, err := c.request(http.MethodGet, "", query, nil, nil)
+
+		if err != nil {
+			return files, dirs, err
+		}
+
+		var result listResult
+
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+
+		if err != nil {
+			return files, dirs, err
+		}
+
+		for _, obj := range result.Contents {
+			// Skip folder placeholders.
+			if strings.HasSuffix(obj.Key, "/") {
+				continue
+			}
+
+			// Skip keys that could be used to write files outside the download folder.
+			if !validKey(obj.Key) {
+				log.Warnf("s3: skipped invalid key %s", txt.Quote(obj.Key))
+				continue
+			}
+
+			files = append(files, fs.FileInfo{
+				Name: path.Base(obj.Key),
+				Abs:  "/" + obj.Key,
+				Size: obj.Size,
+				Date: obj.LastModified,
+				ETag: strings.Trim(obj.ETag, `"`),
+			})
+		}
+
+		for _, p := range result.CommonPrefixes {
+			if name := strings.TrimSuffix(p.Prefix, "/"); validKey(name) {
+				dirs = append(dirs, "/"+name)
+			}
+		}
+
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return files, dirs, nil
+		}
+
+		query.Set("continuation-token", result.NextContinuationToken)
+	}
+}
+
+// Files returns all files in a directory.
+func (c Client) Files(dir string) (result fs.FileInfos, err error) {
+	result, _, err = c.list(dir, false)
+
+	return result, err
+}
+
+// Directories returns all sub directories of a directory. Object storage has no real directories,
+// so they are derived from object keys.
+func (c Client) Directories(root string, recursive bool) (result fs.FileInfos, err error) {
+	var dirs []string
+
+	if recursive {
+		files, _, err := c.list(root, true)
+
+		if err != nil {
+			return result, err
+		}
+
+		root = "/" + objectKey(root)
+		found := make(map[string]bool)
+
+		for _, file := range files {
+			for dir := path.Dir(file.Abs); dir != root && dir != "/" && !found[dir]; dir = path.Dir(dir) {
+				found[dir] = true
+				dirs = append(dirs, dir)
+			}
+		}
+	} else if _, dirs, err = c.list(root, false); err != nil {
+		return result, err
+	}
+
+	sort.Strings(dirs)
+
+	for _, dir := range dirs {
+		result = append(result, fs.FileInfo{Name: path.Base(dir), Abs: dir, Dir: true})
+	}
+
+	return result, nil
+}
+
+// Download downloads a single file to the given location.
+func (c Client) Download(from, to string, force bool) error {
+	if _, err := os.Stat(to); err == nil && !force {
+		return fmt.Errorf("s3: download skipped, %s already exists", to)
+	}
+
+	dir := path.Dir(to)
+	dirInfo, err := os.Stat(dir)
+
+	if err != nil {
+		// Create directory
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			return fmt.Errorf("s3: can't create %s (%s)", dir, err)
+		}
+	} else if !dirInfo.IsDir() {
+		return fmt.Errorf("s3: %s is not a folder", dir)
+	}
+
+	resp, err := c.request(http.MethodGet, objectKey(from), nil, nil, nil)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	f, err := os.OpenFile(to, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		f.Close()
+		os.Remove(to)
+		return err
+	}
+
+	return f.Close()
+}
+
+// CreateDir does nothing, as directories are implicitly created with the objects they contain.
+func (c Client) CreateDir(dir string) error {
+	return nil
+}
+
+// Upload uploads a single file. Files larger than PartSize are uploaded in multiple parts.
+func (c Client) Upload(from, to string) error {
+	file, err := os.Open(from)
+
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	info, err := file.Stat()
+
+	if err != nil {
+		return err
+	}
+
+	key := objectKey(to)
+	header := http.Header{}
+
+	if contentType := mime.TypeByExtension(path.Ext(key)); contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+
+	if info.Size() > PartSize {
+		return c.uploadParts(file, key, header)
+	}
+
+	data, err := ioutil.ReadAll(file)
+
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.request(http.MethodPut, key, nil, header, data)
+
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+// Delete deletes a single file.
+func (c Client) Delete(name string) error {
+	resp, err := c.request(http.MethodDelete, objectKey(name), nil, nil, nil)
+
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
@@ -0,0 +1,313 @@
+package s3
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/remote/s3/s3test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegion(t *testing.T) {
+	assert.Equal(t, "eu-central-1", Region("s3.eu-central-1.wasabisys.com"))
+	assert.Equal(t, "us-west-004", Region("s3.us-west-004.backblazeb2.com"))
+	assert.Equal(t, "eu-west-1", Region("s3-eu-west-1.amazonaws.com"))
+	assert.Equal(t, DefaultRegion, Region("s3.amazonaws.com"))
+	assert.Equal(t, DefaultRegion, Region("localhost"))
+}
+
+func TestNew(t *testing.T) {
+	t.Run("wasabi", func(t *testing.T) {
+		c, err := New("https://s3.eu-central-1.wasabisys.com/photos/", "key", "secret")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "https://s3.eu-central-1.wasabisys.com", c.endpoint)
+		assert.Equal(t, "photos", c.Bucket())
+		assert.Equal(t, "eu-central-1", c.region)
+	})
+	t.Run("region", func(t *testing.T) {
+		c, err := New("http://localhost:9000/photos?region=eu-west-1", "key", "secret")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "http://localhost:9000", c.endpoint)
+		assert.Equal(t, "eu-west-1", c.region)
+	})
+	t.Run("bucket missing", func(t *testing.T) {
+		_, err := New("https://s3.eu-central-1.wasabisys.com/", "key", "secret")
+
+		assert.Error(t, err)
+	})
+	t.Run("invalid scheme", func(t *testing.T) {
+		_, err := New("ftp://example.com/photos", "key", "secret")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestValidKey(t *testing.T) {
+	assert.True(t, validKey("2020/Berlin/a.jpg"))
+	assert.True(t, validKey("a..b.jpg"))
+	assert.False(t, validKey(""))
+	assert.False(t, validKey("/a.jpg"))
+	assert.False(t, validKey("../../a.jpg"))
+	assert.False(t, validKey("2020/../a.jpg"))
+	assert.False(t, validKey("2020/./a.jpg"))
+	assert.False(t, validKey("2020//a.jpg"))
+	assert.False(t, validKey(".."))
+}
+
+func TestClient(t *testing.T) {
+	srv := s3test.NewServer("photos")
+	defer srv.Close()
+
+	c, err := New(srv.Endpoint(), srv.AccessKey, srv.SecretKey)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv.Put("2020/Berlin/a.jpg", []byte("a"))
+	srv.Put("2020/Berlin/b.jpg", []byte("bb"))
+	srv.Put("2020/Lisbon/c.jpg", []byte("ccc"))
+	srv.Put("2021/d.jpg", []byte("dddd"))
+	srv.Put("e.jpg", []byte("eeeee"))
+	srv.Put("2021/", nil)
+
+	t.Run("files", func(t *testing.T) {
+		files, err := c.Files("/2020/Berlin")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(files) != 2 {
+			t.Fatalf("2 files expected: %+v", files)
+		}
+
+		assert.Equal(t, "a.jpg", files[0].Name)
+		assert.Equal(t, "/2020/Berlin/a.jpg", files[0].Abs)
+		assert.Equal(t, int64(1), files[0].Size)
+		assert.Equal(t, "0cc175b9c0f1b6a831c399e269772661", files[0].ETag)
+		assert.False(t, files[0].Date.IsZero())
+	})
+	t.Run("root", func(t *testing.T) {
+		files, err := c.Files("/")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"/e.jpg"}, files.Abs())
+	})
+	t.Run("directories", func(t *testing.T) {
+		dirs, err := c.Directories("/", false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"/2020", "/2021"}, dirs.Abs())
+		assert.True(t, dirs[0].Dir)
+	})
+	t.Run("recursive", func(t *testing.T) {
+		dirs, err := c.Directories("/", true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"/2020", "/2020/Berlin", "/2020/Lisbon", "/2021"}, dirs.Abs())
+
+		dirs, err = c.Directories("/2020", true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"/2020/Berlin", "/2020/Lisbon"}, dirs.Abs())
+	})
+	t.Run("pagination", func(t *testing.T) {
+		srv.MaxKeys = 1
+		defer func() { srv.MaxKeys = 1000 }()
+
+		files, err := c.Files("/2020/Berlin")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, files, 2)
+
+		dirs, err := c.Directories("/", false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"/2020", "/2021"}, dirs.Abs())
+	})
+	t.Run("download", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "s3")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.RemoveAll(dir)
+
+		fileName := filepath.Join(dir, "2020", "Lisbon", "c.jpg")
+
+		if err := c.Download("/2020/Lisbon/c.jpg", fileName, false); err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := ioutil.ReadFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "ccc", string(data))
+		assert.Error(t, c.Download("/2020/Lisbon/c.jpg", fileName, false))
+		assert.NoError(t, c.Download("/2020/Lisbon/c.jpg", fileName, true))
+		assert.Error(t, c.Download("/missing.jpg", filepath.Join(dir, "missing.jpg"), false))
+	})
+	t.Run("upload", func(t *testing.T) {
+		fileName := filepath.Join(os.TempDir(), "s3-upload.jpg")
+
+		if err := ioutil.WriteFile(fileName, []byte("upload"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(fileName)
+
+		assert.NoError(t, c.CreateDir("/uploads"))
+
+		if err := c.Upload(fileName, "/uploads/Foo Bar+ä.jpg"); err != nil {
+			t.Fatal(err)
+		}
+
+		obj, ok := srv.Object("uploads/Foo Bar+ä.jpg")
+
+		assert.True(t, ok)
+		assert.Equal(t, "upload", string(obj.Data))
+
+		if err := c.Delete("/uploads/Foo Bar+ä.jpg"); err != nil {
+			t.Fatal(err)
+		}
+
+		_, ok = srv.Object("uploads/Foo Bar+ä.jpg")
+
+		assert.False(t, ok)
+	})
+	t.Run("multipart", func(t *testing.T) {
+		partSize := PartSize
+		PartSize = 1024
+		defer func() { PartSize = partSize }()
+
+		data := bytes.Repeat([]byte("0123456789"), 250)
+		fileName := filepath.Join(os.TempDir(), "s3-multipart.jpg")
+
+		if err := ioutil.WriteFile(fileName, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(fileName)
+
+		if err := c.Upload(fileName, "/uploads/multipart.jpg"); err != nil {
+			t.Fatal(err)
+		}
+
+		obj, ok := srv.Object("uploads/multipart.jpg")
+
+		assert.True(t, ok)
+		assert.Equal(t, data, obj.Data)
+		assert.True(t, strings.HasSuffix(obj.ETag, `-3"`))
+		assert.Equal(t, 0, srv.Uploads())
+	})
+	t.Run("access denied", func(t *testing.T) {
+		c, err := New(srv.Endpoint(), "invalid", srv.SecretKey)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = c.Files("/")
+
+		if err == nil {
+			t.Fatal("error expected")
+		}
+
+		assert.Contains(t, err.Error(), "AccessDenied")
+	})
+	t.Run("no such bucket", func(t *testing.T) {
+		c, err := New(srv.URL+"/missing", srv.AccessKey, srv.SecretKey)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = c.Files("/")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestClient_InvalidKeys(t *testing.T) {
+	srv := s3test.NewServer("photos")
+	defer srv.Close()
+
+	c, err := New(srv.Endpoint(), srv.AccessKey, srv.SecretKey)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv.Put("2020/a.jpg", []byte("a"))
+	srv.Put("b.jpg", []byte("b"))
+	srv.Put("../../x.jpg", []byte("x"))
+	srv.Put("2020/../../y.jpg", []byte("y"))
+	srv.Put("../z/z.jpg", []byte("z"))
+
+	files, err := c.Files("/")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []string{"/b.jpg"}, files.Abs())
+
+	dirs, err := c.Directories("/", false)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []string{"/2020"}, dirs.Abs())
+
+	dirs, err = c.Directories("/", true)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []string{"/2020"}, dirs.Abs())
+
+	files, err = c.Files("/2020")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []string{"/2020/a.jpg"}, files.Abs())
+}
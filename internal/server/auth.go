@@ -5,16 +5,16 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
-	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	gc "github.com/patrickmn/go-cache"
+	"github.com/photoprism/photoprism/internal/auth"
 	"github.com/photoprism/photoprism/internal/entity"
 )
 
-var basicAuth = struct {
-	user  map[string]entity.Person
-	mutex sync.RWMutex
-}{user: make(map[string]entity.Person)}
+// Successful credentials are cached for a short time to avoid hashing the password on every request.
+var basicAuthCache = gc.New(5*time.Minute, 10*time.Minute)
 
 func GetCredentials(c *gin.Context) (username, password, raw string) {
 	data := c.GetHeader("Authorization")
@@ -49,27 +49,89 @@ func BasicAuth() gin.HandlerFunc {
 
 		username, password, raw := GetCredentials(c)
 
-		basicAuth.mutex.Lock()
-		defer basicAuth.mutex.Unlock()
+		if hit, ok := basicAuthCache.Get(raw); ok {
+			// Make sure the account wasn't deactivated in the meantime.
+			if user := entity.FindPersonByUID(hit.(entity.Person).PersonUID); user != nil && !user.Disabled() {
+				c.Set(gin.AuthUserKey, user.PersonUID)
+				return
+			}
 
-		if user, ok := basicAuth.user[raw]; ok {
-			c.Set(gin.AuthUserKey, user.PersonUID)
+			basicAuthCache.Delete(raw)
+		}
+
+		clientIP := c.ClientIP()
+
+		if wait := auth.Clients.Wait(clientIP); wait > 0 {
+			auth.Throttled("webdav", username, clientIP, wait)
+			c.Header("Retry-After", strconv.Itoa(int(wait.Seconds())+1))
+			c.AbortWithStatus(http.StatusTooManyRequests)
 			return
 		}
 
 		user := entity.FindPersonByUserName(username)
 
+		// Deactivated users are rejected like unknown users.
+		if user != nil && user.Disabled() {
+			user = nil
+		}
+
 		if user != nil {
+			// App tokens may be used instead of the password, they are not cached so that
+			// revoked and expired tokens are rejected immediately.
+			if token := entity.FindAppToken(password); token != nil && token.UserUID == user.PersonUID {
+				// Tokens only grant access if WebDAV is enabled for the user.
+				if !token.AllowWebDAV() || !user.WebDAV {
+					c.AbortWithStatus(http.StatusForbidden)
+					return
+				}
+
+				token.Used()
+				auth.Clients.Reset(clientIP)
+				c.Set(gin.AuthUserKey, user.PersonUID)
+				return
+			}
+
+			if wait := user.LoginWait(); wait > 0 {
+				auth.Throttled("webdav", username, clientIP, wait)
+				c.Header("Retry-After", strconv.Itoa(int(wait.Seconds())+1))
+				c.AbortWithStatus(http.StatusTooManyRequests)
+				return
+			}
+
+			// Passwords alone are not accepted if two-factor authentication is enabled,
+			// WebDAV clients must use an app token instead.
+			if user.TwoFactorEnabled() {
+				auth.Clients.Failed(clientIP)
+				c.Header("WWW-Authenticate", realm)
+				c.AbortWithStatus(http.StatusUnauthorized)
+				return
+			}
+
 			invalid = user.InvalidPassword(password)
 		}
 
 		if user == nil || invalid {
+			// Failures are always counted per client IP as well, so that passwords can't
+			// be tried across many user names without being throttled.
+			if user == nil {
+				auth.Failed("webdav", username, clientIP, auth.Clients.Failed(clientIP))
+			} else {
+				auth.Clients.Failed(clientIP)
+				auth.Failed("webdav", username, clientIP, user.LoginFailed())
+			}
+
 			c.Header("WWW-Authenticate", realm)
 			c.AbortWithStatus(http.StatusUnauthorized)
 			return
 		}
 
-		basicAuth.user[raw] = *user
+		if user.LoginAttempts > 0 {
+			user.LoginSucceeded()
+		}
+
+		auth.Clients.Reset(clientIP)
+
+		basicAuthCache.SetDefault(raw, *user)
 
 		c.Set(gin.AuthUserKey, user.PersonUID)
 	}
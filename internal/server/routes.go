@@ -29,6 +29,7 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.GetConfig(v1)
 
 		api.CreateSession(v1)
+		api.GetOIDC(v1)
 		api.DeleteSession(v1)
 
 		api.GetThumbnail(v1)
@@ -68,16 +69,31 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.DislikeLabel(v1)
 		api.LabelThumbnail(v1)
 
+		api.GetFaces(v1)
+		api.UpdateFaces(v1)
+
 		api.GetFoldersOriginals(v1)
 		api.GetFoldersImport(v1)
 
+		api.GetAlbumComments(v1)
+		api.CreateAlbumComment(v1)
+		api.GetPhotoComments(v1)
+		api.CreatePhotoComment(v1)
+		api.DeleteComment(v1)
+		api.GetActivity(v1)
+
 		api.Upload(v1)
+		api.UploadToAlbum(v1)
 		api.StartImport(v1)
 		api.CancelImport(v1)
 		api.StartIndexing(v1)
 		api.CancelIndexing(v1)
 
 		api.BatchPhotosArchive(v1)
+		api.GetDuplicates(v1)
+		api.KeepDuplicate(v1)
+		api.GetMemories(v1)
+		api.GetHighlights(v1)
 		api.BatchPhotosRestore(v1)
 		api.BatchPhotosPrivate(v1)
 		api.BatchAlbumsDelete(v1)
@@ -108,14 +124,37 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.DeleteAccount(v1)
 		api.UpdateAccount(v1)
 
+		api.GetWebhooks(v1)
+		api.GetWebhook(v1)
+		api.GetWebhookDeliveries(v1)
+		api.CreateWebhook(v1)
+		api.UpdateWebhook(v1)
+		api.DeleteWebhook(v1)
+
 		api.GetSettings(v1)
 		api.SaveSettings(v1)
+		api.GetUsers(v1)
+		api.GetUser(v1)
+		api.CreateUser(v1)
+		api.UpdateUser(v1)
+		api.DeleteUser(v1)
 		api.ChangePassword(v1)
+		api.GetUserSessions(v1)
+		api.DeleteUserSession(v1)
+		api.DeleteUserSessions(v1)
+		api.GetAppTokens(v1)
+		api.CreateAppToken(v1)
+		api.DeleteAppToken(v1)
+		api.GetTwoFactor(v1)
+		api.CreateTwoFactor(v1)
+		api.EnableTwoFactor(v1)
+		api.DeleteTwoFactor(v1)
 		api.GetErrors(v1)
 
 		api.GetSvg(v1)
 
 		api.Websocket(v1)
+		api.GetEvents(v1)
 	}
 
 	// Configure link sharing.
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/face"
+)
+
+var onceFaceNet sync.Once
+
+func initFaceNet() {
+	services.FaceNet = face.New(conf.FaceNetModelPath(), conf.TensorFlowOff())
+}
+
+func FaceNet() *face.Net {
+	onceFaceNet.Do(initFaceNet)
+
+	return services.FaceNet
+}
@@ -9,7 +9,7 @@ import (
 var onceIndex sync.Once
 
 func initIndex() {
-	services.Index = photoprism.NewIndex(Config(), Classify(), NsfwDetector(), Convert())
+	services.Index = photoprism.NewIndex(Config(), Classify(), NsfwDetector(), FaceNet(), Convert())
 }
 
 func Index() *photoprism.Index {
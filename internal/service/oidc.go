@@ -0,0 +1,24 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/oidc"
+)
+
+var onceOIDC sync.Once
+
+func initOIDC() {
+	if !Config().OIDC() {
+		return
+	}
+
+	services.OIDC = oidc.New(Config().OIDCIssuer(), Config().OIDCClient(), Config().OIDCSecret(), Config().OIDCRedirectUrl())
+}
+
+// OIDC returns the OpenID Connect provider or nil if single sign-on is not configured.
+func OIDC() *oidc.Provider {
+	onceOIDC.Do(initOIDC)
+
+	return services.OIDC
+}
@@ -5,7 +5,9 @@ import (
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/nsfw"
+	"github.com/photoprism/photoprism/internal/oidc"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/session"
@@ -18,14 +20,17 @@ var services struct {
 	Cache    *bigcache.BigCache
 	Classify *classify.TensorFlow
 	Convert  *photoprism.Convert
+	FaceNet  *face.Net
+	Faces    *photoprism.Faces
 	Import   *photoprism.Import
 	Index    *photoprism.Index
 	Moments  *photoprism.Moments
 	Purge    *photoprism.Purge
 	Nsfw     *nsfw.Detector
+	OIDC     *oidc.Provider
 	Query    *query.Query
 	Resample *photoprism.Resample
-	Session  *session.Session
+	Session  session.Store
 }
 
 func SetConfig(c *config.Config) {
@@ -50,6 +50,10 @@ func TestMoments(t *testing.T) {
 	assert.IsType(t, &photoprism.Moments{}, Moments())
 }
 
+func TestFaces(t *testing.T) {
+	assert.IsType(t, &photoprism.Faces{}, Faces())
+}
+
 func TestPurge(t *testing.T) {
 	assert.IsType(t, &photoprism.Purge{}, Purge())
 }
@@ -4,6 +4,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/session"
 )
 
@@ -11,10 +12,16 @@ var onceSession sync.Once
 
 func initSession() {
 	// keep sessions for 7 days by default
-	services.Session = session.New(168*time.Hour, Config().CachePath())
+	expiration := 168 * time.Hour
+
+	if Config().SessionStore() == config.SessionStoreMemory {
+		services.Session = session.New(expiration, Config().CachePath())
+	} else {
+		services.Session = session.NewDbStore(expiration)
+	}
 }
 
-func Session() *session.Session {
+func Session() session.Store {
 	onceSession.Do(initSession)
 
 	return services.Session
@@ -19,9 +19,15 @@ func (list UIDs) String() string {
 }
 
 type Data struct {
-	User   entity.Person `json:"user"`   // Session user, guest or anonymous person.
-	Tokens []string      `json:"tokens"` // Slice of secret share tokens.
-	Shares UIDs          `json:"shares"` // Slice of shared entity UIDs.
+	User     entity.Person `json:"user"`     // Session user, guest or anonymous person.
+	Tokens   []string      `json:"tokens"`   // Slice of secret share tokens.
+	Shares   UIDs          `json:"shares"`   // Slice of shared entity UIDs.
+	Uploads  UIDs          `json:"uploads"`  // Slice of shared album UIDs guests may upload to.
+	Comments UIDs          `json:"comments"` // Slice of shared entity UIDs guests may comment on.
+	Links    UIDs          `json:"links"`    // Slice of share link UIDs.
+
+	UserAgent string `json:"-"` // Client user agent.
+	ClientIP  string `json:"-"` // Client IP address.
 }
 
 func (s Data) Saved() Saved {
@@ -53,3 +59,116 @@ func (s Data) HasShare(uid string) bool {
 
 	return false
 }
+
+// CanUpload returns true if a guest may upload files into the shared album.
+func (s Data) CanUpload(uid string) bool {
+	for _, album := range s.Uploads {
+		if album == uid {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CanComment returns true if a guest may comment on the shared entity.
+func (s Data) CanComment(uid string) bool {
+	for _, share := range s.Comments {
+		if share == uid {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasLink returns true if the session was opened with the share link.
+func (s Data) HasLink(uid string) bool {
+	if uid == "" {
+		return false
+	}
+
+	for _, link := range s.Links {
+		if link == uid {
+			return true
+		}
+	}
+
+	return false
+}
+
+// UploadLink returns the UID of the share link that permits uploads to the shared album, if any.
+func (s Data) UploadLink(uid string) string {
+	for _, token := range s.Tokens {
+		for _, link := range entity.FindActiveLinks(token) {
+			if link.ShareUID == uid && link.CanUpload {
+				return link.LinkUID
+			}
+		}
+	}
+
+	return ""
+}
+
+// AddToken adds a secret share token if it doesn't exist yet.
+func (s *Data) AddToken(token string) {
+	for _, t := range s.Tokens {
+		if t == token {
+			return
+		}
+	}
+
+	s.Tokens = append(s.Tokens, token)
+}
+
+// RefreshShares updates shares based on the secret tokens, so that shares are removed once their links expire.
+func (s *Data) RefreshShares() {
+	var tokens []string
+
+	s.Shares = UIDs{}
+	s.Uploads = UIDs{}
+	s.Comments = UIDs{}
+	s.Links = UIDs{}
+
+	for _, token := range s.Tokens {
+		links := entity.FindActiveLinks(token)
+
+		if len(links) == 0 {
+			continue
+		}
+
+		tokens = append(tokens, token)
+
+		for _, link := range links {
+			s.Shares = append(s.Shares, link.ShareUID)
+			s.Links = append(s.Links, link.LinkUID)
+
+			if link.CanUpload {
+				s.Uploads = append(s.Uploads, link.ShareUID)
+			}
+
+			if link.CanComment {
+				s.Comments = append(s.Comments, link.ShareUID)
+			}
+		}
+	}
+
+	s.Tokens = tokens
+}
+
+// Restricted returns true if the user may only access own and shared content.
+func (s Data) Restricted() bool {
+	return !s.User.Admin()
+}
+
+// Owns returns true if the user has unrestricted access or owns the entity. Guests never own
+// entities, as all share links use the same guest account.
+func (s Data) Owns(ownerUID string) bool {
+	if !s.Restricted() {
+		return true
+	} else if s.Guest() {
+		return false
+	}
+
+	return ownerUID != "" && ownerUID == s.User.PersonUID
+}
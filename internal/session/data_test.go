@@ -0,0 +1,65 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestData_Restricted(t *testing.T) {
+	t.Run("admin", func(t *testing.T) {
+		data := Data{User: entity.Admin}
+		assert.False(t, data.Restricted())
+	})
+
+	t.Run("guest", func(t *testing.T) {
+		data := Data{User: entity.Guest}
+		assert.True(t, data.Restricted())
+	})
+}
+
+func TestData_Owns(t *testing.T) {
+	t.Run("admin", func(t *testing.T) {
+		data := Data{User: entity.Admin}
+		assert.True(t, data.Owns("u000000000000099"))
+		assert.True(t, data.Owns(""))
+	})
+
+	t.Run("guest", func(t *testing.T) {
+		data := Data{User: entity.Guest}
+		assert.False(t, data.Owns("u000000000000099"))
+		assert.False(t, data.Owns(""))
+		assert.False(t, data.Owns(entity.Guest.PersonUID))
+	})
+}
+
+func TestData_HasLink(t *testing.T) {
+	data := Data{User: entity.Guest, Links: UIDs{"s000000000000001"}}
+
+	assert.True(t, data.HasLink("s000000000000001"))
+	assert.False(t, data.HasLink("s000000000000002"))
+	assert.False(t, data.HasLink(""))
+}
+
+func TestData_RefreshShares(t *testing.T) {
+	t.Run("valid token", func(t *testing.T) {
+		data := Data{User: entity.Guest, Tokens: []string{"1jxf3jfn2k"}}
+		data.RefreshShares()
+		assert.Equal(t, []string{"1jxf3jfn2k"}, data.Tokens)
+		assert.True(t, data.HasShare("st9lxuqxpogaaba7"))
+		assert.False(t, data.CanUpload("st9lxuqxpogaaba7"))
+		assert.True(t, data.CanComment("st9lxuqxpogaaba7"))
+		assert.Len(t, data.Links, 1)
+		assert.Equal(t, "", data.UploadLink("st9lxuqxpogaaba7"))
+		assert.True(t, data.Valid())
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		data := Data{User: entity.Guest, Tokens: []string{"xxx"}}
+		data.RefreshShares()
+		assert.Empty(t, data.Tokens)
+		assert.True(t, data.NoShares())
+		assert.True(t, data.Invalid())
+	})
+}
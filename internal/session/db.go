@@ -0,0 +1,167 @@
+package session
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// Minimum time between two last activity updates of the same session.
+const activityInterval = time.Minute
+
+// DbStore represents a session store that keeps sessions in the database,
+// so that they survive restarts, can be shared by multiple instances and revoked.
+type DbStore struct {
+	expiration time.Duration
+}
+
+// NewDbStore returns a new database session store.
+func NewDbStore(expiration time.Duration) *DbStore {
+	return &DbStore{expiration: expiration}
+}
+
+// Create saves a new session and returns the secret session id.
+func (s *DbStore) Create(data Data) string {
+	if n := entity.DeleteExpiredSessions(); n > 0 {
+		log.Debugf("session: removed %d expired sessions", n)
+	}
+
+	id := NewID()
+	m := entity.NewSession(id, data.User.PersonUID, s.expiration)
+	setSessionData(&m, data)
+
+	if err := m.Create(); err != nil {
+		log.Errorf("session: %s (create)", err)
+	} else {
+		log.Debugf("session: created")
+	}
+
+	return id
+}
+
+// Update replaces the data of an existing session.
+func (s *DbStore) Update(id string, data Data) error {
+	if id == "" {
+		return fmt.Errorf("session: empty id")
+	}
+
+	m := entity.FindSession(id)
+
+	if m == nil || m.Expired() {
+		return fmt.Errorf("session: %s not found (update)", id)
+	}
+
+	setSessionData(m, data)
+
+	m.ActiveAt = entity.Timestamp()
+	m.ExpiresAt = m.ActiveAt.Add(s.expiration)
+
+	if err := m.Save(); err != nil {
+		return fmt.Errorf("session: %s (update)", err.Error())
+	}
+
+	log.Debugf("session: updated")
+
+	return nil
+}
+
+// Delete removes a session.
+func (s *DbStore) Delete(id string) {
+	if m := entity.FindSession(id); m != nil {
+		if err := m.Delete(); err != nil {
+			log.Errorf("session: %s (delete)", err)
+		} else {
+			log.Debugf("session: deleted")
+		}
+	}
+}
+
+// Get returns the session data or an empty session if not found.
+func (s *DbStore) Get(id string) Data {
+	m := entity.FindSession(id)
+
+	if m == nil {
+		return Data{}
+	}
+
+	if m.Expired() {
+		s.Delete(id)
+		return Data{}
+	}
+
+	user := entity.FindPersonByUID(m.UserUID)
+
+	if user == nil || user.Disabled() {
+		return Data{}
+	}
+
+	if time.Since(m.ActiveAt) > activityInterval {
+		if err := m.UpdateActivity(s.expiration); err != nil {
+			log.Errorf("session: %s (activity)", err)
+		}
+	}
+
+	return Data{
+		User:      *user,
+		Tokens:    splitList(m.Tokens),
+		Shares:    splitList(m.Shares),
+		UserAgent: m.UserAgent,
+		ClientIP:  m.ClientIP,
+	}
+}
+
+// Exists returns true if the session exists and has not expired.
+func (s *DbStore) Exists(id string) bool {
+	m := entity.FindSession(id)
+
+	return m != nil && !m.Expired()
+}
+
+// List returns the active sessions of a user.
+func (s *DbStore) List(userUID string) entity.Sessions {
+	return entity.FindUserSessions(userUID)
+}
+
+// Revoke deletes a session of a user by its public session UID.
+func (s *DbStore) Revoke(userUID, sessionUID string) error {
+	for _, m := range entity.FindUserSessions(userUID) {
+		if m.SessionUID == sessionUID {
+			return m.Delete()
+		}
+	}
+
+	return fmt.Errorf("session: %s not found (revoke)", sessionUID)
+}
+
+// RevokeAll deletes all sessions of a user and returns their number.
+func (s *DbStore) RevokeAll(userUID string) int {
+	return entity.DeleteUserSessions(userUID)
+}
+
+// setSessionData copies session data to the database entity.
+func setSessionData(m *entity.Session, data Data) {
+	m.UserUID = data.User.PersonUID
+	m.Tokens = strings.Join(data.Tokens, ",")
+	m.Shares = data.Shares.String()
+
+	if data.UserAgent != "" {
+		m.UserAgent = data.UserAgent
+	}
+
+	if data.ClientIP != "" {
+		m.ClientIP = data.ClientIP
+	}
+}
+
+// splitList splits a comma separated list, empty values are ignored.
+func splitList(s string) (result []string) {
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
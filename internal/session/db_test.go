@@ -0,0 +1,136 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDbStore_Create(t *testing.T) {
+	s := NewDbStore(time.Hour)
+
+	id := s.Create(Data{User: entity.Admin, UserAgent: "Test", ClientIP: "127.0.0.1"})
+	assert.Equal(t, 48, len(id))
+	assert.True(t, s.Exists(id))
+
+	data := s.Get(id)
+
+	assert.True(t, data.Valid())
+	assert.Equal(t, entity.Admin.PersonUID, data.User.PersonUID)
+	assert.Equal(t, "Test", data.UserAgent)
+	assert.Equal(t, "127.0.0.1", data.ClientIP)
+}
+
+func TestDbStore_Get(t *testing.T) {
+	t.Run("disabled user", func(t *testing.T) {
+		s := NewDbStore(time.Hour)
+
+		user, err := entity.CreatePerson(form.User{UserName: "disabled-session", UserActive: true})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		id := s.Create(Data{User: *user})
+		assert.True(t, s.Get(id).Valid())
+
+		user.UserActive = false
+
+		if err := user.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, s.Get(id).Invalid())
+	})
+}
+
+func TestDbStore_Update(t *testing.T) {
+	s := NewDbStore(time.Hour)
+
+	if err := s.Update(NewID(), Data{User: entity.Admin}); err == nil {
+		t.Fatal("update should fail for unknown session id")
+	}
+
+	id := s.Create(Data{User: entity.Guest})
+
+	if err := s.Update(id, Data{User: entity.Guest, Tokens: []string{"abc"}, Shares: UIDs{"a000000000000001"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	data := s.Get(id)
+
+	assert.True(t, data.Valid())
+	assert.Equal(t, []string{"abc"}, data.Tokens)
+	assert.Equal(t, UIDs{"a000000000000001"}, data.Shares)
+}
+
+func TestDbStore_Delete(t *testing.T) {
+	s := NewDbStore(time.Hour)
+
+	id := s.Create(Data{User: entity.Admin})
+	assert.True(t, s.Exists(id))
+
+	s.Delete(id)
+
+	assert.False(t, s.Exists(id))
+	assert.True(t, s.Get(id).Invalid())
+}
+
+func TestDbStore_Revoke(t *testing.T) {
+	s := NewDbStore(time.Hour)
+	user := entity.Admin
+
+	id := s.Create(Data{User: user})
+
+	var sessionUID string
+
+	for _, m := range s.List(user.PersonUID) {
+		if m.ID == entity.SessionHash(id) {
+			sessionUID = m.SessionUID
+		}
+	}
+
+	assert.NotEmpty(t, sessionUID)
+	assert.Error(t, s.Revoke(entity.Guest.PersonUID, sessionUID))
+	assert.NoError(t, s.Revoke(user.PersonUID, sessionUID))
+	assert.False(t, s.Exists(id))
+}
+
+func TestDbStore_RevokeAll(t *testing.T) {
+	s := NewDbStore(time.Hour)
+
+	first := s.Create(Data{User: entity.Guest})
+	second := s.Create(Data{User: entity.Guest})
+
+	assert.LessOrEqual(t, 2, s.RevokeAll(entity.Guest.PersonUID))
+	assert.False(t, s.Exists(first))
+	assert.False(t, s.Exists(second))
+	assert.Empty(t, s.List(entity.Guest.PersonUID))
+}
+
+func TestSession_Revoke(t *testing.T) {
+	s := New(time.Hour, "")
+
+	id := s.Create(Data{User: entity.Admin})
+	list := s.List(entity.Admin.PersonUID)
+
+	assert.Len(t, list, 1)
+	assert.NoError(t, s.Revoke(entity.Admin.PersonUID, list[0].SessionUID))
+	assert.False(t, s.Exists(id))
+	assert.Equal(t, 0, s.RevokeAll(entity.Admin.PersonUID))
+}
+
+func TestSession_Expired(t *testing.T) {
+	s := New(time.Millisecond, "")
+
+	s.Create(Data{User: entity.Admin})
+
+	time.Sleep(5 * time.Millisecond)
+
+	s.cache.DeleteExpired()
+
+	assert.Empty(t, s.meta)
+}
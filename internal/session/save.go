@@ -15,7 +15,7 @@ var fileMutex sync.RWMutex
 
 // New returns a new session store with an optional cachePath.
 func New(expiration time.Duration, cachePath string) *Session {
-	s := &Session{}
+	s := &Session{expiration: expiration, meta: make(map[string]entity.Session)}
 
 	cleanupInterval := 15 * time.Minute
 
@@ -36,27 +36,15 @@ func New(expiration time.Duration, cachePath string) *Session {
 			for key, saved := range savedItems {
 				user := entity.FindPersonByUID(saved.User)
 
-				if user == nil {
+				if user == nil || user.Disabled() {
 					continue
 				}
 
-				var tokens []string
-				var shared []string
+				data := Data{User: *user, Tokens: saved.Tokens}
+				data.RefreshShares()
 
-				for _, token := range saved.Tokens {
-					links := entity.FindValidLinks(token, "")
-
-					if len(links) > 0 {
-						for _, link := range links {
-							shared = append(shared, link.LinkUID)
-						}
-
-						tokens = append(tokens, token)
-					}
-				}
-
-				data := Data{User: *user, Tokens: tokens, Shares: shared}
 				items[key] = gc.Item{Expiration: saved.Expiration, Object: data}
+				s.meta[key] = entity.NewSession(key, user.PersonUID, time.Until(time.Unix(0, saved.Expiration)))
 			}
 
 			s.cache = gc.NewFrom(expiration, cleanupInterval, items)
@@ -67,6 +55,11 @@ func New(expiration time.Duration, cachePath string) *Session {
 		s.cache = gc.New(expiration, cleanupInterval)
 	}
 
+	// Remove metadata of expired sessions, so that it doesn't grow indefinitely.
+	s.cache.OnEvicted(func(id string, _ interface{}) {
+		s.deleteMeta(id)
+	})
+
 	return s
 }
 
@@ -32,14 +32,33 @@ https://docs.photoprism.org/developer-guide/
 package session
 
 import (
+	"sync"
+	"time"
+
 	gc "github.com/patrickmn/go-cache"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 )
 
 var log = event.Log
 
-// Session represents a session store.
+// Store represents a session store implementation.
+type Store interface {
+	Create(data Data) string
+	Update(id string, data Data) error
+	Delete(id string)
+	Get(id string) Data
+	Exists(id string) bool
+	List(userUID string) entity.Sessions
+	Revoke(userUID, sessionUID string) error
+	RevokeAll(userUID string) int
+}
+
+// Session represents an in-memory session store with an optional file snapshot.
 type Session struct {
-	cacheFile string
-	cache     *gc.Cache
+	cacheFile  string
+	cache      *gc.Cache
+	expiration time.Duration
+	meta       map[string]entity.Session
+	metaMutex  sync.RWMutex
 }
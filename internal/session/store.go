@@ -4,11 +4,13 @@ import (
 	"fmt"
 
 	gc "github.com/patrickmn/go-cache"
+	"github.com/photoprism/photoprism/internal/entity"
 )
 
 func (s *Session) Create(data Data) string {
 	id := NewID()
 	s.cache.Set(id, data, gc.DefaultExpiration)
+	s.setMeta(id, data)
 	log.Debugf("session: created")
 
 	if err := s.Save(); err != nil {
@@ -28,7 +30,7 @@ func (s *Session) Update(id string, data Data) error {
 	}
 
 	s.cache.Set(id, data, gc.DefaultExpiration)
-
+	s.setMeta(id, data)
 	log.Debugf("session: updated")
 
 	if err := s.Save(); err != nil {
@@ -40,6 +42,7 @@ func (s *Session) Update(id string, data Data) error {
 
 func (s *Session) Delete(id string) {
 	s.cache.Delete(id)
+	s.deleteMeta(id)
 	log.Debugf("session: deleted")
 
 	if err := s.Save(); err != nil {
@@ -53,6 +56,7 @@ func (s *Session) Get(id string) Data {
 	}
 
 	if hit, ok := s.cache.Get(id); ok {
+		s.touchMeta(id)
 		return hit.(Data)
 	}
 
@@ -64,3 +68,106 @@ func (s *Session) Exists(id string) bool {
 
 	return found
 }
+
+// List returns the active sessions of a user.
+func (s *Session) List(userUID string) (result entity.Sessions) {
+	s.metaMutex.RLock()
+	defer s.metaMutex.RUnlock()
+
+	for id, m := range s.meta {
+		if m.UserUID != userUID || !s.Exists(id) {
+			continue
+		}
+
+		result = append(result, m)
+	}
+
+	return result
+}
+
+// Revoke deletes a session of a user by its public session UID.
+func (s *Session) Revoke(userUID, sessionUID string) error {
+	if id := s.findMeta(userUID, sessionUID); id != "" {
+		s.Delete(id)
+		return nil
+	}
+
+	return fmt.Errorf("session: %s not found (revoke)", sessionUID)
+}
+
+// RevokeAll deletes all sessions of a user and returns their number.
+func (s *Session) RevokeAll(userUID string) (count int) {
+	for _, id := range s.userIDs(userUID) {
+		s.Delete(id)
+		count++
+	}
+
+	return count
+}
+
+// setMeta updates the session metadata like user agent and client IP.
+func (s *Session) setMeta(id string, data Data) {
+	s.metaMutex.Lock()
+	defer s.metaMutex.Unlock()
+
+	m, ok := s.meta[id]
+
+	if !ok {
+		m = entity.NewSession(id, data.User.PersonUID, s.expiration)
+	}
+
+	m.UserUID = data.User.PersonUID
+	m.UserAgent = data.UserAgent
+	m.ClientIP = data.ClientIP
+	m.ActiveAt = entity.Timestamp()
+	m.ExpiresAt = m.ActiveAt.Add(s.expiration)
+
+	s.meta[id] = m
+}
+
+// touchMeta updates the last activity time.
+func (s *Session) touchMeta(id string) {
+	s.metaMutex.Lock()
+	defer s.metaMutex.Unlock()
+
+	if m, ok := s.meta[id]; ok {
+		m.ActiveAt = entity.Timestamp()
+		s.meta[id] = m
+	}
+}
+
+// deleteMeta removes the session metadata.
+func (s *Session) deleteMeta(id string) {
+	s.metaMutex.Lock()
+	defer s.metaMutex.Unlock()
+
+	delete(s.meta, id)
+}
+
+// findMeta returns the secret session id for a public session UID.
+func (s *Session) findMeta(userUID, sessionUID string) string {
+	s.metaMutex.RLock()
+	defer s.metaMutex.RUnlock()
+
+	for id, m := range s.meta {
+		if m.UserUID == userUID && m.SessionUID == sessionUID {
+			return id
+		}
+	}
+
+	return ""
+}
+
+// userIDs returns the secret session ids of a user.
+func (s *Session) userIDs(userUID string) (result []string) {
+	s.metaMutex.RLock()
+	defer s.metaMutex.RUnlock()
+
+	for id, m := range s.meta {
+		if m.UserUID == userUID {
+			result = append(result, id)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,188 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+const (
+	HeaderEvent     = "X-PhotoPrism-Event"
+	HeaderDelivery  = "X-PhotoPrism-Delivery"
+	HeaderSignature = "X-PhotoPrism-Signature"
+)
+
+// Payload represents the JSON request body sent to webhooks.
+type Payload struct {
+	Event string     `json:"event"`
+	Time  time.Time  `json:"time"`
+	Data  event.Data `json:"data"`
+}
+
+var client = &http.Client{Timeout: 30 * time.Second}
+var retryMutex = sync.Mutex{}
+
+// Signature returns the HMAC-SHA256 signature of a request body.
+func Signature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// RetryDelay returns the backoff delay after a number of failed attempts.
+func RetryDelay(attempts int) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	} else if attempts > 8 {
+		attempts = 8
+	}
+
+	return 30 * time.Second << uint(attempts-1)
+}
+
+// Notify adds pending deliveries of an event for all matching webhooks.
+func Notify(msg event.Message) (result entity.WebhookDeliveries) {
+	hooks := entity.MatchingWebhooks(msg.Name)
+
+	if len(hooks) == 0 {
+		return result
+	}
+
+	payload, err := json.Marshal(Payload{Event: msg.Name, Time: entity.Timestamp(), Data: msg.Fields})
+
+	if err != nil {
+		log.Errorf("webhook: %s (%s)", err, msg.Name)
+		return result
+	}
+
+	for _, hook := range hooks {
+		if m, err := entity.NewWebhookDelivery(hook.WebhookUID, msg.Name, string(payload)); err != nil {
+			log.Errorf("webhook: %s (%s)", err, msg.Name)
+		} else {
+			result = append(result, *m)
+		}
+	}
+
+	return result
+}
+
+// DeliverAll sends a list of deliveries.
+func DeliverAll(deliveries entity.WebhookDeliveries) {
+	for i := range deliveries {
+		if err := Deliver(&deliveries[i]); err != nil {
+			log.Debugf("webhook: %s", err)
+		}
+	}
+}
+
+// Retry sends pending deliveries that are due and removes old entries from the delivery log.
+func Retry() {
+	retryMutex.Lock()
+	defer retryMutex.Unlock()
+
+	DeliverAll(entity.DueWebhookDeliveries(100))
+
+	if err := entity.PurgeWebhookDeliveries(entity.Timestamp().Add(-1 * LogRetention)); err != nil {
+		log.Errorf("webhook: %s (purge log)", err)
+	}
+}
+
+// Deliver sends a pending delivery and updates its status. Failed deliveries are
+// scheduled for retry until the retry limit of the webhook has been reached.
+func Deliver(m *entity.WebhookDelivery) error {
+	if !m.Pending() {
+		return nil
+	}
+
+	hook := entity.FindWebhook(m.WebhookUID)
+
+	if hook == nil || !hook.WebhookEnabled {
+		m.DeliveryStatus = entity.DeliveryStatusFailed
+		m.DeliveryError = "webhook not found or disabled"
+		m.RetryAt = nil
+
+		return m.Save()
+	}
+
+	m.Attempts++
+
+	code, err := send(hook, m)
+
+	m.StatusCode = code
+
+	if err == nil {
+		now := entity.Timestamp()
+		m.DeliveryStatus = entity.DeliveryStatusDelivered
+		m.DeliveryError = ""
+		m.DeliveredAt = &now
+		m.RetryAt = nil
+
+		log.Debugf("webhook: delivered %s to %s", m.EventName, txt.Quote(hook.WebhookName))
+	} else {
+		m.DeliveryError = txt.Clip(err.Error(), 512)
+
+		if m.Attempts > hook.RetryLimit {
+			m.DeliveryStatus = entity.DeliveryStatusFailed
+			m.RetryAt = nil
+
+			log.Warnf("webhook: failed delivering %s to %s (%s)", m.EventName, txt.Quote(hook.WebhookName), err)
+		} else {
+			retryAt := entity.Timestamp().Add(RetryDelay(m.Attempts))
+			m.RetryAt = &retryAt
+		}
+	}
+
+	if saveErr := m.Save(); saveErr != nil {
+		return saveErr
+	}
+
+	return err
+}
+
+// send posts the signed delivery payload to the webhook url and returns the response status code.
+func send(hook *entity.Webhook, m *entity.WebhookDelivery) (int, error) {
+	body := []byte(m.Payload)
+
+	req, err := http.NewRequest(http.MethodPost, hook.WebhookURL, bytes.NewReader(body))
+
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "PhotoPrism-Webhook")
+	req.Header.Set(HeaderEvent, m.EventName)
+	req.Header.Set(HeaderDelivery, strconv.FormatUint(uint64(m.ID), 10))
+	req.Header.Set(HeaderSignature, Signature(hook.WebhookSecret, body))
+
+	resp, err := client.Do(req)
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer resp.Body.Close()
+
+	// Read the response, so that the connection can be reused.
+	_, _ = io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("%s returned status %d", txt.Quote(hook.WebhookName), resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
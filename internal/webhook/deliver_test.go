@@ -0,0 +1,178 @@
+package webhook
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+// testReceiver represents a local webhook endpoint that records requests.
+type testReceiver struct {
+	mutex      sync.Mutex
+	status     int
+	signatures []string
+	bodies     []string
+	events     []string
+}
+
+func (r *testReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	body, _ := ioutil.ReadAll(req.Body)
+
+	r.bodies = append(r.bodies, string(body))
+	r.signatures = append(r.signatures, req.Header.Get(HeaderSignature))
+	r.events = append(r.events, req.Header.Get(HeaderEvent))
+
+	w.WriteHeader(r.status)
+}
+
+func (r *testReceiver) setStatus(status int) {
+	r.mutex.Lock()
+	r.status = status
+	r.mutex.Unlock()
+}
+
+func TestSignature(t *testing.T) {
+	assert.Equal(t, "sha256=f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8", Signature("key", []byte("The quick brown fox jumps over the lazy dog")))
+	assert.NotEqual(t, Signature("key", []byte("foo")), Signature("other", []byte("foo")))
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, 30*time.Second, RetryDelay(0))
+	assert.Equal(t, 30*time.Second, RetryDelay(1))
+	assert.Equal(t, time.Minute, RetryDelay(2))
+	assert.Equal(t, 2*time.Minute, RetryDelay(3))
+	assert.Equal(t, RetryDelay(8), RetryDelay(100))
+}
+
+func TestNotify(t *testing.T) {
+	receiver := &testReceiver{status: http.StatusOK}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	hook, secret, err := entity.CreateWebhook(form.Webhook{Name: "Notify", URL: server.URL, Events: "albums.shared", Enabled: true})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer hook.Delete()
+
+	assert.Empty(t, Notify(event.Message{Name: "albums.updated", Fields: event.Data{}}))
+
+	deliveries := Notify(event.Message{Name: "albums.shared", Fields: event.Data{"entities": []string{"at9lxuqxpogaaba7"}}})
+
+	if len(deliveries) != 1 {
+		t.Fatalf("expected one delivery, found %d", len(deliveries))
+	}
+
+	DeliverAll(deliveries)
+
+	assert.Equal(t, entity.DeliveryStatusDelivered, deliveries[0].DeliveryStatus)
+	assert.Equal(t, http.StatusOK, deliveries[0].StatusCode)
+	assert.NotNil(t, deliveries[0].DeliveredAt)
+
+	if len(receiver.bodies) != 1 {
+		t.Fatalf("expected one request, found %d", len(receiver.bodies))
+	}
+
+	assert.Equal(t, "albums.shared", receiver.events[0])
+	assert.Equal(t, Signature(secret, []byte(receiver.bodies[0])), receiver.signatures[0])
+	assert.Contains(t, receiver.bodies[0], `"event":"albums.shared"`)
+	assert.Contains(t, receiver.bodies[0], `"at9lxuqxpogaaba7"`)
+}
+
+func TestDeliver(t *testing.T) {
+	t.Run("retry", func(t *testing.T) {
+		receiver := &testReceiver{status: http.StatusInternalServerError}
+		server := httptest.NewServer(receiver)
+		defer server.Close()
+
+		hook, _, err := entity.CreateWebhook(form.Webhook{Name: "Retry", URL: server.URL, Events: "photos.*", Enabled: true, RetryLimit: 1})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer hook.Delete()
+
+		m, err := entity.NewWebhookDelivery(hook.WebhookUID, "photos.created", `{"event":"photos.created"}`)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Error(t, Deliver(m))
+		assert.Equal(t, entity.DeliveryStatusPending, m.DeliveryStatus)
+		assert.Equal(t, http.StatusInternalServerError, m.StatusCode)
+		assert.Equal(t, 1, m.Attempts)
+		assert.NotNil(t, m.RetryAt)
+
+		receiver.setStatus(http.StatusNoContent)
+
+		assert.NoError(t, Deliver(m))
+		assert.Equal(t, entity.DeliveryStatusDelivered, m.DeliveryStatus)
+		assert.Equal(t, 2, m.Attempts)
+		assert.Nil(t, m.RetryAt)
+		assert.Len(t, receiver.bodies, 2)
+
+		deliveries := entity.FindWebhookDeliveries(hook.WebhookUID, 10, 0)
+
+		assert.Len(t, deliveries, 1)
+		assert.Equal(t, entity.DeliveryStatusDelivered, deliveries[0].DeliveryStatus)
+	})
+	t.Run("retry limit", func(t *testing.T) {
+		receiver := &testReceiver{status: http.StatusBadGateway}
+		server := httptest.NewServer(receiver)
+		defer server.Close()
+
+		hook, _, err := entity.CreateWebhook(form.Webhook{Name: "Limit", URL: server.URL, Events: "photos.*", Enabled: true, RetryLimit: -1})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer hook.Delete()
+
+		m, err := entity.NewWebhookDelivery(hook.WebhookUID, "photos.archived", `{}`)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Error(t, Deliver(m))
+		assert.Equal(t, entity.DeliveryStatusFailed, m.DeliveryStatus)
+		assert.Nil(t, m.RetryAt)
+		assert.NoError(t, Deliver(m))
+		assert.Len(t, receiver.bodies, 1)
+	})
+	t.Run("disabled", func(t *testing.T) {
+		hook, _, err := entity.CreateWebhook(form.Webhook{Name: "Disabled", URL: "http://localhost:1/", Events: "*", Enabled: false})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer hook.Delete()
+
+		m, err := entity.NewWebhookDelivery(hook.WebhookUID, "photos.updated", `{}`)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NoError(t, Deliver(m))
+		assert.Equal(t, entity.DeliveryStatusFailed, m.DeliveryStatus)
+		assert.Equal(t, 0, m.Attempts)
+	})
+}
@@ -0,0 +1,67 @@
+/*
+Package webhook delivers library events to external HTTP endpoints.
+
+Payloads are JSON encoded and signed with the webhook secret, so that receivers can verify them:
+
+	X-PhotoPrism-Signature: sha256=<hex encoded HMAC-SHA256 of the request body>
+
+Failed deliveries are retried with exponential backoff, all attempts are kept in the delivery log.
+*/
+package webhook
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+var log = event.Log
+var stop = make(chan bool, 1)
+
+// Topics lists the event topics webhooks can subscribe to.
+var Topics = []string{
+	"photos.*",
+	"memories.*",
+	"albums.*",
+	"labels.*",
+	"import.*",
+	"index.*",
+	"upload.*",
+	"sync.*",
+}
+
+// RetryInterval is the interval in which pending deliveries are retried.
+var RetryInterval = 30 * time.Second
+
+// LogRetention is the time after which deliveries are removed from the log.
+var LogRetention = 30 * 24 * time.Hour
+
+// Start subscribes to library events and delivers them to webhooks in the background.
+func Start() {
+	s := event.Subscribe(Topics...)
+	ticker := time.NewTicker(RetryInterval)
+
+	go func() {
+		defer event.Unsubscribe(s)
+
+		for {
+			select {
+			case <-stop:
+				log.Info("webhook: shutting down")
+				ticker.Stop()
+				return
+			case msg := <-s.Receiver:
+				if deliveries := Notify(msg); len(deliveries) > 0 {
+					go DeliverAll(deliveries)
+				}
+			case <-ticker.C:
+				go Retry()
+			}
+		}
+	}()
+}
+
+// Stop shuts down the webhook dispatcher.
+func Stop() {
+	stop <- true
+}
@@ -0,0 +1,64 @@
+package workers
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Backup represents a backup worker.
+type Backup struct {
+	conf *config.Config
+}
+
+// NewBackup returns a new backup worker.
+func NewBackup(conf *config.Config) *Backup {
+	return &Backup{conf: conf}
+}
+
+// Start creates a new index backup if the latest one is older than the backup interval
+// and removes old backups exceeding the configured count.
+func (worker *Backup) Start() error {
+	interval := worker.conf.BackupInterval()
+
+	if interval <= 0 {
+		return nil
+	}
+
+	dir := worker.conf.BackupPath()
+
+	files, err := photoprism.BackupFiles(dir)
+
+	if err != nil {
+		return err
+	}
+
+	if n := len(files); n > 0 {
+		if s, err := os.Stat(files[n-1]); err == nil && time.Since(s.ModTime()) < interval {
+			return nil
+		}
+	}
+
+	fileName := photoprism.BackupFileName(dir, time.Now())
+
+	if _, err := photoprism.NewBackup(worker.conf).Start(fileName, worker.conf.SidecarYaml()); err != nil {
+		return err
+	}
+
+	files = append(files, fileName)
+
+	// Remove the oldest backups.
+	for i := 0; i < len(files)-worker.conf.BackupCount(); i++ {
+		if err := os.Remove(files[i]); err != nil {
+			log.Errorf("backup: %s", err)
+		} else {
+			log.Infof("backup: removed %s", txt.Quote(filepath.Base(files[i])))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,16 @@
+package workers
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBackup(t *testing.T) {
+	conf := config.TestConfig()
+
+	worker := NewBackup(conf)
+
+	assert.IsType(t, &Backup{}, worker)
+}
@@ -0,0 +1,82 @@
+package workers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+// Memories represents a worker that picks photos taken on this day in previous years as daily highlights.
+// Highlights of restricted users are picked from their own memories, see query.HighlightsOnDay.
+type Memories struct {
+	conf *config.Config
+}
+
+// NewMemories returns a new memories worker.
+func NewMemories(conf *config.Config) *Memories {
+	return &Memories{conf: conf}
+}
+
+// Start picks the highlights of the current day unless they already exist, removes highlights
+// of previous days and publishes a memories.today event if there are new highlights.
+func (worker *Memories) Start() (err error) {
+	if err := mutex.MemoriesWorker.Start(); err != nil {
+		return fmt.Errorf("memories: %s", err.Error())
+	}
+
+	defer mutex.MemoriesWorker.Stop()
+
+	now := time.Now()
+
+	if highlights, err := entity.FindHighlights(now); err != nil {
+		return fmt.Errorf("memories: %s", err.Error())
+	} else if len(highlights) > 0 {
+		return nil
+	}
+
+	if err := entity.DeleteHighlights(now); err != nil {
+		log.Errorf("memories: %s", err)
+	}
+
+	results, err := query.MemoriesOnDay(form.MemorySearch{
+		Year:  now.Year(),
+		Month: int(now.Month()),
+		Day:   now.Day(),
+	})
+
+	if err != nil {
+		return fmt.Errorf("memories: %s", err.Error())
+	}
+
+	highlights := results.Highlights(query.HighlightCount)
+
+	if len(highlights) == 0 {
+		return nil
+	}
+
+	count := 0
+
+	for _, m := range highlights {
+		if err := entity.NewHighlight(now, m.PhotoUID, m.YearsAgo).Create(); err != nil {
+			log.Errorf("memories: %s", err)
+		} else {
+			count++
+		}
+	}
+
+	log.Infof("memories: picked %d highlights for %s", count, now.Format(entity.HighlightDateFormat))
+
+	// Photo UIDs are not included, as not all users may see them.
+	event.Publish("memories.today", event.Data{
+		"date":  now.Format(entity.HighlightDateFormat),
+		"count": count,
+	})
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package workers
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMemories(t *testing.T) {
+	conf := config.TestConfig()
+
+	worker := NewMemories(conf)
+
+	assert.IsType(t, &Memories{}, worker)
+}
+
+func TestMemories_Start(t *testing.T) {
+	conf := config.TestConfig()
+
+	worker := NewMemories(conf)
+
+	if err := worker.Start(); err != nil {
+		t.Fatal(err)
+	}
+}
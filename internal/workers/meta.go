@@ -119,6 +119,12 @@ func (worker *Meta) Start() (err error) {
 		log.Error(err)
 	}
 
+	faces := photoprism.NewFaces(worker.conf)
+
+	if err := faces.Start(); err != nil {
+		log.Error(err)
+	}
+
 	runtime.GC()
 
 	return nil
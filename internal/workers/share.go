@@ -12,7 +12,6 @@ import (
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/remote"
-	"github.com/photoprism/photoprism/internal/remote/webdav"
 	"github.com/photoprism/photoprism/internal/thumb"
 )
 
@@ -55,7 +54,7 @@ func (worker *Share) Start() (err error) {
 			return nil
 		}
 
-		if a.AccType != remote.ServiceWebDAV {
+		if !remote.CanSync(a.AccType) {
 			continue
 		}
 
@@ -71,7 +70,13 @@ func (worker *Share) Start() (err error) {
 			continue
 		}
 
-		client := webdav.New(a.AccURL, a.AccUser, a.AccPass)
+		client, err := a.Client()
+
+		if err != nil {
+			worker.logError(err)
+			continue
+		}
+
 		existingDirs := make(map[string]string)
 
 		for _, file := range files {
@@ -135,7 +140,7 @@ func (worker *Share) Start() (err error) {
 			return nil
 		}
 
-		if a.AccType != remote.ServiceWebDAV {
+		if !remote.CanSync(a.AccType) {
 			continue
 		}
 
@@ -151,7 +156,12 @@ func (worker *Share) Start() (err error) {
 			continue
 		}
 
-		client := webdav.New(a.AccURL, a.AccUser, a.AccPass)
+		client, err := a.Client()
+
+		if err != nil {
+			worker.logError(err)
+			continue
+		}
 
 		for _, file := range files {
 			if mutex.ShareWorker.Canceled() {
@@ -55,7 +55,7 @@ func (worker *Sync) Start() (err error) {
 	accounts, err := query.AccountSearch(f)
 
 	for _, a := range accounts {
-		if a.AccType != remote.ServiceWebDAV {
+		if !remote.CanSync(a.AccType) {
 			continue
 		}
 
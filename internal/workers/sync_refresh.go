@@ -4,17 +4,21 @@ import (
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/remote"
-	"github.com/photoprism/photoprism/internal/remote/webdav"
 	"github.com/photoprism/photoprism/pkg/fs"
 )
 
 // Updates the local list of remote files so that they can be downloaded in batches
 func (worker *Sync) refresh(a entity.Account) (complete bool, err error) {
-	if a.AccType != remote.ServiceWebDAV {
+	if !remote.CanSync(a.AccType) {
 		return false, nil
 	}
 
-	client := webdav.New(a.AccURL, a.AccUser, a.AccPass)
+	client, err := a.Client()
+
+	if err != nil {
+		log.Error(err)
+		return false, err
+	}
 
 	subDirs, err := client.Directories(a.SyncPath, true)
 
@@ -47,6 +51,7 @@ func (worker *Sync) refresh(a entity.Account) (complete bool, err error) {
 			f.Status = entity.FileSyncIgnore
 			f.RemoteDate = file.Date
 			f.RemoteSize = file.Size
+			f.RemoteETag = file.ETag
 
 			// Select supported types for download
 			mediaType := fs.GetMediaType(file.Name)
@@ -72,15 +77,28 @@ func (worker *Sync) refresh(a entity.Account) (complete bool, err error) {
 				worker.logError(f.Update("Status", entity.FileSyncNew))
 			}
 
-			if f.Status == entity.FileSyncDownloaded && !f.RemoteDate.Equal(file.Date) {
+			if f.Status == entity.FileSyncDownloaded && remoteChanged(f, file) {
 				worker.logError(f.Updates(map[string]interface{}{
 					"Status":     entity.FileSyncNew,
 					"RemoteDate": file.Date,
 					"RemoteSize": file.Size,
+					"RemoteETag": file.ETag,
 				}))
+			} else if f.RemoteETag == "" && file.ETag != "" {
+				worker.logError(f.Update("RemoteETag", file.ETag))
 			}
 		}
 	}
 
 	return true, nil
 }
+
+// remoteChanged returns true if a remote file was modified since it was last synced. The ETag is compared
+// if both are known, as it also detects changes of files that were replaced without updating the date.
+func remoteChanged(f *entity.FileSync, file fs.FileInfo) bool {
+	if f.RemoteETag != "" && file.ETag != "" {
+		return f.RemoteETag != file.ETag
+	}
+
+	return !f.RemoteDate.Equal(file.Date)
+}
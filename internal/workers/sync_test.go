@@ -2,8 +2,11 @@ package workers
 
 import (
 	"testing"
+	"time"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -14,3 +17,20 @@ func TestNewSync(t *testing.T) {
 
 	assert.IsType(t, &Sync{}, worker)
 }
+
+func TestRemoteChanged(t *testing.T) {
+	date := time.Date(2020, 10, 17, 12, 0, 0, 0, time.UTC)
+
+	t.Run("etag", func(t *testing.T) {
+		f := &entity.FileSync{RemoteDate: date, RemoteETag: "abc"}
+
+		assert.False(t, remoteChanged(f, fs.FileInfo{Date: date.Add(time.Hour), ETag: "abc"}))
+		assert.True(t, remoteChanged(f, fs.FileInfo{Date: date, ETag: "def"}))
+	})
+	t.Run("date", func(t *testing.T) {
+		f := &entity.FileSync{RemoteDate: date}
+
+		assert.False(t, remoteChanged(f, fs.FileInfo{Date: date, ETag: "abc"}))
+		assert.True(t, remoteChanged(f, fs.FileInfo{Date: date.Add(time.Hour)}))
+	})
+}
@@ -10,7 +10,6 @@ import (
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
-	"github.com/photoprism/photoprism/internal/remote/webdav"
 )
 
 // Uploads local files to a remote account
@@ -30,7 +29,13 @@ func (worker *Sync) upload(a entity.Account) (complete bool, err error) {
 		return true, nil
 	}
 
-	client := webdav.New(a.AccURL, a.AccUser, a.AccPass)
+	client, err := a.Client()
+
+	if err != nil {
+		worker.logError(err)
+		return false, err
+	}
+
 	existingDirs := make(map[string]string)
 
 	for _, file := range files {
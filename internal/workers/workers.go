@@ -24,11 +24,15 @@ func Start(conf *config.Config) {
 				mutex.MetaWorker.Cancel()
 				mutex.ShareWorker.Cancel()
 				mutex.SyncWorker.Cancel()
+				mutex.BackupWorker.Cancel()
+				mutex.MemoriesWorker.Cancel()
 				return
 			case <-ticker.C:
 				StartMeta(conf)
 				StartShare(conf)
 				StartSync(conf)
+				StartBackup(conf)
+				StartMemories(conf)
 			}
 		}
 	}()
@@ -74,3 +78,27 @@ func StartSync(conf *config.Config) {
 		}()
 	}
 }
+
+// StartBackup runs the backup worker once.
+func StartBackup(conf *config.Config) {
+	if !mutex.BackupWorker.Busy() {
+		go func() {
+			worker := NewBackup(conf)
+			if err := worker.Start(); err != nil {
+				log.Error(err)
+			}
+		}()
+	}
+}
+
+// StartMemories runs the memories worker once.
+func StartMemories(conf *config.Config) {
+	if !mutex.MemoriesWorker.Busy() {
+		go func() {
+			worker := NewMemories(conf)
+			if err := worker.Start(); err != nil {
+				log.Error(err)
+			}
+		}()
+	}
+}
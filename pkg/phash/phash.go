@@ -0,0 +1,197 @@
+/*
+
+Package phash implements perceptual image hashes to find similar images.
+
+Copyright (c) 2018 - 2020 Michael Mayer <hello@photoprism.org>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism™ is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.org if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.org/developer-guide/
+
+*/
+package phash
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"math/bits"
+	"sort"
+	"strconv"
+)
+
+// Hash represents a 64 bit perceptual image hash.
+type Hash uint64
+
+// String returns the hash as hex string with 16 characters.
+func (h Hash) String() string {
+	return fmt.Sprintf("%016x", uint64(h))
+}
+
+// Distance returns the number of different bits, similar images have a small distance.
+func (h Hash) Distance(other Hash) int {
+	return bits.OnesCount64(uint64(h ^ other))
+}
+
+// Parse returns the hash for a hex string as returned by String().
+func Parse(s string) (Hash, error) {
+	if len(s) != 16 {
+		return 0, fmt.Errorf("phash: invalid hash %q", s)
+	}
+
+	v, err := strconv.ParseUint(s, 16, 64)
+
+	if err != nil {
+		return 0, fmt.Errorf("phash: invalid hash %q", s)
+	}
+
+	return Hash(v), nil
+}
+
+// DHash returns the difference hash of an image, it compares the brightness of adjacent pixels.
+func DHash(img image.Image) Hash {
+	pixels := grayscale(img, 9, 8)
+
+	var h Hash
+
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			h <<= 1
+
+			if pixels[y*9+x] < pixels[y*9+x+1] {
+				h |= 1
+			}
+		}
+	}
+
+	return h
+}
+
+// PHash returns the DCT based perceptual hash of an image, it's more robust against
+// changes in brightness, contrast and compression than the difference hash.
+func PHash(img image.Image) Hash {
+	const size = 32
+
+	pixels := grayscale(img, size, size)
+	freq := dct(pixels, size)
+
+	// Use the lowest 8x8 frequencies, without the average in the top left corner.
+	values := make([]float64, 0, 64)
+
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			values = append(values, freq[y*size+x])
+		}
+	}
+
+	sorted := append([]float64{}, values[1:]...)
+	sort.Float64s(sorted)
+	median := (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+
+	var h Hash
+
+	for _, v := range values {
+		h <<= 1
+
+		if v > median {
+			h |= 1
+		}
+	}
+
+	return h
+}
+
+// grayscale returns the luminance of an image scaled to width x height using area averaging.
+func grayscale(img image.Image, width, height int) []float64 {
+	b := img.Bounds()
+	result := make([]float64, width*height)
+	counts := make([]float64, width*height)
+
+	if b.Dx() <= 0 || b.Dy() <= 0 {
+		return result
+	}
+
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		ty := (y - b.Min.Y) * height / b.Dy()
+
+		for x := b.Min.X; x < b.Max.X; x++ {
+			tx := (x - b.Min.X) * width / b.Dx()
+
+			r, g, bl, _ := img.At(x, y).RGBA()
+
+			i := ty*width + tx
+			result[i] += 0.299*float64(r) + 0.587*float64(g) + 0.114*float64(bl)
+			counts[i]++
+		}
+	}
+
+	// Images smaller than the target size leave some values empty.
+	for i := range result {
+		if counts[i] > 0 {
+			result[i] /= counts[i]
+		}
+	}
+
+	return result
+}
+
+// dct returns the two dimensional discrete cosine transform (DCT-II) of a size x size matrix.
+func dct(pixels []float64, size int) []float64 {
+	cos := make([]float64, size*size)
+
+	for k := 0; k < size; k++ {
+		for n := 0; n < size; n++ {
+			cos[k*size+n] = math.Cos(math.Pi / float64(size) * (float64(n) + 0.5) * float64(k))
+		}
+	}
+
+	rows := make([]float64, size*size)
+
+	for y := 0; y < size; y++ {
+		for k := 0; k < size; k++ {
+			var sum float64
+
+			for n := 0; n < size; n++ {
+				sum += pixels[y*size+n] * cos[k*size+n]
+			}
+
+			rows[y*size+k] = sum
+		}
+	}
+
+	result := make([]float64, size*size)
+
+	for x := 0; x < size; x++ {
+		for k := 0; k < size; k++ {
+			var sum float64
+
+			for n := 0; n < size; n++ {
+				sum += rows[n*size+x] * cos[k*size+n]
+			}
+
+			result[k*size+x] = sum
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,101 @@
+package phash
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testImage returns a test image with a pattern that depends on the seed.
+func testImage(width, height, seed int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			v := uint8((x*255/width + (y*seed*255/height)%256) % 256)
+
+			if (x*8/width+y*4/height*seed)%3 == 0 {
+				v = 255 - v
+			}
+
+			img.Set(x, y, color.RGBA{R: v, G: v / 2, B: 255 - v, A: 255})
+		}
+	}
+
+	return img
+}
+
+// brighten returns a copy of the image with increased brightness.
+func brighten(img image.Image, delta uint8) image.Image {
+	b := img.Bounds()
+	result := image.NewRGBA(b)
+
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c := color.RGBAModel.Convert(img.At(x, y)).(color.RGBA)
+
+			add := func(v uint8) uint8 {
+				if int(v)+int(delta) > 255 {
+					return 255
+				}
+
+				return v + delta
+			}
+
+			result.Set(x, y, color.RGBA{R: add(c.R), G: add(c.G), B: add(c.B), A: 255})
+		}
+	}
+
+	return result
+}
+
+func TestHash_String(t *testing.T) {
+	h := Hash(0xf0e1d2c3b4a59687)
+
+	assert.Equal(t, "f0e1d2c3b4a59687", h.String())
+	assert.Equal(t, "0000000000000001", Hash(1).String())
+
+	result, err := Parse(h.String())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, h, result)
+
+	_, err = Parse("foo")
+
+	assert.Error(t, err)
+}
+
+func TestHash_Distance(t *testing.T) {
+	assert.Equal(t, 0, Hash(0xff).Distance(0xff))
+	assert.Equal(t, 8, Hash(0xff).Distance(0))
+	assert.Equal(t, 64, Hash(0).Distance(^Hash(0)))
+}
+
+func TestDHash(t *testing.T) {
+	img := testImage(640, 480, 1)
+	small := testImage(160, 120, 1)
+	other := testImage(640, 480, 3)
+
+	h := DHash(img)
+
+	assert.LessOrEqual(t, h.Distance(DHash(small)), 6)
+	assert.LessOrEqual(t, h.Distance(DHash(brighten(img, 20))), 6)
+	assert.Greater(t, h.Distance(DHash(other)), 10)
+}
+
+func TestPHash(t *testing.T) {
+	img := testImage(640, 480, 1)
+	small := testImage(160, 120, 1)
+	other := testImage(640, 480, 3)
+
+	h := PHash(img)
+
+	assert.LessOrEqual(t, h.Distance(PHash(small)), 6)
+	assert.LessOrEqual(t, h.Distance(PHash(brighten(img, 20))), 6)
+	assert.Greater(t, h.Distance(PHash(other)), 10)
+}
@@ -40,6 +40,9 @@ import (
 // Default cell level, see https://s2geometry.io/resources/s2cell_statistics.html.
 var DefaultLevel = 21
 
+// EarthRadius is the mean radius of the earth in kilometers.
+const EarthRadius = 6371.0
+
 // Token returns the S2 cell token for coordinates using the default level.
 func Token(lat, lng float64) string {
 	return TokenLevel(lat, lng, DefaultLevel)
@@ -103,3 +106,11 @@ func Range(token string, levelUp int) (min, max string) {
 
 	return parent.Prev().ChildBeginAtLevel(lvl).ToToken(), parent.Next().ChildBeginAtLevel(lvl).ToToken()
 }
+
+// Distance returns the great circle distance between two coordinates in kilometers.
+func Distance(lat1, lng1, lat2, lng2 float64) float64 {
+	a := gs2.LatLngFromDegrees(lat1, lng1)
+	b := gs2.LatLngFromDegrees(lat2, lng2)
+
+	return a.Distance(b).Radians() * EarthRadius
+}
@@ -164,3 +164,14 @@ func TestRange(t *testing.T) {
 		assert.Equal(t, "", max)
 	})
 }
+
+func TestDistance(t *testing.T) {
+	t.Run("berlin_lisbon", func(t *testing.T) {
+		result := Distance(52.520008, 13.404954, 38.722252, -9.139337)
+		assert.InDelta(t, 2312, result, 10)
+	})
+	t.Run("same", func(t *testing.T) {
+		result := Distance(48.56344833333333, 8.996878333333333, 48.56344833333333, 8.996878333333333)
+		assert.Equal(t, 0.0, result)
+	})
+}
@@ -0,0 +1,89 @@
+package totp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Shared secret from RFC 6238, Appendix B.
+var rfcSecret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestCode(t *testing.T) {
+	// Test vectors from RFC 6238, Appendix B truncated to 6 digits.
+	vectors := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+
+	for ts, expected := range vectors {
+		code, err := Code(rfcSecret, time.Unix(ts, 0))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, expected, code, "time %d", ts)
+	}
+
+	t.Run("invalid secret", func(t *testing.T) {
+		_, err := Code("!!!", time.Now())
+		assert.Error(t, err)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+
+	t.Run("current", func(t *testing.T) {
+		counter, ok := Validate("050471", rfcSecret, now)
+		assert.True(t, ok)
+		assert.Equal(t, Counter(now), counter)
+	})
+	t.Run("previous step", func(t *testing.T) {
+		_, ok := Validate("050471", rfcSecret, now.Add(Period))
+		assert.True(t, ok)
+	})
+	t.Run("expired", func(t *testing.T) {
+		_, ok := Validate("050471", rfcSecret, now.Add(3*Period))
+		assert.False(t, ok)
+	})
+	t.Run("wrong length", func(t *testing.T) {
+		_, ok := Validate("0504", rfcSecret, now)
+		assert.False(t, ok)
+	})
+}
+
+func TestNewSecret(t *testing.T) {
+	secret, err := NewSecret()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 32, len(secret))
+
+	code, err := Code(secret, time.Now())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, ok := Validate(code, secret, time.Now())
+	assert.True(t, ok)
+}
+
+func TestURL(t *testing.T) {
+	url := URL("PhotoPrism", "admin", "JBSWY3DPEHPK3PXP")
+
+	assert.True(t, strings.HasPrefix(url, "otpauth://totp/PhotoPrism:admin?"))
+	assert.Contains(t, url, "secret=JBSWY3DPEHPK3PXP")
+	assert.Contains(t, url, "issuer=PhotoPrism")
+}
@@ -0,0 +1,118 @@
+package txt
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Stemming is supported for English and German only. Words in other languages are returned in
+// lower case without changes, as suffix rules of one language would break words of another.
+//
+// English words are stemmed with a reduced set of Porter rules for plurals and verb forms.
+// German support is limited to unambiguous plural endings like "-ungen".
+var stemmers = map[string]func(string) string{
+	"en": stemEnglish,
+	"de": stemGerman,
+}
+
+// germanSuffixes maps unambiguous German plural endings to their singular form.
+var germanSuffixes = [][2]string{
+	{"ungen", "ung"},
+	{"heiten", "heit"},
+	{"keiten", "keit"},
+	{"innen", "in"},
+}
+
+// StemLang returns the two letter code of a language like "de_DE", or an empty string if
+// stemming is not supported for it.
+func StemLang(lang string) string {
+	lang = strings.ToLower(lang)
+
+	if len(lang) > 2 {
+		lang = lang[:2]
+	}
+
+	if _, ok := stemmers[lang]; !ok {
+		return ""
+	}
+
+	return lang
+}
+
+// Stem returns the stem of a word in the given language for full-text indexing,
+// e.g. "flowers" becomes "flower" in English, see StemLang for supported languages.
+func Stem(w, lang string) string {
+	w = strings.ToLower(w)
+
+	if utf8.RuneCountInString(w) < 4 {
+		return w
+	}
+
+	if stem, ok := stemmers[StemLang(lang)]; ok {
+		return stem(w)
+	}
+
+	return w
+}
+
+// StemKeywords returns the stems of all keywords in a string, see Keywords.
+func StemKeywords(s, lang string) (results []string) {
+	for _, w := range Keywords(s) {
+		results = append(results, Stem(w, lang))
+	}
+
+	return results
+}
+
+// stemGerman removes unambiguous German plural endings.
+func stemGerman(w string) string {
+	for _, s := range germanSuffixes {
+		if strings.HasSuffix(w, s[0]) && len(w) > len(s[0])+2 {
+			return strings.TrimSuffix(w, s[0]) + s[1]
+		}
+	}
+
+	return w
+}
+
+// stemEnglish removes English plural and verb endings.
+func stemEnglish(w string) string {
+	switch {
+	case strings.HasSuffix(w, "sses"):
+		return strings.TrimSuffix(w, "es")
+	case strings.HasSuffix(w, "ies") && len(w) > 4:
+		return strings.TrimSuffix(w, "ies") + "y"
+	case strings.HasSuffix(w, "ing"):
+		if stem := strings.TrimSuffix(w, "ing"); len(stem) >= 3 && hasVowel(stem) {
+			return undouble(stem)
+		}
+	case strings.HasSuffix(w, "eed"):
+		return w
+	case strings.HasSuffix(w, "ed"):
+		if stem := strings.TrimSuffix(w, "ed"); len(stem) >= 3 && hasVowel(stem) {
+			return undouble(stem)
+		}
+	case strings.HasSuffix(w, "ss") || strings.HasSuffix(w, "us") || strings.HasSuffix(w, "is"):
+		return w
+	case strings.HasSuffix(w, "s"):
+		return strings.TrimSuffix(w, "s")
+	}
+
+	return w
+}
+
+// hasVowel returns true if the string contains a vowel.
+func hasVowel(s string) bool {
+	return strings.ContainsAny(s, "aeiouy")
+}
+
+// undouble removes a double consonant at the end of a stem, e.g. "runn" becomes "run".
+func undouble(s string) string {
+	n := len(s)
+
+	if n < 2 || s[n-1] != s[n-2] || strings.ContainsRune("aeioulsz", rune(s[n-1])) {
+		return s
+	}
+
+	return s[:n-1]
+}
@@ -0,0 +1,52 @@
+package txt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStemLang(t *testing.T) {
+	assert.Equal(t, "en", StemLang("en"))
+	assert.Equal(t, "de", StemLang("de_DE"))
+	assert.Equal(t, "de", StemLang("DE"))
+	assert.Equal(t, "", StemLang("fr"))
+	assert.Equal(t, "", StemLang(""))
+}
+
+func TestStem(t *testing.T) {
+	t.Run("plural", func(t *testing.T) {
+		assert.Equal(t, "flower", Stem("Flowers", "en"))
+		assert.Equal(t, "city", Stem("cities", "en"))
+		assert.Equal(t, "glass", Stem("glasses", "en"))
+		assert.Equal(t, "glass", Stem("glass", "en"))
+		assert.Equal(t, "bus", Stem("bus", "en"))
+		assert.Equal(t, "cactus", Stem("cactus", "en"))
+	})
+	t.Run("verbs", func(t *testing.T) {
+		assert.Equal(t, "walk", Stem("walking", "en"))
+		assert.Equal(t, "walk", Stem("walked", "en"))
+		assert.Equal(t, "run", Stem("running", "en"))
+		assert.Equal(t, "fall", Stem("falling", "en"))
+		assert.Equal(t, "speed", Stem("speed", "en"))
+		assert.Equal(t, "king", Stem("king", "en"))
+	})
+	t.Run("german", func(t *testing.T) {
+		assert.Equal(t, "wanderung", Stem("Wanderungen", "de"))
+		assert.Equal(t, "sehenswürdigkeit", Stem("Sehenswürdigkeiten", "de_DE"))
+		assert.Equal(t, "freundin", Stem("Freundinnen", "de"))
+		assert.Equal(t, "haus", Stem("Haus", "de"))
+	})
+	t.Run("unsupported", func(t *testing.T) {
+		assert.Equal(t, "fleurs", Stem("Fleurs", "fr"))
+		assert.Equal(t, "chiens", Stem("chiens", ""))
+	})
+	t.Run("short", func(t *testing.T) {
+		assert.Equal(t, "cat", Stem("cat", "en"))
+		assert.Equal(t, "", Stem("", "en"))
+	})
+}
+
+func TestStemKeywords(t *testing.T) {
+	assert.Equal(t, []string{"black", "cat", "sleep", "sofa"}, StemKeywords("Black cats sleeping on the Sofa", "en"))
+}